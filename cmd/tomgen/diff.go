@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around changes.
+const diffContext = 3
+
+// unifiedDiff returns the unified diff between old and new, or an empty
+// string if there are no differences.
+func unifiedDiff(oldName, newName string, old, new []byte) string {
+	a, b := splitLines(string(old)), splitLines(string(new))
+	edits := diffLines(a, b)
+
+	var out strings.Builder
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+
+		// find the end of the hunk, merging the changes which are close enough
+		// to share their context.
+		start, end := max(i-diffContext, 0), i
+		for {
+			for end < len(edits) && edits[end].op != ' ' {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].op == ' ' {
+				next++
+			}
+			if next == len(edits) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		end = min(end+diffContext, len(edits))
+		writeHunk(&out, edits[start:end], a, b)
+		i = end
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, hunk []edit, a, b []string) {
+	var oldCount, newCount int
+	for _, e := range hunk {
+		if e.op != '+' {
+			oldCount++
+		}
+		if e.op != '-' {
+			newCount++
+		}
+	}
+	oldStart, newStart := hunk[0].a, hunk[0].b
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, e := range hunk {
+		// the index of the other file may be past its end.
+		var line string
+		if e.op == '-' {
+			line = a[e.a]
+		} else {
+			line = b[e.b]
+		}
+		out.WriteByte(e.op)
+		out.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// splitLines splits s into lines, keeping the trailing newlines.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// edit is a single line of a diff. a and b are the indexes of the line in the
+// old and new file, respectively; for insertions and deletions, they are the
+// index of the next line in the file where the line is missing.
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	a, b int
+}
+
+// diffLines computes the shortest edit script from a to b, using the
+// linear-space variant of Myers' algorithm: the middle snake of the edit
+// graph splits the problem in two halves, which are diffed recursively.
+// Within each run of changes, the deletions come before the insertions.
+func diffLines(a, b []string) []edit {
+	// the values of k are bounded by the maximum D of the middle snake of the
+	// whole problem, which is also that of all the sub-problems.
+	size := (len(a)+len(b)+1)/2 + 1
+	d := differ{
+		a:   a,
+		b:   b,
+		vf:  make([]int, 2*size+1),
+		vb:  make([]int, 2*size+1),
+		off: size,
+	}
+	d.compare(0, len(a), 0, len(b))
+
+	// put the deletions of each run of changes first.
+	edits := d.edits
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		x, y, dels, j := edits[i].a, edits[i].b, 0, i
+		for ; j < len(edits) && edits[j].op != ' '; j++ {
+			if edits[j].op == '-' {
+				dels++
+			}
+		}
+		for n := i; n < j; n++ {
+			if n-i < dels {
+				edits[n] = edit{'-', x + n - i, y}
+			} else {
+				edits[n] = edit{'+', x + dels, y + n - i - dels}
+			}
+		}
+		i = j
+	}
+	return edits
+}
+
+// differ holds the state of diffLines. vf and vb contain the furthest x
+// reached on each diagonal k = x - y, offset by off, searching forwards from
+// the start and backwards from the end of the current sub-problem.
+type differ struct {
+	a, b   []string
+	vf, vb []int
+	off    int
+	edits  []edit
+}
+
+// compare appends the edits from a[a0:a1] to b[b0:b1].
+func (d *differ) compare(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.edits = append(d.edits, edit{' ', a0, b0})
+		a0, b0 = a0+1, b0+1
+	}
+	suffix := 0
+	for a0 < a1-suffix && b0 < b1-suffix && d.a[a1-suffix-1] == d.b[b1-suffix-1] {
+		suffix++
+	}
+	a1, b1 = a1-suffix, b1-suffix
+
+	switch {
+	case a0 == a1:
+		for y := b0; y < b1; y++ {
+			d.edits = append(d.edits, edit{'+', a0, y})
+		}
+	case b0 == b1:
+		for x := a0; x < a1; x++ {
+			d.edits = append(d.edits, edit{'-', x, b0})
+		}
+	default:
+		// with the common prefix and suffix removed, the distance is at
+		// least 2, so both halves are smaller than the whole.
+		x0, y0, x1, y1 := d.middleSnake(a0, a1, b0, b1)
+		d.compare(a0, x0, b0, y0)
+		for x, y := x0, y0; x < x1; x, y = x+1, y+1 {
+			d.edits = append(d.edits, edit{' ', x, y})
+		}
+		d.compare(x1, a1, y1, b1)
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.edits = append(d.edits, edit{' ', a1 + i, b1 + i})
+	}
+}
+
+// middleSnake returns the start and end of the middle snake of the shortest
+// edit script from a[a0:a1] to b[b0:b1], searching from both ends at once
+// until the paths overlap.
+func (d *differ) middleSnake(a0, a1, b0, b1 int) (x0, y0, x1, y1 int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta&1 != 0
+	vf, vb, off := d.vf, d.vb, d.off
+	vf[off+1], vb[off+1] = 0, 0
+	for dist := 0; dist <= (n+m+1)/2; dist++ {
+		for k := -dist; k <= dist; k += 2 {
+			var x int
+			if k == -dist || (k != dist && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.a[a0+x] == d.b[b0+y] {
+				x, y = x+1, y+1
+			}
+			vf[off+k] = x
+			// the backward path on diagonal k has x = n - vb[delta-k].
+			if odd && k >= delta-(dist-1) && k <= delta+(dist-1) && x+vb[off+delta-k] >= n {
+				return a0 + sx, b0 + sy, a0 + x, b0 + y
+			}
+		}
+		for k := -dist; k <= dist; k += 2 {
+			// x and y are counted from the end of a and b.
+			var x int
+			if k == -dist || (k != dist && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.a[a1-x-1] == d.b[b1-y-1] {
+				x, y = x+1, y+1
+			}
+			vb[off+k] = x
+			if !odd && delta-k >= -dist && delta-k <= dist && x+vf[off+delta-k] >= n {
+				return a1 - x, b1 - y, a1 - sx, b1 - sy
+			}
+		}
+	}
+	panic("diffLines: middle snake not found")
+}
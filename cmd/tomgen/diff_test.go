@@ -0,0 +1,131 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tt := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{name: "equal", old: "x\ny\n", new: "x\ny\n"},
+		{name: "empty"},
+		{
+			name: "change",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "extra old lines",
+			old:  "x\ny\nz\n",
+			new:  "x\n",
+			want: "--- old\n+++ new\n@@ -1,3 +1,1 @@\n x\n-y\n-z\n",
+		},
+		{
+			name: "extra new lines",
+			old:  "x\n",
+			new:  "x\ny\nz\n",
+			want: "--- old\n+++ new\n@@ -1,1 +1,3 @@\n x\n+y\n+z\n",
+		},
+		{
+			name: "new file",
+			new:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "deleted file",
+			old:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-x\n-y\n",
+		},
+		{
+			name: "no newline at end",
+			old:  "x\ny",
+			new:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n x\n-y\n\\ No newline at end of file\n+y\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "0\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n13\n",
+			want: "--- old\n+++ new\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n" +
+				"@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+13\n",
+		},
+		{
+			name: "merged hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:  "0\n2\n3\n4\n5\n6\n7\n9\n",
+			want: "--- old\n+++ new\n" +
+				"@@ -1,8 +1,8 @@\n-1\n+0\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+9\n",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := unifiedDiff("old", "new", []byte(tc.old), []byte(tc.new))
+			if got != tc.want {
+				t.Errorf("want:\n%s\ngot:\n%s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+	for i := 0; i < 1000; i++ {
+		a, b := randLines(), randLines()
+		edits := diffLines(a, b)
+
+		// the edits must turn a into b...
+		var x, y, changes int
+		for _, e := range edits {
+			if e.a != x || e.b != y {
+				t.Fatalf("%q -> %q: edit %+v at a=%d b=%d", a, b, e, x, y)
+			}
+			switch e.op {
+			case ' ':
+				if a[x] != b[y] {
+					t.Fatalf("%q -> %q: %q kept as %q", a, b, a[x], b[y])
+				}
+				x, y = x+1, y+1
+			case '-':
+				x, changes = x+1, changes+1
+			case '+':
+				y, changes = y+1, changes+1
+			}
+		}
+		if x != len(a) || y != len(b) {
+			t.Fatalf("%q -> %q: edits end at a=%d b=%d", a, b, x, y)
+		}
+		// ...and be as short as possible.
+		if want := len(a) + len(b) - 2*lcs(a, b); changes != want {
+			t.Fatalf("%q -> %q: want %d changes, got %d", a, b, want, changes)
+		}
+	}
+}
+
+// lcs returns the length of the longest common subsequence of a and b.
+func lcs(a, b []string) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(cur[j], prev[j+1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/wire"
+)
+
+// runIR implements the "tomgen ir" subcommand, printing the IR of the given
+// symbols and how each field is laid out in the binary encoding.
+func runIR(args []string) error {
+	fs := flag.NewFlagSet("tomgen ir", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "print the output as JSON")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), `usage: tomgen ir [flags] [symbol...]
+
+Prints the IR computed for the given symbols (or the annotated types in the
+current directory), together with the wire layout of each field.
+The symbols are in the same format as the main command.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var (
+		records []ir.StructRecord
+		err     error
+	)
+	if fs.NArg() == 0 {
+		records, _, err = annotatedRecords(false)
+	} else {
+		records, err = symbolRecords(fs.Args(), nil, false)
+	}
+	if err != nil {
+		return err
+	}
+
+	plan, err := wire.Lower(records)
+	if err != nil {
+		return err
+	}
+	layouts := make([]messageLayout, len(plan.Messages))
+	for i, msg := range plan.Messages {
+		layouts[i] = messageLayout{
+			Name:   msg.Name,
+			Source: msg.Source,
+			Fields: layoutFields(msg.Fields),
+		}
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "\t")
+		return enc.Encode(layouts)
+	}
+	return printLayouts(os.Stdout, layouts)
+}
+
+type messageLayout struct {
+	Name   string        `json:"name"`
+	Source string        `json:"source"`
+	Fields []fieldLayout `json:"fields"`
+}
+
+// fieldLayout describes a struct field, and how it is encoded.
+type fieldLayout struct {
+	Name string `json:"name"`
+	// Go-like representation of the field's record.
+	Type        string `json:"type"`
+	BinFieldNum uint32 `json:"bin_field_num"`
+	// Tag bytes, hex-encoded.
+	Tag      string `json:"tag"`
+	WireType string `json:"wire_type"`
+	TagFlag  string `json:"tag_flag"`
+	JSONName string `json:"json_name"`
+	Repeated bool   `json:"repeated"`
+	Packed   bool   `json:"packed"`
+	Optional bool   `json:"optional"`
+	// Fields of the struct contained in the field, if any.
+	Fields []fieldLayout `json:"fields,omitempty"`
+}
+
+func layoutFields(fields []*wire.Field) []fieldLayout {
+	res := make([]fieldLayout, len(fields))
+	for i, f := range fields {
+		res[i] = layoutField(f)
+	}
+	return res
+}
+
+func layoutField(f *wire.Field) fieldLayout {
+	l := fieldLayout{
+		Name:        f.Name,
+		Type:        describeRecord(f.Record),
+		BinFieldNum: f.BinFieldNum,
+		Tag:         hex.EncodeToString(f.Tag),
+		WireType:    f.WireType.String(),
+		TagFlag:     f.TagFlag.String(),
+		JSONName:    f.JSONName,
+		Repeated:    f.Repeated != nil,
+		Packed:      f.Repeated != nil && f.Repeated.Packed,
+		Optional:    f.Optional,
+	}
+	if f.Value.Message != nil {
+		l.Fields = layoutFields(f.Value.Message.Fields)
+	}
+	return l
+}
+
+// describeRecord returns a Go-like representation of rec.
+func describeRecord(rec ir.Record) string {
+	switch rec := rec.(type) {
+	case ir.ScalarRecord:
+		return rec.Name
+	case ir.BytesRecord:
+		switch {
+		case rec.String:
+			return "string"
+		case rec.Size >= 0:
+			return "[" + strconv.FormatInt(rec.Size, 10) + "]byte"
+		default:
+			return "[]byte"
+		}
+	case ir.RepeatedRecord:
+		size := ""
+		if rec.Size >= 0 {
+			size = strconv.FormatInt(rec.Size, 10)
+		}
+		return "[" + size + "]" + describeRecord(rec.Elem)
+	case ir.OptionalRecord:
+		return "*" + describeRecord(rec.Elem)
+	case ir.StructRecord:
+		if rec.Name == "" {
+			return "struct"
+		}
+		return rec.Name
+	case ir.NamedRecord:
+		return rec.Name
+	case ir.AnyRecord:
+		if rec.Source == "" {
+			return "any"
+		}
+		return rec.Source
+	default:
+		return fmt.Sprintf("%T", rec)
+	}
+}
+
+func printLayouts(w io.Writer, layouts []messageLayout) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for i, msg := range layouts {
+		if i > 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "# %s (%s)\n", msg.Name, msg.Source)
+		fmt.Fprintln(tw, "FIELD\tNUM\tTAG\tWIRE\tTYPE\tJSON\tFLAGS\tENCODING")
+		printFields(tw, msg.Fields, 0)
+	}
+	return tw.Flush()
+}
+
+func printFields(w io.Writer, fields []fieldLayout, depth int) {
+	for _, f := range fields {
+		var enc []string
+		if f.Optional {
+			enc = append(enc, "optional")
+		}
+		if f.Repeated {
+			enc = append(enc, "repeated")
+		}
+		if f.Packed {
+			enc = append(enc, "packed")
+		}
+		fmt.Fprintf(w, "%s%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			strings.Repeat("  ", depth), f.Name,
+			f.BinFieldNum, f.Tag, f.WireType, f.Type,
+			f.JSONName, orDash(f.TagFlag), orDash(strings.Join(enc, ",")))
+		printFields(w, f.Fields, depth+1)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
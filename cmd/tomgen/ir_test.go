@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+func TestIR(t *testing.T) {
+	stdout, code := runTomgen(t, "ir", "./testdata/layout.Layout")
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	const want = `# Layout (github.com/thehowl/tomino/cmd/tomgen/testdata/layout.Layout)
+FIELD          NUM  TAG  WIRE    TYPE      JSON         FLAGS            ENCODING
+Fixed          1    09   i64     int64     fixed        fixed64          -
+Ptr            2    10   varint  *uint32   Ptr          json_omit_empty  optional
+Packed         3    1a   len     []int16   Packed       -                repeated,packed
+Ptrs           4    22   len     [2]*bool  Ptrs         nil_elements     repeated,packed
+Float          5    2d   i32     float32   Float        unsafe           -
+Time           6    32   len     Time      Time         -                -
+  Seconds      1    08   varint  uint64    seconds      -                -
+  Nanoseconds  2    10   varint  uint32    nanoseconds  -                -
+Bytes          7    3a   len     [4]byte   Bytes        -                -
+Inner          8    42   len     []Inner   Inner        write_empty      repeated
+  S            1    0a   len     string    S            -                -
+`
+	if stdout != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, stdout)
+	}
+}
+
+func TestIRJSON(t *testing.T) {
+	stdout, code := runTomgen(t, "ir", "-json", "./testdata/layout.Layout", "./testdata/symbols.Coin")
+	if code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	var layouts []messageLayout
+	if err := json.Unmarshal([]byte(stdout), &layouts); err != nil {
+		t.Fatal(err)
+	}
+	if len(layouts) != 2 || layouts[0].Name != "Layout" || layouts[1].Name != "Coin" {
+		t.Fatalf("unexpected messages: %+v", layouts)
+	}
+	if src := layouts[1].Source; src != "github.com/thehowl/tomino/cmd/tomgen/testdata/symbols.Coin" {
+		t.Errorf("unexpected source %q", src)
+	}
+
+	fields := layouts[0].Fields
+	if len(fields) != 8 {
+		t.Fatalf("want 8 fields, got %d", len(fields))
+	}
+	wantPtrs := fieldLayout{
+		Name:        "Ptrs",
+		Type:        "[2]*bool",
+		BinFieldNum: 4,
+		Tag:         "22",
+		WireType:    "len",
+		TagFlag:     "nil_elements",
+		JSONName:    "Ptrs",
+		Repeated:    true,
+		Packed:      true,
+	}
+	if !reflect.DeepEqual(wantPtrs, fields[3]) {
+		t.Errorf("want %+v, got %+v", wantPtrs, fields[3])
+	}
+	wantInner := fieldLayout{
+		Name:        "Inner",
+		Type:        "[]Inner",
+		BinFieldNum: 8,
+		Tag:         "42",
+		WireType:    "len",
+		TagFlag:     "write_empty",
+		JSONName:    "Inner",
+		Repeated:    true,
+		Fields: []fieldLayout{{
+			Name:        "S",
+			Type:        "string",
+			BinFieldNum: 1,
+			Tag:         "0a",
+			WireType:    "len",
+			JSONName:    "S",
+		}},
+	}
+	if !reflect.DeepEqual(wantInner, fields[7]) {
+		t.Errorf("want %+v, got %+v", wantInner, fields[7])
+	}
+}
+
+func TestDescribeRecord(t *testing.T) {
+	tt := []struct {
+		rec  ir.Record
+		want string
+	}{
+		{ir.ScalarRecord{Name: "uint16"}, "uint16"},
+		{ir.BytesRecord{String: true, Size: -1}, "string"},
+		{ir.BytesRecord{Size: -1}, "[]byte"},
+		{ir.BytesRecord{Size: 32}, "[32]byte"},
+		{ir.RepeatedRecord{Elem: ir.OptionalRecord{Elem: ir.ScalarRecord{Name: "bool"}}, Size: -1}, "[]*bool"},
+		{ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int8"}, Size: 0}, "[0]int8"},
+		{ir.StructRecord{Name: "Coin"}, "Coin"},
+		{ir.StructRecord{}, "struct"},
+		{ir.NamedRecord{Name: "Memo"}, "Memo"},
+		{ir.AnyRecord{}, "any"},
+		{ir.AnyRecord{Source: "x.Msg"}, "x.Msg"},
+	}
+	for _, tc := range tt {
+		if got := describeRecord(tc.rec); got != tc.want {
+			t.Errorf("describeRecord(%#v): want %q, got %q", tc.rec, tc.want, got)
+		}
+	}
+}
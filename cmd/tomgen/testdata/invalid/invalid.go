@@ -0,0 +1,22 @@
+// Package invalid contains types for which tomgen reports errors.
+package invalid
+
+// Unsupported can't be converted to the IR.
+type Unsupported struct {
+	Chan    chan int
+	PtrPtr  **int
+	Allowed string
+	Func    func()
+}
+
+// Invalid is converted to the IR, but fails validation.
+type Invalid struct {
+	A     int64
+	Inner []*Inner
+}
+
+type Inner struct {
+	Float float64
+	B     string `json:"C"`
+	C     string
+}
@@ -0,0 +1,19 @@
+// Package layout contains the types used to test tomgen ir.
+package layout
+
+import "time"
+
+type Layout struct {
+	Fixed  int64   `binary:"fixed64" json:"fixed"`
+	Ptr    *uint32 `json:",omitempty"`
+	Packed []int16
+	Ptrs   [2]*bool `amino:"nil_elements"`
+	Float  float32  `amino:"unsafe"`
+	Time   time.Time
+	Bytes  [4]byte
+	Inner  []Inner `amino:"write_empty"`
+}
+
+type Inner struct {
+	S string
+}
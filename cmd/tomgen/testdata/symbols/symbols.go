@@ -0,0 +1,36 @@
+// Package symbols contains the types used to test the symbols passed to
+// tomgen, including wildcards.
+package symbols
+
+type Account struct {
+	Address string
+	Coins   []Coin
+}
+
+type Coin struct {
+	Denom  string
+	Amount int64
+}
+
+type Excluded struct {
+	A bool
+}
+
+// Ineligible types, which are skipped when using wildcards.
+type (
+	Func           func()
+	Chan           chan int
+	Alias          = Coin
+	Generic[T any] struct{ V T }
+)
+
+type unexported struct {
+	B bool
+}
+
+// Invalid is converted to the IR, but fails validation, so it is skipped when
+// using wildcards.
+type Invalid struct {
+	A string `json:"x"`
+	B string `json:"x"`
+}
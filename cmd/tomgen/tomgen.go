@@ -1,84 +1,521 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/thehowl/tomino/generator"
 	"github.com/thehowl/tomino/generator/ir"
+	ctarget "github.com/thehowl/tomino/generator/targets/c"
 	gotarget "github.com/thehowl/tomino/generator/targets/go"
+	"github.com/thehowl/tomino/generator/targets/go/gojson"
+	"github.com/thehowl/tomino/generator/targets/go/goregistry"
+	pytarget "github.com/thehowl/tomino/generator/targets/python"
+	rusttarget "github.com/thehowl/tomino/generator/targets/rust"
+	tstarget "github.com/thehowl/tomino/generator/targets/ts"
+	zigtarget "github.com/thehowl/tomino/generator/targets/zig"
 	"golang.org/x/tools/go/packages"
 )
 
+// defaultOutput is the file written when generating annotated types,
+// followed by the extension of the target.
+const defaultOutput = "tomino_gen"
+
+// target is a language tomgen can generate code for.
+type target struct {
+	// ext is the extension of the generated files.
+	ext   string
+	write func(w io.Writer, records []ir.StructRecord, opts targetOptions) error
+	// header, if set, generates a header file, written next to the output
+	// with the extension .h.
+	header func(w io.Writer, records []ir.StructRecord, opts targetOptions) error
+}
+
+// targetOptions are the options passed to the targets.
+type targetOptions struct {
+	pkgName  string
+	purego   bool
+	registry bool
+	// header is the file name of the header, if the target has one and it
+	// is written to a separate file.
+	header string
+}
+
+var targets = map[string]target{
+	"c": {
+		ext: ".c",
+		write: func(w io.Writer, records []ir.StructRecord, opts targetOptions) error {
+			return ctarget.Write(w, records, ctarget.Options{Header: opts.header})
+		},
+		header: func(w io.Writer, records []ir.StructRecord, opts targetOptions) error {
+			return ctarget.WriteHeader(w, records, ctarget.Options{Header: opts.header})
+		},
+	},
+	"gno": {ext: ".gno", write: func(w io.Writer, records []ir.StructRecord, opts targetOptions) error {
+		return gotarget.Write(w, records, gotarget.Options{Package: opts.pkgName, Gno: true, Features: []gotarget.Feature{gojson.Feature}})
+	}},
+	"go": {ext: ".go", write: func(w io.Writer, records []ir.StructRecord, opts targetOptions) error {
+		features := []gotarget.Feature{gojson.Feature}
+		if opts.registry {
+			features = append(features, goregistry.Feature)
+		}
+		return gotarget.Write(w, records, gotarget.Options{Package: opts.pkgName, PureGo: opts.purego, Features: features})
+	}},
+	"python": {ext: ".py", write: func(w io.Writer, records []ir.StructRecord, _ targetOptions) error {
+		return pytarget.Write(w, records, pytarget.Options{})
+	}},
+	"rust": {ext: ".rs", write: func(w io.Writer, records []ir.StructRecord, _ targetOptions) error {
+		return rusttarget.Write(w, records, rusttarget.Options{})
+	}},
+	"ts": {ext: ".ts", write: func(w io.Writer, records []ir.StructRecord, _ targetOptions) error {
+		return tstarget.Write(w, records, tstarget.Options{})
+	}},
+	"zig": {ext: ".zig", write: func(w io.Writer, records []ir.StructRecord, _ targetOptions) error {
+		return zigtarget.Write(w, records, zigtarget.Options{})
+	}},
+}
+
+type options struct {
+	exclude  []string
+	output   string
+	pkgName  string
+	check    bool
+	target   string
+	purego   bool
+	registry bool
+	unknown  bool
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "ir" {
+		if err := runIR(os.Args[2:]); err != nil {
+			printError(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var opts options
+	exclude := flag.String("exclude", "", "comma-separated list of types to skip when using wildcards;\n"+
+		"may be given as Name, or qualified as path/to/pkg.Name")
+	flag.StringVar(&opts.output, "o", "", "output file (default stdout, or "+defaultOutput+".<ext> for annotated types)")
+	flag.BoolVar(&opts.check, "check", false, "check that the output file is up to date, printing a diff\n"+
+		"if it is not, without writing anything")
+	flag.StringVar(&opts.pkgName, "pkg", "", "package name of the generated file (default tomtypes,\n"+
+		"or the name of the current package for annotated types)")
+	flag.BoolVar(&opts.purego, "purego", false, "don't use unsafe in the generated code (go target only;\n"+
+		"the gno target never uses it)")
+	flag.BoolVar(&opts.registry, "registry", false, "also generate Marshal, Unmarshal and the other functions of amino's API,\n"+
+		"dispatching to the generated types (go target only)")
+	flag.BoolVar(&opts.unknown, "unknown", false, "keep the unknown fields found when decoding the messages, and write them\n"+
+		"back when encoding (go and gno targets only)")
+	flag.StringVar(&opts.target, "target", "go", "language of the generated code: c, gno, go, python, rust, ts (TypeScript) or zig")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `usage: tomgen [flags] [symbol...]
+       tomgen ir [-json] [symbol...]
+
+Each symbol can be one of:
+	net/url.URL    a type in the given package
+	./pkg.Type     a type in a package, relative to the current directory
+	.Type          a type in the package in the current directory
+	net/url.*      all the exported struct types in the given package,
+	               except those in generated files and those which
+	               tomgen can't convert (skipped with a notice)
+
+If no symbols are given, tomgen generates the types in the current directory
+annotated with a %s comment, writing the output to %s.go
+(or the extension of the chosen -target).
+This is meant to be used with go:generate:
+
+	//go:generate tomgen
+
+	//tomino:generate
+	type Transaction struct { ... }
+
+tomgen ir prints the IR of the given types, and their wire layout.
+
+Flags:
+`, generator.AnnotationDirective, defaultOutput)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
-	args := flag.Args()
-	if err := run(args); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	if *exclude != "" {
+		opts.exclude = strings.Split(*exclude, ",")
+	}
+	if err := run(flag.Args(), opts); err != nil {
+		printError(err)
+		os.Exit(1)
+	}
+}
+
+func printError(err error) {
+	// multiple errors may be returned, one per line.
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(os.Stderr, "error: %s\n", line)
+	}
+}
+
+const loadMode = packages.NeedName | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesSizes
+
+func run(args []string, opts options) error {
+	tgt, ok := targets[opts.target]
+	if !ok {
+		return fmt.Errorf("unknown target %q", opts.target)
+	}
+	if opts.purego && opts.target != "go" {
+		return fmt.Errorf("-purego is not supported by the %s target", opts.target)
+	}
+	if opts.registry && opts.target != "go" {
+		return fmt.Errorf("-registry is not supported by the %s target", opts.target)
+	}
+	if opts.unknown && opts.target != "go" && opts.target != "gno" {
+		return fmt.Errorf("-unknown is not supported by the %s target", opts.target)
+	}
+
+	var (
+		records []ir.StructRecord
+		pkgName string
+		err     error
+	)
+	if len(args) == 0 {
+		records, pkgName, err = annotatedRecords(opts.unknown)
+		if opts.output == "" {
+			opts.output = defaultOutput + tgt.ext
+		}
+	} else {
+		records, err = symbolRecords(args, opts.exclude, opts.unknown)
+	}
+	if err != nil {
+		return err
+	}
+	if opts.pkgName != "" {
+		pkgName = opts.pkgName
+	}
+
+	topts := targetOptions{pkgName: pkgName, purego: opts.purego, registry: opts.registry}
+	var header bytes.Buffer
+	headerPath := ""
+	if tgt.header != nil {
+		if opts.output != "" {
+			headerPath = strings.TrimSuffix(opts.output, filepath.Ext(opts.output)) + ".h"
+			topts.header = filepath.Base(headerPath)
+		}
+		if err := tgt.header(&header, records, topts); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	err = tgt.write(&buf, records, topts)
+	if err != nil {
+		return err
+	}
+	// TODO: For go specifically, we should codegen both the target, but then do
+	// converter methods as well.
+
+	switch {
+	case opts.check:
+		if headerPath != "" {
+			return errors.Join(check(headerPath, header.Bytes()), check(opts.output, buf.Bytes()))
+		}
+		return check(opts.output, buf.Bytes())
+	case opts.output == "":
+		// without a separate header, it is written before the output.
+		if _, err := os.Stdout.Write(header.Bytes()); err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	default:
+		if headerPath != "" {
+			if err := os.WriteFile(headerPath, header.Bytes(), 0o644); err != nil {
+				return err
+			}
+		}
+		return os.WriteFile(opts.output, buf.Bytes(), 0o644)
+	}
+}
+
+// check compares the generated code with the contents of the file at path.
+// If they differ, it prints the diff to stdout and returns an error.
+func check(path string, generated []byte) error {
+	if path == "" {
+		return errors.New("-check requires an output file (-o)")
+	}
+	current, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	diff := unifiedDiff(path, path+" (generated)", current, generated)
+	if diff == "" {
+		return nil
+	}
+	fmt.Print(diff)
+	return fmt.Errorf("%s is not up to date; re-run tomgen to regenerate it", path)
+}
+
+// annotatedRecords returns the records for the types in the package in the
+// current directory, annotated with generator.AnnotationDirective.
+// It additionally returns the name of the package.
+// If unknown is set, all the records keep their unknown fields.
+func annotatedRecords(unknown bool) ([]ir.StructRecord, string, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, ".")
+	if err != nil {
+		return nil, "", fmt.Errorf("loading packages: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, "", fmt.Errorf("current directory matches %d packages (want 1)", len(pkgs))
+	}
+	pkg := pkgs[0]
+	if errs := packageErrors(pkg); len(errs) > 0 {
+		return nil, "", errors.Join(errs...)
+	}
+
+	anns, err := generator.FindAnnotations(pkg.Fset, pkg.Syntax)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(anns) == 0 {
+		return nil, "", fmt.Errorf("no types annotated with %s in package %s", generator.AnnotationDirective, pkg.PkgPath)
+	}
+
+	records := make([]ir.StructRecord, 0, len(anns))
+	objs := make([]types.Object, 0, len(anns))
+	var errs []error
+	for _, ann := range anns {
+		obj := pkg.Types.Scope().Lookup(ann.TypeName)
+		ann.KeepUnknown = ann.KeepUnknown || unknown
+		rec, err := generator.Parse(pkg.Fset, obj, ann)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, rec)
+		objs = append(objs, obj)
+	}
+	if len(errs) > 0 {
+		return nil, "", errors.Join(errs...)
 	}
+	return records, pkg.Name, generator.ResolveInterfaces(records, objs)
 }
 
-func run(args []string) error {
+// symbolRecords returns the records for the given symbols, passed on the
+// command line. If unknown is set, the records keep their unknown fields.
+func symbolRecords(args, exclude []string, unknown bool) ([]ir.StructRecord, error) {
 	// parse symbols
 	qsym := make([]qualifiedSymbol, 0, len(args))
 	var paths []string
+	resolved := make(map[string]string)
 	for _, arg := range args {
-		lastSlash := strings.IndexByte(arg, '/') + 1
-		lastPart := arg[lastSlash:]
-		dot := strings.LastIndexByte(lastPart, '.')
-		if dot < 0 {
-			// TODO: support symbols in local dir.
-			return fmt.Errorf("invalid argument: %q (need a qualified symbol, like 'net/url.URL')", arg)
-		}
-		pkg := arg[:lastSlash+dot]
-		qsym = append(qsym, qualifiedSymbol{
-			pkg:    pkg,
-			symbol: lastPart[dot+1:],
-		})
-		if !slices.Contains(paths, pkg) {
-			paths = append(paths, pkg)
-		}
-	}
-
-	pkgs, err := packages.Load(&packages.Config{
-		Mode: packages.NeedName | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesSizes,
-	}, paths...)
+		sym, err := parseSymbol(arg)
+		if err != nil {
+			return nil, err
+		}
+		if isLocalPattern(sym.pkg) {
+			// local packages are loaded using their import path, so we can
+			// find them in the result of packages.Load.
+			path, ok := resolved[sym.pkg]
+			if !ok {
+				path, err = resolveLocal(sym.pkg)
+				if err != nil {
+					return nil, err
+				}
+				resolved[sym.pkg] = path
+			}
+			sym.pkg = path
+		}
+		qsym = append(qsym, sym)
+		if !slices.Contains(paths, sym.pkg) {
+			paths = append(paths, sym.pkg)
+		}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, paths...)
 	if err != nil {
-		return fmt.Errorf("loading packages: %w", err)
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+
+	// collect all errors, so they can all be reported together.
+	var errs []error
+	failedPkgs := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if pkgErrs := packageErrors(pkg); len(pkgErrs) > 0 {
+			errs = append(errs, pkgErrs...)
+			failedPkgs[pkg.PkgPath] = true
+		}
 	}
 
 	records := make([]ir.StructRecord, 0, len(qsym))
+	// the objects of records, to resolve the interfaces.
+	parsed := make([]types.Object, 0, len(qsym))
+	seen := make(map[string]bool, len(qsym))
 	for _, sym := range qsym {
+		if failedPkgs[sym.pkg] {
+			continue
+		}
 		pos := slices.IndexFunc(pkgs, func(pkg *packages.Package) bool { return pkg.PkgPath == sym.pkg })
 		if pos < 0 {
-			panic("could not find explicitly requested package?")
+			errs = append(errs, fmt.Errorf("package %s not found", sym.pkg))
+			continue
 		}
 		pkg := pkgs[pos]
-		obj := pkg.Types.Scope().Lookup(sym.symbol)
-		rec, err := generator.Parse(obj)
-		if err != nil {
-			return err
+
+		var objs []types.Object
+		if sym.symbol == "*" {
+			objs = wildcardSymbols(pkg, exclude)
+		} else {
+			obj := pkg.Types.Scope().Lookup(sym.symbol)
+			if obj == nil {
+				errs = append(errs, fmt.Errorf("symbol %s not found in package %s", sym.symbol, sym.pkg))
+				continue
+			}
+			objs = []types.Object{obj}
 		}
-		records = append(records, rec)
-		if err := rec.Validate(); err != nil {
-			return fmt.Errorf("validating IR for %s.%s: %w", sym.pkg, sym.symbol, err)
+
+		for _, obj := range objs {
+			qualified := sym.pkg + "." + obj.Name()
+			if seen[qualified] {
+				continue
+			}
+			seen[qualified] = true
+
+			rec, err := generator.Parse(pkg.Fset, obj, generator.Annotation{KeepUnknown: unknown})
+			if err != nil {
+				if sym.symbol == "*" {
+					// not explicitly requested; skip it.
+					fmt.Fprintf(os.Stderr, "tomgen: skipping %s: %v\n", qualified, err)
+					continue
+				}
+				errs = append(errs, err)
+				continue
+			}
+			records = append(records, rec)
+			parsed = append(parsed, obj)
 		}
 	}
-	err = gotarget.Write(os.Stdout, records)
-	if err != nil {
-		return err
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
-	// TODO: For go specifically, we should codegen both the target, but then do
-	// converter methods as well.
+	return records, generator.ResolveInterfaces(records, parsed)
+}
 
-	return nil
+// packageErrors returns the errors encountered by packages.Load when loading
+// pkg.
+func packageErrors(pkg *packages.Package) []error {
+	errs := make([]error, len(pkg.Errors))
+	for i, err := range pkg.Errors {
+		errs[i] = err
+	}
+	return errs
 }
 
 type qualifiedSymbol struct {
 	pkg    string
 	symbol string
 }
+
+// parseSymbol parses a symbol passed on the command line, in the form
+// path/to/pkg.Symbol. The package may be a relative path, or omitted
+// altogether (ie. .Symbol) to refer to the package in the current directory.
+// Symbol may be * to match all the exported types in the package.
+func parseSymbol(arg string) (qualifiedSymbol, error) {
+	lastSlash := strings.LastIndexByte(arg, '/') + 1
+	dot := strings.LastIndexByte(arg[lastSlash:], '.')
+	if dot < 0 || lastSlash+dot == len(arg)-1 {
+		return qualifiedSymbol{}, fmt.Errorf("invalid argument: %q (need a qualified symbol, like 'net/url.URL', './pkg.Type' or 'net/url.*')", arg)
+	}
+	qs := qualifiedSymbol{
+		pkg:    arg[:lastSlash+dot],
+		symbol: arg[lastSlash+dot+1:],
+	}
+	if qs.pkg == "" {
+		qs.pkg = "."
+	}
+	return qs, nil
+}
+
+func isLocalPattern(pkg string) bool {
+	return pkg == "." || pkg == ".." ||
+		strings.HasPrefix(pkg, "./") || strings.HasPrefix(pkg, "../")
+}
+
+// resolveLocal returns the import path of the package in the given relative
+// directory.
+func resolveLocal(pattern string) (string, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, pattern)
+	if err != nil {
+		return "", fmt.Errorf("loading package %s: %w", pattern, err)
+	}
+	if len(pkgs) != 1 {
+		return "", fmt.Errorf("pattern %s matches %d packages (want 1)", pattern, len(pkgs))
+	}
+	if len(pkgs[0].Errors) > 0 {
+		return "", fmt.Errorf("loading package %s: %w", pattern, pkgs[0].Errors[0])
+	}
+	return pkgs[0].PkgPath, nil
+}
+
+// wildcardSymbols returns all the exported type names in pkg which can be
+// used to generate a message, and which are not in the exclude list.
+// Ineligible types, and those declared in generated files, are skipped,
+// printing a notice to stderr.
+func wildcardSymbols(pkg *packages.Package, exclude []string) []types.Object {
+	generated := make(map[*token.File]bool)
+	for _, f := range pkg.Syntax {
+		if ast.IsGenerated(f) {
+			generated[pkg.Fset.File(f.Pos())] = true
+		}
+	}
+
+	var objs []types.Object
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !tn.Exported() ||
+			slices.Contains(exclude, name) ||
+			slices.Contains(exclude, pkg.PkgPath+"."+name) {
+			continue
+		}
+		reason := ineligibleReason(tn)
+		if reason == "" && generated[pkg.Fset.File(tn.Pos())] {
+			reason = "declared in a generated file"
+		}
+		if reason != "" {
+			fmt.Fprintf(os.Stderr, "tomgen: skipping %s.%s: %s\n", pkg.PkgPath, name, reason)
+			continue
+		}
+		objs = append(objs, tn)
+	}
+	return objs
+}
+
+// ineligibleReason returns a non-empty string explaining why tn may not be
+// used to generate a message, if that is the case.
+func ineligibleReason(tn *types.TypeName) string {
+	if tn.IsAlias() {
+		return "type is an alias"
+	}
+	named, ok := tn.Type().(*types.Named)
+	if !ok {
+		return "type is not a named type"
+	}
+	if named.TypeParams().Len() > 0 {
+		return "generic types are not supported"
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return fmt.Sprintf("underlying type %s is not a struct", named.Underlying())
+	}
+	return ""
+}
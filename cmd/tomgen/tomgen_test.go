@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"golang.org/x/tools/go/packages"
+)
+
+// TestMain runs tomgen instead of the tests if TOMGEN_MAIN is set, so that
+// the tests can check its exit codes and output; see [runTomgen].
+func TestMain(m *testing.M) {
+	if os.Getenv("TOMGEN_MAIN") != "" {
+		os.Args = append([]string{"tomgen"}, os.Args[1:]...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runTomgen runs tomgen with the given arguments, returning its stdout and
+// exit code.
+func runTomgen(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "TOMGEN_MAIN=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		t.Logf("stderr: %s", stderr.String())
+		return stdout.String(), exitErr.ExitCode()
+	case err != nil:
+		t.Fatal(err)
+	}
+	return stdout.String(), 0
+}
+
+func TestCheck(t *testing.T) {
+	const sym = "./testdata/symbols.Coin"
+	dir := t.TempDir()
+	out := filepath.Join(dir, "coin.go")
+
+	// missing file.
+	stdout, code := runTomgen(t, "-check", "-o", out, sym)
+	if code != 1 || !strings.Contains(stdout, "+++ "+out+" (generated)") {
+		t.Errorf("missing file: want exit code 1 and a diff, got %d and %q", code, stdout)
+	}
+	if _, err := os.Stat(out); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("-check must not write the output: %v", err)
+	}
+
+	// up to date.
+	if _, code := runTomgen(t, "-o", out, sym); code != 0 {
+		t.Fatalf("generating: exit code %d", code)
+	}
+	stdout, code = runTomgen(t, "-check", "-o", out, sym)
+	if code != 0 || stdout != "" {
+		t.Errorf("up to date: want exit code 0 and no output, got %d and %q", code, stdout)
+	}
+
+	// stale.
+	generated, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := bytes.Replace(generated, []byte("CoinMessage"), []byte("OldMessage"), 1)
+	if err := os.WriteFile(out, stale, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout, code = runTomgen(t, "-check", "-o", out, sym)
+	if code != 1 || !strings.Contains(stdout, "+// CoinMessage") || !strings.Contains(stdout, "-// OldMessage") {
+		t.Errorf("stale: want exit code 1 and a diff, got %d and %q", code, stdout)
+	}
+	if b, err := os.ReadFile(out); err != nil || !bytes.Equal(b, stale) {
+		t.Errorf("-check must not modify the output: %v", err)
+	}
+
+	// -check needs an output file.
+	if _, code := runTomgen(t, "-check", sym); code != 1 {
+		t.Errorf("without -o: want exit code 1, got %d", code)
+	}
+}
+
+func TestParseSymbol(t *testing.T) {
+	tt := []struct {
+		arg  string
+		want qualifiedSymbol
+		err  bool
+	}{
+		{arg: "net/url.URL", want: qualifiedSymbol{"net/url", "URL"}},
+		{arg: "github.com/x/y.Type", want: qualifiedSymbol{"github.com/x/y", "Type"}},
+		{arg: "./pkg.Type", want: qualifiedSymbol{"./pkg", "Type"}},
+		{arg: "../pkg.Type", want: qualifiedSymbol{"../pkg", "Type"}},
+		{arg: ".Type", want: qualifiedSymbol{".", "Type"}},
+		{arg: "net/url.*", want: qualifiedSymbol{"net/url", "*"}},
+		{arg: ".*", want: qualifiedSymbol{".", "*"}},
+		{arg: "URL", err: true},
+		{arg: "net/url", err: true},
+		{arg: "net/url.", err: true},
+		{arg: "github.com/x/y", err: true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.arg, func(t *testing.T) {
+			got, err := parseSymbol(tc.arg)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("want error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsLocalPattern(t *testing.T) {
+	for pkg, want := range map[string]bool{
+		".":              true,
+		"..":             true,
+		"./pkg":          true,
+		"../pkg":         true,
+		"net/url":        false,
+		".hidden/pkg":    false,
+		"github.com/x/y": false,
+	} {
+		if got := isLocalPattern(pkg); got != want {
+			t.Errorf("isLocalPattern(%q): want %v, got %v", pkg, want, got)
+		}
+	}
+}
+
+func TestResolveLocal(t *testing.T) {
+	const want = "github.com/thehowl/tomino/cmd/tomgen/testdata/symbols"
+	got, err := resolveLocal("./testdata/symbols")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if _, err := resolveLocal("./testdata/missing"); err == nil {
+		t.Error("want error for a missing package")
+	}
+}
+
+func TestWildcardSymbols(t *testing.T) {
+	const pkgPath = "github.com/thehowl/tomino/cmd/tomgen/testdata/symbols"
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode}, "./testdata/symbols")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt := []struct {
+		name    string
+		exclude []string
+		want    []string
+	}{
+		// ineligible types, like Func and Alias, and Generated are skipped.
+		{name: "all", want: []string{"Account", "Coin", "Excluded", "Invalid"}},
+		{
+			name:    "exclude",
+			exclude: []string{"Excluded", pkgPath + ".Coin"},
+			want:    []string{"Account", "Invalid"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := wildcardSymbols(pkgs[0], tc.exclude)
+			got := make([]string, len(objs))
+			for i, obj := range objs {
+				got[i] = obj.Name()
+			}
+			if !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func recordNames(records []ir.StructRecord) []string {
+	names := make([]string, len(records))
+	for i, rec := range records {
+		names[i] = rec.Name
+	}
+	return names
+}
+
+func TestSymbolRecords(t *testing.T) {
+	const pkgPath = "github.com/thehowl/tomino/cmd/tomgen/testdata/symbols"
+	tt := []struct {
+		name    string
+		args    []string
+		exclude []string
+		want    []string
+		err     string
+	}{
+		{
+			name: "local",
+			args: []string{"./testdata/symbols.Coin"},
+			want: []string{"Coin"},
+		},
+		{
+			name: "import path",
+			args: []string{pkgPath + ".Coin", pkgPath + ".Account"},
+			want: []string{"Coin", "Account"},
+		},
+		{
+			name: "wildcard",
+			args: []string{"./testdata/symbols.*"},
+			want: []string{"Account", "Coin", "Excluded"},
+		},
+		{
+			name:    "wildcard exclude",
+			args:    []string{"./testdata/symbols.*"},
+			exclude: []string{"Excluded", pkgPath + ".Coin"},
+			want:    []string{"Account"},
+		},
+		{
+			name: "duplicates",
+			args: []string{"./testdata/symbols.Coin", pkgPath + ".*"},
+			want: []string{"Coin", "Account", "Excluded"},
+		},
+		{
+			name: "explicit generated",
+			args: []string{"./testdata/symbols.Generated"},
+			want: []string{"Generated"},
+		},
+		{
+			name: "explicit invalid",
+			args: []string{"./testdata/symbols.Invalid"},
+			err:  `JSON name "x" already used by field A`,
+		},
+		{
+			name: "explicit ineligible",
+			args: []string{"./testdata/symbols.Func"},
+			err:  "unsupported type",
+		},
+		{
+			name: "not found",
+			args: []string{"./testdata/symbols.Missing"},
+			err:  "symbol Missing not found in package " + pkgPath,
+		},
+		{
+			name: "invalid",
+			args: []string{"symbols"},
+			err:  "invalid argument",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := symbolRecords(tc.args, tc.exclude, false)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("want error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := recordNames(records); !reflect.DeepEqual(tc.want, got) {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	_, err := symbolRecords([]string{"./testdata/invalid.Unsupported", "./testdata/invalid.Missing"}, nil, false)
+	if err == nil {
+		t.Fatal("want error")
+	}
+	file := filepath.Join("testdata", "invalid", "invalid.go")
+	// all the errors are reported, rather than only the first one.
+	want := []string{
+		file + ":6:2: Unsupported.Chan: unsupported type: *types.Chan (chan int)",
+		file + ":7:2: Unsupported.PtrPtr: type **int is pointer of pointer",
+		file + ":9:2: Unsupported.Func: unsupported type: *types.Signature (func())",
+		"symbol Missing not found in package github.com/thehowl/tomino/cmd/tomgen/testdata/invalid",
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want %d errors, got:\n%v", len(want), err)
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, want[i]) {
+			t.Errorf("want error ending with %q, got %q", want[i], line)
+		}
+	}
+}
+
+func TestValidationErrorPositions(t *testing.T) {
+	_, err := symbolRecords([]string{"./testdata/invalid.Invalid"}, nil, false)
+	if err == nil {
+		t.Fatal("want error")
+	}
+	file := filepath.Join("testdata", "invalid", "invalid.go")
+	want := []string{
+		file + ":19:2: Invalid.Inner[].Float: floating points must be used with the `amino:\"unsafe\"` struct tag",
+		file + ":21:2: Invalid.Inner[].C: JSON name \"C\" already used by field B",
+	}
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("want %d errors, got:\n%v", len(want), err)
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, want[i]) {
+			t.Errorf("want error ending with %q, got %q", want[i], line)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+// AnnotationDirective is the comment directive used to mark type declarations
+// for which tomino messages should be generated.
+const AnnotationDirective = "//tomino:generate"
+
+// Annotation is a [AnnotationDirective] found on a type declaration.
+//
+// The directive can be followed by space-separated options:
+//
+//	//tomino:generate name=Transaction nojson unknown typeurl=/std.Tx
+//
+// The available options are:
+//
+//   - name=<Name>: use Name as the name of the generated message, instead of
+//     the name of the type.
+//   - nojson: do not generate JSON encoders for the message.
+//   - unknown: keep the unknown fields found when decoding the message and the
+//     structs it contains, and write them back when encoding it.
+//   - typeurl=</pkg.Name>: the type URL identifying the message when it is
+//     the value of an interface, instead of /<package name>.<type name>.
+type Annotation struct {
+	// Name of the annotated type.
+	TypeName string
+	// Position of the directive in the source code.
+	Pos token.Position
+
+	// Options.
+	Name        string
+	SkipJSON    bool
+	KeepUnknown bool
+	TypeURL     string
+}
+
+// Apply applies the options of the annotation to rec.
+func (a Annotation) Apply(rec *ir.StructRecord) {
+	if a.Name != "" {
+		rec.Name = a.Name
+	}
+	rec.SkipJSON = a.SkipJSON
+	if a.TypeURL != "" {
+		rec.TypeURL = a.TypeURL
+	}
+	rec.KeepUnknown = false
+	if a.KeepUnknown {
+		*rec = keepUnknown(*rec).(ir.StructRecord)
+	}
+}
+
+// keepUnknown returns a copy of rec where KeepUnknown is set on all the
+// StructRecords, except for the well-known time.Time and time.Duration, whose
+// encoding is fixed.
+func keepUnknown(rec ir.Record) ir.Record {
+	switch rec := rec.(type) {
+	case ir.StructRecord:
+		if rec.Source == "time.Time" || rec.Source == "time.Duration" {
+			return rec
+		}
+		rec.KeepUnknown = true
+		rec.Fields = slices.Clone(rec.Fields)
+		for i, fld := range rec.Fields {
+			rec.Fields[i].Record = keepUnknown(fld.Record)
+		}
+		return rec
+	case ir.RepeatedRecord:
+		rec.Elem = keepUnknown(rec.Elem)
+		return rec
+	case ir.OptionalRecord:
+		rec.Elem = keepUnknown(rec.Elem)
+		return rec
+	case ir.NamedRecord:
+		rec.Elem = keepUnknown(rec.Elem)
+		return rec
+	default:
+		return rec
+	}
+}
+
+// FindAnnotations looks for type declarations annotated with the
+// [AnnotationDirective] in the given files, returning them in source order.
+func FindAnnotations(fset *token.FileSet, files []*ast.File) ([]Annotation, error) {
+	var anns []Annotation
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				// type X struct{} has the comment on the GenDecl, while
+				// type ( X struct{} ) has it on the TypeSpec.
+				if doc == nil && !gd.Lparen.IsValid() {
+					doc = gd.Doc
+				}
+				ann, ok, err := parseAnnotation(fset, doc)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					ann.TypeName = ts.Name.Name
+					anns = append(anns, ann)
+				}
+			}
+		}
+	}
+	return anns, nil
+}
+
+func parseAnnotation(fset *token.FileSet, doc *ast.CommentGroup) (Annotation, bool, error) {
+	if doc == nil {
+		return Annotation{}, false, nil
+	}
+	for _, c := range doc.List {
+		rest, ok := strings.CutPrefix(c.Text, AnnotationDirective)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		ann := Annotation{Pos: fset.Position(c.Pos())}
+		for _, opt := range strings.Fields(rest) {
+			key, val, hasVal := strings.Cut(opt, "=")
+			switch {
+			case key == "name" && hasVal && token.IsIdentifier(val):
+				ann.Name = val
+			case key == "nojson" && !hasVal:
+				ann.SkipJSON = true
+			case key == "unknown" && !hasVal:
+				ann.KeepUnknown = true
+			case key == "typeurl" && len(val) > 1 && val[0] == '/':
+				ann.TypeURL = val
+			default:
+				return Annotation{}, false, fmt.Errorf("%s: invalid %s option: %q", ann.Pos, AnnotationDirective, opt)
+			}
+		}
+		return ann, true, nil
+	}
+	return Annotation{}, false, nil
+}
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+func TestFindAnnotations(t *testing.T) {
+	const src = `package x
+
+//tomino:generate
+type A struct{}
+
+// B has a doc comment.
+//
+//tomino:generate name=Bee nojson unknown
+type B struct{}
+
+type (
+	//tomino:generate	nojson
+	C struct{}
+
+	NotAnnotated struct{}
+)
+
+//tomino:generated
+type D struct{}
+
+// the directive must be at the start of the comment.
+// //tomino:generate
+type E struct{}
+
+//tomino:generate
+func F() {}
+
+//tomino:generate
+var G int
+`
+	fset := token.NewFileSet()
+	f, err := goparser.ParseFile(fset, "x.go", src, goparser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anns, err := FindAnnotations(fset, []*ast.File{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		Annotation
+		pos string
+	}{
+		{Annotation{TypeName: "A"}, "x.go:3:1"},
+		{Annotation{TypeName: "B", Name: "Bee", SkipJSON: true, KeepUnknown: true}, "x.go:8:1"},
+		{Annotation{TypeName: "C", SkipJSON: true}, "x.go:12:2"},
+	}
+	if len(anns) != len(want) {
+		t.Fatalf("want %d annotations, got %d: %+v", len(want), len(anns), anns)
+	}
+	for i, ann := range anns {
+		if ann.Pos.String() != want[i].pos {
+			t.Errorf("%s: want position %s, got %s", ann.TypeName, want[i].pos, ann.Pos)
+		}
+		ann.Pos = token.Position{}
+		if ann != want[i].Annotation {
+			t.Errorf("want %+v, got %+v", want[i].Annotation, ann)
+		}
+	}
+}
+
+func TestParseAnnotation(t *testing.T) {
+	tt := []struct {
+		comment string
+		want    Annotation
+		ok      bool
+		err     string
+	}{
+		{comment: "//tomino:generate", ok: true},
+		{comment: "//tomino:generate name=Tx", want: Annotation{Name: "Tx"}, ok: true},
+		{comment: "//tomino:generate nojson", want: Annotation{SkipJSON: true}, ok: true},
+		{comment: "//tomino:generate unknown", want: Annotation{KeepUnknown: true}, ok: true},
+		{comment: "//tomino:generate  name=Tx   nojson ", want: Annotation{Name: "Tx", SkipJSON: true}, ok: true},
+		{comment: "//tomino:generate typeurl=/std.Tx", want: Annotation{TypeURL: "/std.Tx"}, ok: true},
+		{comment: "//tomino:generatex"},
+		{comment: "// tomino:generate"},
+		{comment: "//go:generate tomgen"},
+		{comment: "//tomino:generate json", err: `x.go:1:1: invalid //tomino:generate option: "json"`},
+		{comment: "//tomino:generate name", err: `invalid //tomino:generate option: "name"`},
+		{comment: "//tomino:generate name=", err: `invalid //tomino:generate option: "name="`},
+		{comment: "//tomino:generate name=1x", err: `invalid //tomino:generate option: "name=1x"`},
+		{comment: "//tomino:generate nojson=true", err: `invalid //tomino:generate option: "nojson=true"`},
+		{comment: "//tomino:generate typeurl=std.Tx", err: `invalid //tomino:generate option: "typeurl=std.Tx"`},
+		{comment: "//tomino:generate typeurl=/", err: `invalid //tomino:generate option: "typeurl=/"`},
+	}
+	for _, tc := range tt {
+		t.Run(tc.comment, func(t *testing.T) {
+			fset := token.NewFileSet()
+			fset.AddFile("x.go", -1, 100)
+			doc := &ast.CommentGroup{List: []*ast.Comment{{Slash: 1, Text: tc.comment}}}
+			ann, ok, err := parseAnnotation(fset, doc)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("want error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tc.ok {
+				t.Fatalf("want ok = %v, got %v", tc.ok, ok)
+			}
+			ann.Pos = token.Position{}
+			if ann != tc.want {
+				t.Errorf("want %+v, got %+v", tc.want, ann)
+			}
+		})
+	}
+}
+
+func TestAnnotationApply(t *testing.T) {
+	rec := ir.StructRecord{Name: "Transaction", Source: "x.Transaction", TypeURL: "/x.Transaction"}
+	Annotation{Name: "Tx", SkipJSON: true, KeepUnknown: true, TypeURL: "/std.Tx"}.Apply(&rec)
+	want := ir.StructRecord{Name: "Tx", Source: "x.Transaction", SkipJSON: true, KeepUnknown: true, TypeURL: "/std.Tx"}
+	if !reflect.DeepEqual(want, rec) {
+		t.Errorf("want %+v, got %+v", want, rec)
+	}
+
+	// without a name or type URL, those of the type are kept.
+	Annotation{}.Apply(&rec)
+	want = ir.StructRecord{Name: "Tx", Source: "x.Transaction", TypeURL: "/std.Tx"}
+	if !reflect.DeepEqual(want, rec) {
+		t.Errorf("want %+v, got %+v", want, rec)
+	}
+
+	// the structs contained in rec keep their unknown fields too, except for
+	// the well-known types.
+	timeRec := ir.StructRecord{Name: "Time", Source: "time.Time"}
+	inner := ir.StructRecord{Name: "Coin", Source: "x.Coin"}
+	rec = ir.StructRecord{Name: "Tx", Fields: []ir.StructField{
+		{Name: "Coins", Record: ir.RepeatedRecord{Elem: ir.OptionalRecord{Elem: inner}, Size: -1}},
+		{Name: "Time", Record: timeRec},
+	}}
+	orig := rec
+	Annotation{KeepUnknown: true}.Apply(&rec)
+	unknownInner := inner
+	unknownInner.KeepUnknown = true
+	want = ir.StructRecord{Name: "Tx", KeepUnknown: true, Fields: []ir.StructField{
+		{Name: "Coins", Record: ir.RepeatedRecord{Elem: ir.OptionalRecord{Elem: unknownInner}, Size: -1}},
+		{Name: "Time", Record: timeRec},
+	}}
+	if !reflect.DeepEqual(want, rec) {
+		t.Errorf("want %+v, got %+v", want, rec)
+	}
+	if orig.Fields[0].Record.(ir.RepeatedRecord).Elem.(ir.OptionalRecord).Elem.(ir.StructRecord).KeepUnknown {
+		t.Error("the original record must not be modified")
+	}
+}
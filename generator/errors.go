@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+)
+
+// Error is an error encountered while converting a Go type to the IR.
+type Error struct {
+	// Position of the offending field or type declaration.
+	// May be invalid, if the position is not known.
+	Pos token.Position
+	// Path to the offending field, starting from the name of the top-level
+	// type; ie. Tx.Msgs[].Amount.Denom
+	Path string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	var bld strings.Builder
+	if e.Pos.IsValid() {
+		bld.WriteString(e.Pos.String())
+		bld.WriteString(": ")
+	}
+	if e.Path != "" {
+		bld.WriteString(e.Path)
+		bld.WriteString(": ")
+	}
+	bld.WriteString(e.Err.Error())
+	return bld.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// ErrorList is a list of [*Error]. It is returned by [Parse] to report all
+// the problems found in a type, rather than only the first one.
+type ErrorList []*Error
+
+// Add appends an [*Error] to the list.
+func (el *ErrorList) Add(pos token.Position, path string, err error) {
+	*el = append(*el, &Error{Pos: pos, Path: path, Err: err})
+}
+
+// Error returns the errors of the list, one per line.
+func (el ErrorList) Error() string {
+	switch len(el) {
+	case 0:
+		return "no errors"
+	case 1:
+		return el[0].Error()
+	}
+	var bld strings.Builder
+	for i, e := range el {
+		if i > 0 {
+			bld.WriteByte('\n')
+		}
+		bld.WriteString(e.Error())
+	}
+	return bld.String()
+}
+
+// Unwrap returns the errors of the list, allowing them to be inspected using
+// errors.Is and errors.As.
+func (el ErrorList) Unwrap() []error {
+	errs := make([]error, len(el))
+	for i, e := range el {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Err returns an error equivalent to this list.
+// If the list is empty, Err returns nil.
+func (el ErrorList) Err() error {
+	if len(el) == 0 {
+		return nil
+	}
+	return el
+}
+
+// errorf creates a new [*Error] and adds it to el.
+func (el *ErrorList) errorf(pos token.Position, path, format string, args ...any) {
+	el.Add(pos, path, fmt.Errorf(format, args...))
+}
@@ -1,9 +1,11 @@
 package generator
 
 import (
-	"fmt"
+	"errors"
+	"go/token"
 	"go/types"
 	"reflect"
+	"strings"
 
 	"github.com/thehowl/tomino/generator/ir"
 )
@@ -11,23 +13,132 @@ import (
 // Parse contructs an ir.StructRecord from the given Go types.Object.
 // The StructRecord can then be used with programming language specific targets
 // to generate encoder/decoder code.
-func Parse(obj types.Object) (ir.StructRecord, error) {
+//
+// The options of ann are applied to the record, which is then validated; the
+// TypeName of ann is not used. The interfaces in the record cannot hold any
+// value until they are resolved with [ResolveInterfaces]. If the type cannot be converted, or the record
+// is not valid, the returned error is an [ErrorList], containing all of the
+// problems found in the type. fset is used to resolve the positions of the
+// errors, and may be nil.
+func Parse(fset *token.FileSet, obj types.Object, ann Annotation) (ir.StructRecord, error) {
+	p := &parser{fset: fset}
+	if obj == nil {
+		p.errs.errorf(token.Position{}, "", "invalid symbol: <nil>")
+		return ir.StructRecord{}, p.errs
+	}
 	tn, ok := obj.(*types.TypeName)
 	if !ok {
-		return ir.StructRecord{}, fmt.Errorf("invalid symbol: %T", obj)
+		p.errs.errorf(p.position(obj.Pos()), obj.Name(), "invalid symbol: %T", obj)
+		return ir.StructRecord{}, p.errs
 	}
 
 	// TODO: does this work with aliases? (maybe it shouldn't.)
 	tp := tn.Type()
-	rec, err := parse(tp)
-	if err != nil {
+	rec := p.parse(tp, tn.Name(), tn.Pos())
+	if err := p.errs.Err(); err != nil {
 		return ir.StructRecord{}, err
 	}
-	return rec.(ir.StructRecord), nil
+	sr, ok := rec.(ir.StructRecord)
+	if !ok {
+		p.errs.errorf(p.position(tn.Pos()), tn.Name(), "type %s is not a struct (%s)", tp, rec.Kind())
+		return ir.StructRecord{}, p.errs
+	}
+	if pkg := tn.Pkg(); pkg != nil {
+		// like amino, which uses the package name as the default name of the
+		// protobuf package.
+		sr.TypeURL = "/" + pkg.Name() + "." + tn.Name()
+	}
+	ann.Apply(&sr)
+	if err := sr.Validate(); err != nil {
+		p.validationErrors(tn, err)
+		return ir.StructRecord{}, p.errs
+	}
+	return sr, nil
+}
+
+// parser holds the state while converting a type to the IR.
+type parser struct {
+	fset *token.FileSet
+	errs ErrorList
+	// named types currently being parsed, to detect recursive types.
+	parsing []*types.Named
+}
+
+func (p *parser) position(pos token.Pos) token.Position {
+	if p.fset == nil {
+		return token.Position{}
+	}
+	return p.fset.Position(pos)
+}
+
+// validationErrors adds the errors returned by ir.StructRecord.Validate to
+// p.errs, at the position of the offending field.
+func (p *parser) validationErrors(obj types.Object, err error) {
+	var ve ir.ValidationErrors
+	if !errors.As(err, &ve) {
+		p.errs.Add(p.position(obj.Pos()), obj.Name(), err)
+		return
+	}
+	for _, e := range ve {
+		p.errs.Add(p.position(fieldPos(obj, e.Path)), e.Path, e.Err)
+	}
 }
 
-func parse(tp types.Type) (ir.Record, error) {
-	// TODO: change to custom error type.
+// fieldPos returns the position of the struct field at path, in the format
+// of ir.ValidationError.Path, starting from the type declared by obj.
+// If the path can't be resolved entirely, as for the fields of well-known
+// types, the position of the last field found is returned instead, or that of
+// obj itself.
+func fieldPos(obj types.Object, path string) token.Pos {
+	pos, tp := obj.Pos(), obj.Type()
+	names := strings.Split(path, ".")
+	// the first element is the name of the record, which may differ from the
+	// name of the type.
+	for _, name := range names[1:] {
+		name = strings.TrimRight(name, "[]")
+		st := structOf(tp)
+		if st == nil {
+			return pos
+		}
+		var fld *types.Var
+		for i := 0; i < st.NumFields(); i++ {
+			if st.Field(i).Name() == name {
+				fld = st.Field(i)
+				break
+			}
+		}
+		if fld == nil {
+			return pos
+		}
+		pos, tp = fld.Pos(), fld.Type()
+	}
+	return pos
+}
+
+// structOf returns the struct type of tp, or that of its elements if tp is a
+// pointer, slice or array. It returns nil if there is none.
+func structOf(tp types.Type) *types.Struct {
+	for {
+		switch t := tp.Underlying().(type) {
+		case *types.Struct:
+			return t
+		case *types.Pointer:
+			tp = t.Elem()
+		case *types.Slice:
+			tp = t.Elem()
+		case *types.Array:
+			tp = t.Elem()
+		default:
+			return nil
+		}
+	}
+}
+
+// parse converts tp into an ir.Record. path is the path of the type being
+// parsed, for error reporting; pos is the position of the field or type
+// declaration it comes from.
+// If an error is encountered, it is added to p.errs and parse returns nil.
+func (p *parser) parse(tp types.Type, path string, pos token.Pos) ir.Record {
 	switch tp := tp.(type) {
 	case *types.Basic:
 		sr := ir.ScalarRecord{Name: tp.Name()}
@@ -37,21 +148,31 @@ func parse(tp types.Type) (ir.Record, error) {
 		case "rune":
 			sr.Name = "int32"
 		case "string":
-			return ir.BytesRecord{String: true, Size: -1}, nil
+			return ir.BytesRecord{String: true, Size: -1}
 		}
 
-		return sr, sr.Validate()
+		if err := sr.Validate(); err != nil {
+			p.errs.Add(p.position(pos), path, err)
+			return nil
+		}
+		return sr
 	case *types.Pointer:
-		if _, isPtr := tp.Elem().Underlying().(*types.Pointer); isPtr {
-			return nil, fmt.Errorf("type %v is pointer of pointer", tp.String())
+		switch tp.Elem().Underlying().(type) {
+		case *types.Pointer:
+			p.errs.errorf(p.position(pos), path, "type %v is pointer of pointer", tp.String())
+			return nil
+		case *types.Interface:
+			p.errs.errorf(p.position(pos), path, "type %v is pointer to interface", tp.String())
+			return nil
 		}
-		v, err := parse(tp.Elem())
-		if err != nil {
-			return nil, err
+		v := p.parse(tp.Elem(), path, pos)
+		if v == nil {
+			return nil
 		}
-		return ir.OptionalRecord{Elem: v}, nil
+		return ir.OptionalRecord{Elem: v}
 	case *types.Struct:
 		flds := make([]ir.StructField, 0, tp.NumFields())
+		failed := false
 		for i := 0; i < tp.NumFields(); i++ {
 			fld := tp.Field(i)
 			if !fld.Exported() {
@@ -69,53 +190,67 @@ func parse(tp types.Type) (ir.Record, error) {
 			if skip {
 				continue
 			}
-			var err error
-			sf.Record, err = parse(fld.Type())
-			if err != nil {
-				return nil, err
+			// keep parsing the other fields on error, so we can report all of
+			// the errors in the struct.
+			sf.Record = p.parse(fld.Type(), path+"."+fld.Name(), fld.Pos())
+			if sf.Record == nil {
+				failed = true
 			}
 			flds = append(flds, sf)
 		}
-		return ir.StructRecord{Fields: flds}, nil
+		if failed {
+			return nil
+		}
+		return ir.StructRecord{Fields: flds}
 	case *types.Array:
 		if isUint8(tp.Elem()) {
-			return ir.BytesRecord{Size: tp.Len()}, nil
+			return ir.BytesRecord{Size: tp.Len()}
 		}
-		elem, err := parse(tp.Elem())
-		if err != nil {
-			return nil, err
+		elem := p.parse(tp.Elem(), path+"[]", pos)
+		if elem == nil {
+			return nil
 		}
-		return ir.RepeatedRecord{Elem: elem, Size: tp.Len()}, nil
+		return ir.RepeatedRecord{Elem: elem, Size: tp.Len()}
 	case *types.Slice:
 		if isUint8(tp.Elem()) {
-			return ir.BytesRecord{Size: -1}, nil
+			return ir.BytesRecord{Size: -1}
 		}
-		elem, err := parse(tp.Elem())
-		if err != nil {
-			return nil, err
+		elem := p.parse(tp.Elem(), path+"[]", pos)
+		if elem == nil {
+			return nil
 		}
-		return ir.RepeatedRecord{Elem: elem, Size: -1}, nil
+		return ir.RepeatedRecord{Elem: elem, Size: -1}
 	case *types.Interface:
-		panic("not implemented")
+		// the types it can hold are set by ResolveInterfaces.
+		return ir.AnyRecord{Source: tp.String()}
 	case *types.Named:
 		if sr, ok := findWellKnown(tp); ok {
-			return sr, nil
+			return sr
+		}
+		if _, ok := tp.Underlying().(*types.Interface); ok {
+			return ir.AnyRecord{Source: tp.String()}
+		}
+		for _, n := range p.parsing {
+			if n == tp {
+				p.errs.errorf(p.position(pos), path, "recursive type %v is not supported", tp)
+				return nil
+			}
 		}
 
 		// TODO: should centralize names in a registry so we re-use encoders.
 		// TODO: should understand a type having AminoMarshal / AminoUnmarshal.
-		parsed, err := parse(tp.Underlying())
-		if err != nil {
-			return nil, err
-		}
+		p.parsing = append(p.parsing, tp)
+		parsed := p.parse(tp.Underlying(), path, pos)
+		p.parsing = p.parsing[:len(p.parsing)-1]
 		if str, ok := parsed.(ir.StructRecord); ok {
 			str.Name = tp.Obj().Name()
 			str.Source = tp.String()
-			return str, nil
+			return str
 		}
-		return parsed, err
+		return parsed
 	default:
-		return nil, fmt.Errorf("unsupported type: %T (%v)", tp, tp)
+		p.errs.errorf(p.position(pos), path, "unsupported type: %T (%v)", tp, tp)
+		return nil
 	}
 }
 
@@ -133,7 +268,8 @@ func isUint8(tp types.Type) bool {
 // different.
 func findWellKnown(tp *types.Named) (ir.StructRecord, bool) {
 	obj := tp.Obj()
-	if obj.Pkg().Path() != "time" {
+	// Pkg is nil for universe types, like error.
+	if obj.Pkg() == nil || obj.Pkg().Path() != "time" {
 		return ir.StructRecord{}, false
 	}
 	timeFields := []ir.StructField{
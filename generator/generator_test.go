@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+// parseSource type-checks src, and parses the type name declared in it.
+func parseSource(t *testing.T, src, name string) (ir.StructRecord, error) {
+	t.Helper()
+	return parseAnnotated(t, src, name, Annotation{})
+}
+
+// parseAnnotated is like parseSource, applying ann to the parsed type.
+func parseAnnotated(t *testing.T, src, name string, ann Annotation) (ir.StructRecord, error) {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkg := checkFile(t, fset, src)
+	return Parse(fset, pkg.Scope().Lookup(name), ann)
+}
+
+// checkFile type-checks src, as the file x.go of package x.
+func checkFile(t *testing.T, fset *token.FileSet, src string) *types.Package {
+	t.Helper()
+	f, err := goparser.ParseFile(fset, "x.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("x", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestParseFloat(t *testing.T) {
+	const src = `package x
+
+type Safe struct {
+	A float64   ` + "`amino:\"unsafe\"`" + `
+	B []float32 ` + "`amino:\"unsafe\"`" + `
+}
+
+type Unsafe struct {
+	A float64
+	B *float32 ` + "`amino:\"write_empty\"`" + `
+}
+`
+	rec, err := parseSource(t, src, "Safe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range rec.Fields {
+		if f.TagFlag&ir.Unsafe == 0 {
+			t.Errorf("Safe.%s: want the unsafe flag, got %q", f.Name, f.TagFlag)
+		}
+	}
+
+	// floats without the unsafe tag are rejected by the validation.
+	_, err = parseSource(t, src, "Unsafe")
+	if err == nil {
+		t.Fatal("Unsafe: want an error")
+	}
+	for _, want := range []string{
+		"x.go:9:2: Unsafe.A: floating points must be used with the `amino:\"unsafe\"` struct tag",
+		"x.go:10:2: Unsafe.B: floating points must be used with the `amino:\"unsafe\"` struct tag",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Unsafe: want error %q, got %q", want, err)
+		}
+	}
+}
+
+func TestParseInterface(t *testing.T) {
+	const src = `package x
+
+type Tx struct {
+	Msgs []Msg
+	Memo any
+}
+
+type Msg interface{ Route() string }
+
+type Send struct{ To string }
+
+func (Send) Route() string { return "bank" }
+
+type Call struct{ Func string }
+
+func (*Call) Route() string { return "vm" }
+
+type Bad struct {
+	Msg *Msg
+}
+`
+	pkg := checkFile(t, token.NewFileSet(), src)
+	objs := []types.Object{pkg.Scope().Lookup("Tx"), pkg.Scope().Lookup("Send"), pkg.Scope().Lookup("Call")}
+	records := make([]ir.StructRecord, len(objs))
+	for i, obj := range objs {
+		rec, err := Parse(nil, obj, Annotation{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		records[i] = rec
+	}
+	if want := "/x.Send"; records[1].TypeURL != want {
+		t.Errorf("want type URL %q, got %q", want, records[1].TypeURL)
+	}
+	if err := ResolveInterfaces(records, objs); err != nil {
+		t.Fatal(err)
+	}
+	want := []ir.Record{
+		ir.RepeatedRecord{Elem: ir.AnyRecord{Source: "x.Msg", Subset: []string{"x.Send", "*x.Call"}}, Size: -1},
+		ir.AnyRecord{Source: "any", Subset: []string{"x.Tx", "x.Send", "x.Call"}},
+	}
+	for i, fld := range records[0].Fields {
+		if !reflect.DeepEqual(want[i], fld.Record) {
+			t.Errorf("%s: want %+v, got %+v", fld.Name, want[i], fld.Record)
+		}
+	}
+
+	// the interfaces must be implemented by one of the types.
+	err := ResolveInterfaces(records[:1], objs[:1])
+	const wantErr = "interface x.Msg is not implemented by any of the types"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("want error %q, got %v", wantErr, err)
+	}
+
+	// pointers to interfaces are rejected.
+	_, err = Parse(nil, pkg.Scope().Lookup("Bad"), Annotation{})
+	const wantPtr = "Bad.Msg: type *x.Msg is pointer to interface"
+	if err == nil || err.Error() != wantPtr {
+		t.Errorf("want error %q, got %v", wantPtr, err)
+	}
+}
+
+func TestParseAnnotated(t *testing.T) {
+	const src = `package x
+
+type Tx struct {
+	A          string
+	XXX_unknown []byte
+}
+`
+	rec, err := parseSource(t, src, "Tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Name != "Tx" || rec.KeepUnknown {
+		t.Errorf("want Tx without KeepUnknown, got %s (KeepUnknown: %t)", rec.Name, rec.KeepUnknown)
+	}
+
+	// the record is validated after applying the annotation.
+	_, err = parseAnnotated(t, src, "Tx", Annotation{Name: "Transaction", KeepUnknown: true})
+	const want = "x.go:5:2: Transaction.XXX_unknown: field name XXX_unknown is reserved for the unknown fields"
+	if err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+}
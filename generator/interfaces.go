@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+// ResolveInterfaces sets the Subset of the AnyRecords in records, which must
+// have been returned by [Parse] for the corresponding objs.
+//
+// An interface can hold the types of objs which implement it: the interface
+// holds values of the type if they implement it, or pointers to them if only
+// the pointer type does, like amino's pointer-preferred types. Like with
+// amino, where the concrete types of interfaces must be registered, values of
+// any other type cannot be encoded. ResolveInterfaces returns an error if an
+// interface has no implementations.
+func ResolveInterfaces(records []ir.StructRecord, objs []types.Object) error {
+	ifaces := make(map[string]*types.Interface)
+	seen := make(map[types.Type]bool)
+	for _, obj := range objs {
+		collectInterfaces(obj.Type(), ifaces, seen)
+	}
+	if len(ifaces) == 0 {
+		return nil
+	}
+
+	srcs := make([]string, 0, len(ifaces))
+	for src := range ifaces {
+		srcs = append(srcs, src)
+	}
+	slices.Sort(srcs)
+
+	subsets := make(map[string][]string, len(ifaces))
+	var errs ErrorList
+	for _, src := range srcs {
+		var subset []string
+		for _, obj := range objs {
+			tp := obj.Type()
+			switch {
+			case types.Implements(tp, ifaces[src]):
+				subset = append(subset, tp.String())
+			case types.Implements(types.NewPointer(tp), ifaces[src]):
+				subset = append(subset, "*"+tp.String())
+			}
+		}
+		if len(subset) == 0 {
+			errs.errorf(token.Position{}, "", "interface %s is not implemented by any of the types", src)
+		}
+		subsets[src] = subset
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	for i, rec := range records {
+		records[i] = resolveAny(rec, subsets).(ir.StructRecord)
+	}
+	return nil
+}
+
+// collectInterfaces adds the interfaces contained in tp to ifaces, keyed by
+// the Source of their AnyRecords.
+func collectInterfaces(tp types.Type, ifaces map[string]*types.Interface, seen map[types.Type]bool) {
+	if seen[tp] {
+		return
+	}
+	seen[tp] = true
+	switch t := tp.(type) {
+	case *types.Interface:
+		ifaces[t.String()] = t
+	case *types.Named:
+		if it, ok := t.Underlying().(*types.Interface); ok {
+			ifaces[t.String()] = it
+			return
+		}
+		collectInterfaces(t.Underlying(), ifaces, seen)
+	case *types.Pointer:
+		collectInterfaces(t.Elem(), ifaces, seen)
+	case *types.Slice:
+		collectInterfaces(t.Elem(), ifaces, seen)
+	case *types.Array:
+		collectInterfaces(t.Elem(), ifaces, seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			collectInterfaces(t.Field(i).Type(), ifaces, seen)
+		}
+	}
+}
+
+// resolveAny returns a copy of rec where the Subset of the AnyRecords is set
+// from subsets.
+func resolveAny(rec ir.Record, subsets map[string][]string) ir.Record {
+	switch rec := rec.(type) {
+	case ir.AnyRecord:
+		rec.Subset = subsets[rec.Source]
+		return rec
+	case ir.StructRecord:
+		rec.Fields = slices.Clone(rec.Fields)
+		for i, fld := range rec.Fields {
+			rec.Fields[i].Record = resolveAny(fld.Record, subsets)
+		}
+		return rec
+	case ir.RepeatedRecord:
+		rec.Elem = resolveAny(rec.Elem, subsets)
+		return rec
+	case ir.OptionalRecord:
+		rec.Elem = resolveAny(rec.Elem, subsets)
+		return rec
+	case ir.NamedRecord:
+		rec.Elem = resolveAny(rec.Elem, subsets)
+		return rec
+	default:
+		return rec
+	}
+}
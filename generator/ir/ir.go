@@ -2,13 +2,16 @@ package ir
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"reflect"
 	"slices"
 	"strings"
 )
 
+// UnknownFieldName is the name of the field holding the encoded unknown fields
+// of a StructRecord with KeepUnknown.
+const UnknownFieldName = "XXX_unknown"
+
 type TagFlag byte
 
 const (
@@ -71,6 +74,15 @@ type (
 		Name   string
 		Source string
 		Fields []StructField
+		// Do not generate JSON encoders for this record.
+		SkipJSON bool
+		// Keep the unknown fields found when decoding, in a field named
+		// UnknownFieldName, and write them back when encoding.
+		KeepUnknown bool
+		// TypeURL identifies the type in the google.protobuf.Any values
+		// holding it, like "/tm.PubKeyEd25519". It is set for the top-level
+		// records, which can be the values of interfaces.
+		TypeURL string
 	}
 
 	// individual field of the struct
@@ -93,6 +105,11 @@ type (
 
 	// interfaces
 	AnyRecord struct {
+		// Source is the Go type of the interface.
+		Source string
+		// Subset are the types the interface can hold: the Source of their
+		// StructRecord, prefixed by "*" if the interface holds pointers to
+		// them, like amino's pointer-preferred types.
 		Subset []string
 	}
 
@@ -121,81 +138,67 @@ type (
 func (StructRecord) assertRecord() {}
 func (StructRecord) Kind() string  { return "struct" }
 func (s StructRecord) Validate() error {
-	for _, fld := range s.Fields {
-		// can only use fixed flags on appropriate types.
-		if fld.TagFlag&BinFixed64 != 0 {
-			switch fld.Record {
-			case ScalarRecord{Name: "uint64"}, ScalarRecord{Name: "int64"}, ScalarRecord{Name: "float64"}:
-			default:
-				return fmt.Errorf("invalid record for usage with fixed64: %v", fld.Record)
-			}
-		}
-		if fld.TagFlag&BinFixed32 != 0 {
-			switch fld.Record {
-			case ScalarRecord{Name: "uint32"}, ScalarRecord{Name: "int32"}, ScalarRecord{Name: "float32"}:
-			default:
-				return fmt.Errorf("invalid record for usage with fixed32: %v", fld.Record)
-			}
-		}
-		if err := fld.Record.Validate(); err != nil {
-			return err
-		}
-	}
-	return nil
+	var v validator
+	Walk(&v, s)
+	return v.errs.Err()
 }
 
 func (RepeatedRecord) assertRecord() {}
 func (RepeatedRecord) Kind() string  { return "repeated" }
 func (rr RepeatedRecord) Validate() error {
-	if rr.Size < 0 && rr.Size != -1 {
-		return fmt.Errorf("invalid size: %d", rr.Size)
-	}
-	if rr.Elem == (ScalarRecord{Name: "uint8"}) {
-		return errors.New("elem of RepeatedRecord cannot be uint8 (should use BytesRecord instead)")
-	}
-	return rr.Elem.Validate()
+	var v validator
+	Walk(&v, rr)
+	return v.errs.Err()
 }
 
 func (ScalarRecord) assertRecord() {}
 func (ScalarRecord) Kind() string  { return "scalar" }
 func (s ScalarRecord) Validate() error {
-	switch s.Name {
-	case "bool",
-		"int", "int8", "int16", "int32", "int64",
-		"uint", "uint8", "uint16", "uint32", "uint64",
-		"float32", "float64":
-		return nil
-	default:
-		return fmt.Errorf("invalid scalar record: %q", s.Name)
-	}
+	var v validator
+	Walk(&v, s)
+	return v.errs.Err()
 }
 
 func (OptionalRecord) assertRecord() {}
 func (OptionalRecord) Kind() string  { return "optional" }
 func (or OptionalRecord) Validate() error {
-	if or.Elem == nil {
-		return errors.New("OptionalRecord on nil")
-	}
-	return or.Elem.Validate()
+	var v validator
+	Walk(&v, or)
+	return v.errs.Err()
 }
 
 func (BytesRecord) assertRecord() {}
 func (BytesRecord) Kind() string  { return "bytes" }
 func (br BytesRecord) Validate() error {
-	if br.String && br.Size != -1 {
-		return errors.New("string BytesRecord must have size = -1")
-	}
-	if br.Size < 0 && br.Size != -1 {
-		return fmt.Errorf("invalid size: %d", br.Size)
-	}
-	return nil
+	var v validator
+	Walk(&v, br)
+	return v.errs.Err()
+}
+
+func (AnyRecord) assertRecord() {}
+func (AnyRecord) Kind() string  { return "any" }
+func (ar AnyRecord) Validate() error {
+	var v validator
+	Walk(&v, ar)
+	return v.errs.Err()
+}
+
+func (NamedRecord) assertRecord() {}
+func (NamedRecord) Kind() string  { return "named" }
+func (nr NamedRecord) Validate() error {
+	var v validator
+	Walk(&v, nr)
+	return v.errs.Err()
 }
 
 var (
 	_ Record = StructRecord{}
 	_ Record = ScalarRecord{}
+	_ Record = RepeatedRecord{}
 	_ Record = OptionalRecord{}
 	_ Record = BytesRecord{}
+	_ Record = AnyRecord{}
+	_ Record = NamedRecord{}
 )
 
 func (p *StructField) ParseTag(tag reflect.StructTag) (skip bool) {
@@ -247,53 +250,69 @@ func (p *StructField) ParseTag(tag reflect.StructTag) (skip bool) {
 }
 
 func (p StructField) Validate() error {
-	switch {
-	case p.TagFlag&BinFixed32 != 0 &&
-		p.Record != ScalarRecord{"int32"} &&
-		p.Record != ScalarRecord{"uint32"}:
-		return errors.New("tag fixed32 may only be used on uint32 or int32")
-	case p.TagFlag&BinFixed64 != 0 &&
-		p.Record != ScalarRecord{"int64"} &&
-		p.Record != ScalarRecord{"uint64"}:
-		return errors.New("tag fixed64 may only be used on uint64 or int64")
-	case p.TagFlag&Unsafe == 0 &&
-		(p.Record == ScalarRecord{"float64"} ||
-			p.Record == ScalarRecord{"float32"}):
-		return errors.New("floating points must be used with the `amino:\"unsafe\"` struct tag")
+	var v validator
+	v.field(p.Name, p)
+	if p.Record != nil {
+		walk(&v, &Node{Path: p.Name, Record: p.Record, Field: &p})
 	}
-	return nil
+	return v.errs.Err()
 }
 
 func (p StructField) String() string {
 	return fmt.Sprintf("%04d=%s[%s] { %v }", p.BinFieldNum, p.Name, p.TagFlag.String(), p.Record)
 }
 
-func (p StructField) Tag() []byte {
-	const (
-		recordTypeVarint = 0
-		recordTypeI64    = 1
-		recordTypeLen    = 2
-		recordTypeI32    = 5
-	)
+// WireType is the type of a record in the binary encoding, which determines
+// the size of its value.
+type WireType byte
+
+const (
+	WireVarint WireType = 0
+	WireI64    WireType = 1
+	WireLen    WireType = 2
+	WireI32    WireType = 5
+)
 
+func (w WireType) String() string {
+	switch w {
+	case WireVarint:
+		return "varint"
+	case WireI64:
+		return "i64"
+	case WireLen:
+		return "len"
+	case WireI32:
+		return "i32"
+	default:
+		return fmt.Sprintf("WireType(%d)", byte(w))
+	}
+}
+
+// WireType returns the wire type used to encode the field's record.
+func (p StructField) WireType() WireType {
 	if _, ok := p.Record.(OptionalRecord); ok {
-		panic("StructField.Tag on OptionalRecord; you should handle the OptionalRecord then use .WithRecord on the Elem.")
+		panic("StructField.WireType on OptionalRecord; you should handle the OptionalRecord then use .WithRecord on the Elem.")
 	}
 
 	// NOTE: here we don't validate whether the type should be a BinFixed64/32.
 	// it's done as part of StructField.Validate.
-	x := uint64(p.BinFieldNum) << 3
 	sr, isScalar := p.Record.(ScalarRecord)
-	if p.TagFlag&BinFixed64 != 0 || sr.Name == "float64" {
-		x |= recordTypeI64
-	} else if p.TagFlag&BinFixed32 != 0 || sr.Name == "float32" {
-		x |= recordTypeI32
-	} else if isScalar {
-		x |= recordTypeVarint
-	} else {
-		x |= recordTypeLen
+	switch {
+	case !isScalar:
+		return WireLen
+	case p.TagFlag&BinFixed64 != 0 || sr.Name == "float64":
+		return WireI64
+	case p.TagFlag&BinFixed32 != 0 || sr.Name == "float32":
+		return WireI32
+	default:
+		return WireVarint
 	}
+}
 
+// Tag returns the encoded tag of the field, containing the field number and
+// the wire type.
+func (p StructField) Tag() []byte {
+	x := uint64(p.BinFieldNum)<<3 | uint64(p.WireType())
 	var buf [10]byte
 	return buf[:binary.PutUvarint(buf[:], x)]
 }
@@ -322,7 +341,4 @@ func (p ScalarRecord) IsUnsigned() bool {
 - If the child is a non-bytelength, then we can encode it in packed form.
 - What is ReprType realy? Should we encode []byte as bytes, even when it's a type of bytes?
 - Consider what will happen when we have []byte as a result of MarshalAmino (ie returns byte, but it's an array).
-- Multidimensional lists (could start off by rejecting them, simply.)
-
-TODO: OptionalRecord
-- Support it in StructField.Validate */
+- Multidimensional lists (could start off by rejecting them, simply.) */
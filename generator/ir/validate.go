@@ -0,0 +1,210 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxBinFieldNum is the maximum field number which can be used in the binary
+// encoding, as in protobuf (2^29-1).
+const MaxBinFieldNum = 1<<29 - 1
+
+// ValidationError is a violation of the invariants of the IR.
+type ValidationError struct {
+	// Path to the offending record, starting from the name of the top-level
+	// record; ie. Tx.Msgs[].Amount.Denom
+	Path string
+	Err  error
+}
+
+func (v *ValidationError) Error() string {
+	if v.Path == "" {
+		return v.Err.Error()
+	}
+	return v.Path + ": " + v.Err.Error()
+}
+
+func (v *ValidationError) Unwrap() error { return v.Err }
+
+// ValidationErrors is the list of all violations found when validating a
+// record, returned by the Validate methods.
+type ValidationErrors []*ValidationError
+
+// Error returns the errors of the list, one per line.
+func (ve ValidationErrors) Error() string {
+	s := make([]string, len(ve))
+	for i, e := range ve {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Unwrap returns the errors of the list, allowing them to be inspected using
+// errors.Is and errors.As.
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(ve))
+	for i, e := range ve {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Err returns an error equivalent to this list.
+// If the list is empty, Err returns nil.
+func (ve ValidationErrors) Err() error {
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// validator collects the violations found in a record. It is a [Visitor],
+// used with [Walk] to implement the Validate methods of all records.
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Err: fmt.Errorf(format, args...)})
+}
+
+// Pre validates the record of n, but not its children, which are visited by
+// Walk. Nil children are skipped by Walk, so they are reported here instead.
+func (v *validator) Pre(n *Node) bool {
+	path := n.Path
+	switch rec := n.Record.(type) {
+	case ScalarRecord:
+		switch rec.Name {
+		case "bool",
+			"int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+		default:
+			v.errorf(path, "invalid scalar record: %q", rec.Name)
+		}
+	case StructRecord:
+		v.structRecord(path, rec)
+	case RepeatedRecord:
+		if rec.Size < 0 && rec.Size != -1 {
+			v.errorf(path, "invalid size: %d", rec.Size)
+		}
+		if rec.Elem == (ScalarRecord{Name: "uint8"}) {
+			v.errorf(path, "elem of RepeatedRecord cannot be uint8 (should use BytesRecord instead)")
+			return false
+		}
+		if rec.Elem == nil {
+			v.errorf(path+"[]", "nil record")
+		}
+	case OptionalRecord:
+		switch rec.Elem.(type) {
+		case OptionalRecord:
+			v.errorf(path, "OptionalRecord of OptionalRecord")
+			return false
+		case AnyRecord:
+			v.errorf(path, "OptionalRecord of AnyRecord (pointers to interfaces are not supported)")
+			return false
+		}
+		if rec.Elem == nil {
+			v.errorf(path, "OptionalRecord on nil")
+		}
+	case BytesRecord:
+		if rec.String && rec.Size != -1 {
+			v.errorf(path, "string BytesRecord must have size = -1")
+		}
+		if rec.Size < 0 && rec.Size != -1 {
+			v.errorf(path, "invalid size: %d", rec.Size)
+		}
+	case AnyRecord:
+		for _, name := range rec.Subset {
+			if strings.TrimPrefix(name, "*") == "" {
+				v.errorf(path, "AnyRecord with empty name in subset")
+			}
+		}
+	case NamedRecord:
+		if rec.Name == "" {
+			v.errorf(path, "NamedRecord without a name")
+		}
+		if rec.Elem == nil {
+			v.errorf(path, "nil record")
+		}
+	default:
+		v.errorf(path, "unsupported record kind: %s", rec.Kind())
+	}
+	return true
+}
+
+func (v *validator) Post(*Node) {}
+
+func (v *validator) structRecord(path string, s StructRecord) {
+	if s.TypeURL != "" && !strings.HasPrefix(s.TypeURL, "/") {
+		v.errorf(path, "type URL %q does not start with /", s.TypeURL)
+	}
+	nums := make(map[uint32]string, len(s.Fields))
+	jsonNames := make(map[string]string, len(s.Fields))
+	for _, fld := range s.Fields {
+		fldPath := fld.Name
+		if path != "" {
+			fldPath = path + "." + fld.Name
+		}
+
+		if s.KeepUnknown && fld.Name == UnknownFieldName {
+			v.errorf(fldPath, "field name %s is reserved for the unknown fields", UnknownFieldName)
+		}
+		switch {
+		case fld.BinFieldNum == 0:
+			v.errorf(fldPath, "invalid field number 0")
+		case fld.BinFieldNum > MaxBinFieldNum:
+			v.errorf(fldPath, "field number %d is larger than the maximum %d", fld.BinFieldNum, MaxBinFieldNum)
+		}
+		if other, ok := nums[fld.BinFieldNum]; ok {
+			v.errorf(fldPath, "field number %d already used by field %s", fld.BinFieldNum, other)
+		} else {
+			nums[fld.BinFieldNum] = fld.Name
+		}
+		if other, ok := jsonNames[fld.JSONName]; ok {
+			v.errorf(fldPath, "JSON name %q already used by field %s", fld.JSONName, other)
+		} else {
+			jsonNames[fld.JSONName] = fld.Name
+		}
+
+		v.field(fldPath, fld)
+	}
+}
+
+// field validates the tags of the field p. Its record is validated by Walk.
+func (v *validator) field(path string, p StructField) {
+	if p.Name == "" {
+		v.errorf(path, "field has no name")
+	}
+
+	// Tags apply to the "leaf" scalar, also for pointers and slices/arrays
+	// (where they apply to the elements).
+	leaf := p.Record
+	for {
+		if rr, ok := leaf.(RepeatedRecord); ok {
+			leaf = rr.Elem
+		} else if or, ok := leaf.(OptionalRecord); ok {
+			leaf = or.Elem
+		} else {
+			break
+		}
+	}
+	sr, _ := leaf.(ScalarRecord)
+
+	switch {
+	case p.TagFlag&BinFixed64 != 0 && p.TagFlag&BinFixed32 != 0:
+		v.errorf(path, "tags fixed64 and fixed32 may not be used together")
+	case p.TagFlag&BinFixed64 != 0 &&
+		sr.Name != "int64" && sr.Name != "uint64" && sr.Name != "float64":
+		v.errorf(path, "tag fixed64 may only be used on int64, uint64 or float64")
+	case p.TagFlag&BinFixed32 != 0 &&
+		sr.Name != "int32" && sr.Name != "uint32" && sr.Name != "float32":
+		v.errorf(path, "tag fixed32 may only be used on int32, uint32 or float32")
+	}
+	if p.TagFlag&Unsafe == 0 && (sr.Name == "float64" || sr.Name == "float32") {
+		v.errorf(path, "floating points must be used with the `amino:\"unsafe\"` struct tag")
+	}
+	if p.Record == nil {
+		v.errorf(path, "nil record")
+	}
+}
@@ -0,0 +1,229 @@
+package ir
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var (
+		int64Rec   = ScalarRecord{Name: "int64"}
+		stringRec  = BytesRecord{String: true, Size: -1}
+		float64Rec = ScalarRecord{Name: "float64"}
+	)
+	field := func(name string, num uint32, rec Record) StructField {
+		return StructField{Name: name, JSONName: name, BinFieldNum: num, Record: rec}
+	}
+	withFlag := func(f StructField, flag TagFlag) StructField {
+		f.TagFlag |= flag
+		return f
+	}
+	tx := func(fields ...StructField) StructRecord {
+		return StructRecord{Name: "Tx", Fields: fields}
+	}
+
+	tt := []struct {
+		name string
+		rec  Record
+		// errors, formatted as ValidationError.
+		want []string
+	}{
+		{
+			name: "valid",
+			rec: tx(
+				field("A", 1, int64Rec),
+				field("B", 2, stringRec),
+				withFlag(field("C", 3, float64Rec), Unsafe|BinFixed64),
+				field("D", MaxBinFieldNum, RepeatedRecord{Elem: OptionalRecord{Elem: int64Rec}, Size: -1}),
+			),
+		},
+		{
+			name: "duplicate field numbers",
+			rec:  tx(field("A", 1, int64Rec), field("B", 2, int64Rec), field("C", 1, int64Rec)),
+			want: []string{"Tx.C: field number 1 already used by field A"},
+		},
+		{
+			name: "duplicate JSON names",
+			rec: tx(
+				field("A", 1, int64Rec),
+				StructField{Name: "B", JSONName: "A", BinFieldNum: 2, Record: int64Rec},
+			),
+			want: []string{`Tx.B: JSON name "A" already used by field A`},
+		},
+		{
+			name: "field number 0",
+			rec:  tx(field("A", 0, int64Rec)),
+			want: []string{"Tx.A: invalid field number 0"},
+		},
+		{
+			name: "field number too large",
+			rec:  tx(field("A", MaxBinFieldNum+1, int64Rec)),
+			want: []string{"Tx.A: field number 536870912 is larger than the maximum 536870911"},
+		},
+		{
+			name: "fixed64 on non-integer",
+			rec:  tx(withFlag(field("A", 1, stringRec), BinFixed64)),
+			want: []string{"Tx.A: tag fixed64 may only be used on int64, uint64 or float64"},
+		},
+		{
+			name: "fixed64 on int32",
+			rec:  tx(withFlag(field("A", 1, ScalarRecord{Name: "int32"}), BinFixed64)),
+			want: []string{"Tx.A: tag fixed64 may only be used on int64, uint64 or float64"},
+		},
+		{
+			name: "fixed32 on non-integer",
+			rec:  tx(withFlag(field("A", 1, ScalarRecord{Name: "bool"}), BinFixed32)),
+			want: []string{"Tx.A: tag fixed32 may only be used on int32, uint32 or float32"},
+		},
+		{
+			name: "fixed64 and fixed32",
+			rec:  tx(withFlag(field("A", 1, int64Rec), BinFixed64|BinFixed32)),
+			want: []string{"Tx.A: tags fixed64 and fixed32 may not be used together"},
+		},
+		{
+			name: "fixed64 on elements",
+			rec: tx(withFlag(field("A", 1, RepeatedRecord{
+				Elem: OptionalRecord{Elem: ScalarRecord{Name: "uint64"}}, Size: -1,
+			}), BinFixed64)),
+		},
+		{
+			name: "float without unsafe",
+			rec:  tx(field("A", 1, float64Rec)),
+			want: []string{"Tx.A: floating points must be used with the `amino:\"unsafe\"` struct tag"},
+		},
+		{
+			name: "float32 elements without unsafe",
+			rec:  tx(field("A", 1, RepeatedRecord{Elem: ScalarRecord{Name: "float32"}, Size: 2})),
+			want: []string{"Tx.A: floating points must be used with the `amino:\"unsafe\"` struct tag"},
+		},
+		{
+			name: "nested optionals",
+			rec:  tx(field("A", 1, OptionalRecord{Elem: OptionalRecord{Elem: int64Rec}})),
+			want: []string{"Tx.A: OptionalRecord of OptionalRecord"},
+		},
+		{
+			name: "nil records",
+			rec: tx(
+				field("A", 1, nil),
+				field("B", 2, RepeatedRecord{Size: -1}),
+				field("C", 3, OptionalRecord{}),
+			),
+			want: []string{
+				"Tx.A: nil record",
+				"Tx.B[]: nil record",
+				"Tx.C: OptionalRecord on nil",
+			},
+		},
+		{
+			name: "reserved unknown field name",
+			rec: StructRecord{
+				Name:        "Tx",
+				Fields:      []StructField{field(UnknownFieldName, 1, int64Rec)},
+				KeepUnknown: true,
+			},
+			want: []string{"Tx.XXX_unknown: field name XXX_unknown is reserved for the unknown fields"},
+		},
+		{
+			name: "unknown field name without KeepUnknown",
+			rec:  tx(field(UnknownFieldName, 1, int64Rec)),
+		},
+		{
+			name: "invalid scalar",
+			rec:  tx(field("A", 1, ScalarRecord{Name: "complex128"})),
+			want: []string{`Tx.A: invalid scalar record: "complex128"`},
+		},
+		{
+			name: "repeated uint8",
+			rec:  tx(field("A", 1, RepeatedRecord{Elem: ScalarRecord{Name: "uint8"}, Size: -1})),
+			want: []string{"Tx.A: elem of RepeatedRecord cannot be uint8 (should use BytesRecord instead)"},
+		},
+		{
+			name: "sized string",
+			rec:  tx(field("A", 1, BytesRecord{String: true, Size: 4})),
+			want: []string{"Tx.A: string BytesRecord must have size = -1"},
+		},
+		{
+			name: "invalid sizes",
+			rec: tx(
+				field("A", 1, BytesRecord{Size: -2}),
+				field("B", 2, RepeatedRecord{Elem: int64Rec, Size: -2}),
+			),
+			want: []string{"Tx.A: invalid size: -2", "Tx.B: invalid size: -2"},
+		},
+		{
+			name: "nested struct",
+			rec: tx(field("Msgs", 1, RepeatedRecord{Size: -1, Elem: StructRecord{
+				Name: "Msg",
+				Fields: []StructField{
+					field("Amount", 1, StructRecord{
+						Name:   "Coin",
+						Fields: []StructField{field("Denom", 0, stringRec)},
+					}),
+				},
+			}})),
+			want: []string{"Tx.Msgs[].Amount.Denom: invalid field number 0"},
+		},
+		{
+			name: "every violation",
+			rec: tx(
+				field("A", 0, nil),
+				field("B", 1, float64Rec),
+				field("C", 1, int64Rec),
+			),
+			want: []string{
+				"Tx.A: invalid field number 0",
+				"Tx.A: nil record",
+				"Tx.B: floating points must be used with the `amino:\"unsafe\"` struct tag",
+				"Tx.C: field number 1 already used by field B",
+			},
+		},
+		{
+			name: "empty name in AnyRecord",
+			rec:  AnyRecord{Subset: []string{"A", ""}},
+			want: []string{"AnyRecord with empty name in subset"},
+		},
+		{
+			name: "NamedRecord without a name",
+			rec:  NamedRecord{Elem: int64Rec},
+			want: []string{"NamedRecord without a name"},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rec.Validate()
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var ve ValidationErrors
+			if !errors.As(err, &ve) {
+				t.Fatalf("want ValidationErrors, got %T (%v)", err, err)
+			}
+			if len(ve) != len(tc.want) {
+				t.Fatalf("want %d errors, got:\n%v", len(tc.want), err)
+			}
+			for i, e := range ve {
+				if e.Error() != tc.want[i] {
+					t.Errorf("want error %q, got %q", tc.want[i], e.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestStructFieldValidate(t *testing.T) {
+	f := StructField{Name: "F", Record: ScalarRecord{Name: "float32"}, TagFlag: BinFixed64}
+	err := f.Validate()
+	want := "F: tag fixed64 may only be used on int64, uint64 or float64\n" +
+		"F: floating points must be used with the `amino:\"unsafe\"` struct tag"
+	if err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+
+	f.TagFlag = BinFixed32 | Unsafe
+	if err := f.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
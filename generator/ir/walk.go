@@ -0,0 +1,104 @@
+package ir
+
+// Node is a record visited by [Walk], together with its position in the tree
+// of records.
+type Node struct {
+	// Path of the record, in the same format as [ValidationError.Path];
+	// ie. Tx.Msgs[].Amount.Denom
+	// Elements of a RepeatedRecord have the suffix "[]"; the elements of
+	// OptionalRecord and NamedRecord have the same path as their parent.
+	Path string
+	// Record being visited.
+	Record Record
+	// Field is the struct field containing the record, or nil for the root
+	// record. For the elements of RepeatedRecord, OptionalRecord and
+	// NamedRecord, it is the same field as their parent; this way, the
+	// TagFlag of the field can always be retrieved.
+	// The field should not be modified.
+	Field *StructField
+	// Parent is the node containing this one, or nil for the root record.
+	Parent *Node
+}
+
+// Depth returns the number of ancestors of the node.
+func (n *Node) Depth() int {
+	d := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		d++
+	}
+	return d
+}
+
+// Visitor is used with [Walk] to traverse a tree of records.
+type Visitor interface {
+	// Pre is called for each node before its children are visited
+	// (pre-order). If Pre returns false, the children of the node are not
+	// visited, and Post is not called for the node.
+	Pre(n *Node) bool
+	// Post is called for each node after its children have been visited
+	// (post-order).
+	Post(n *Node)
+}
+
+// Walk traverses the tree of records starting at root, in depth-first order,
+// calling the methods of v for each record. The children of the records are:
+//
+//   - StructRecord: the records of each field, in order.
+//   - RepeatedRecord, OptionalRecord, NamedRecord: Elem.
+//
+// The other records have no children. Nil records are not visited.
+// If root is a StructRecord, its name is used as the first element of the
+// paths.
+func Walk(v Visitor, root Record) {
+	if root == nil {
+		return
+	}
+	var path string
+	if sr, ok := root.(StructRecord); ok {
+		path = sr.Name
+	}
+	walk(v, &Node{Path: path, Record: root})
+}
+
+func walk(v Visitor, n *Node) {
+	if !v.Pre(n) {
+		return
+	}
+
+	child := func(path string, rec Record, fld *StructField) {
+		if rec != nil {
+			walk(v, &Node{Path: path, Record: rec, Field: fld, Parent: n})
+		}
+	}
+	switch rec := n.Record.(type) {
+	case StructRecord:
+		for i := range rec.Fields {
+			fld := &rec.Fields[i]
+			path := fld.Name
+			if n.Path != "" {
+				path = n.Path + "." + fld.Name
+			}
+			child(path, fld.Record, fld)
+		}
+	case RepeatedRecord:
+		child(n.Path+"[]", rec.Elem, n.Field)
+	case OptionalRecord:
+		child(n.Path, rec.Elem, n.Field)
+	case NamedRecord:
+		child(n.Path, rec.Elem, n.Field)
+	}
+
+	v.Post(n)
+}
+
+// Inspect traverses the tree of records starting at root, like [Walk],
+// calling f for each node in pre-order. If f returns false, the children of
+// the node are not visited.
+func Inspect(root Record, f func(n *Node) bool) {
+	Walk(inspector(f), root)
+}
+
+type inspector func(n *Node) bool
+
+func (f inspector) Pre(n *Node) bool { return f(n) }
+func (inspector) Post(*Node)         {}
@@ -0,0 +1,185 @@
+package ir
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recorder is a Visitor recording the visited nodes.
+type recorder struct {
+	visits []string
+	// prune, if set, is the path of the nodes whose children are skipped.
+	prune string
+}
+
+func (r *recorder) Pre(n *Node) bool {
+	r.visits = append(r.visits, "pre "+n.Path+" "+n.Record.Kind())
+	return r.prune == "" || n.Path != r.prune
+}
+
+func (r *recorder) Post(n *Node) {
+	r.visits = append(r.visits, "post "+n.Path+" "+n.Record.Kind())
+}
+
+func walkTestRecord() StructRecord {
+	coin := StructRecord{
+		Name: "Coin",
+		Fields: []StructField{
+			{Name: "Denom", BinFieldNum: 1, Record: BytesRecord{String: true, Size: -1}},
+			{Name: "Amount", BinFieldNum: 2, Record: ScalarRecord{Name: "int64"}},
+		},
+	}
+	return StructRecord{
+		Name: "Tx",
+		Fields: []StructField{
+			{Name: "Fee", BinFieldNum: 1, Record: OptionalRecord{Elem: coin}},
+			{Name: "Coins", BinFieldNum: 2, Record: RepeatedRecord{Elem: coin, Size: -1}},
+			{Name: "Memo", BinFieldNum: 3, Record: NamedRecord{Name: "Memo", Elem: ScalarRecord{Name: "bool"}}},
+			{Name: "Nil", BinFieldNum: 4},
+		},
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var r recorder
+	Walk(&r, walkTestRecord())
+	want := []string{
+		"pre Tx struct",
+		"pre Tx.Fee optional",
+		"pre Tx.Fee struct",
+		"pre Tx.Fee.Denom bytes",
+		"post Tx.Fee.Denom bytes",
+		"pre Tx.Fee.Amount scalar",
+		"post Tx.Fee.Amount scalar",
+		"post Tx.Fee struct",
+		"post Tx.Fee optional",
+		"pre Tx.Coins repeated",
+		"pre Tx.Coins[] struct",
+		"pre Tx.Coins[].Denom bytes",
+		"post Tx.Coins[].Denom bytes",
+		"pre Tx.Coins[].Amount scalar",
+		"post Tx.Coins[].Amount scalar",
+		"post Tx.Coins[] struct",
+		"post Tx.Coins repeated",
+		"pre Tx.Memo named",
+		"pre Tx.Memo scalar",
+		"post Tx.Memo scalar",
+		"post Tx.Memo named",
+		// Nil is not visited.
+		"post Tx struct",
+	}
+	if !reflect.DeepEqual(want, r.visits) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(r.visits, "\n"))
+	}
+}
+
+func TestWalkPrune(t *testing.T) {
+	// when Pre returns false, neither the children nor Post are visited.
+	r := recorder{prune: "Tx.Coins"}
+	Walk(&r, StructRecord{
+		Name: "Tx",
+		Fields: []StructField{
+			{Name: "Coins", Record: RepeatedRecord{Elem: ScalarRecord{Name: "int64"}, Size: -1}},
+			{Name: "Memo", Record: BytesRecord{String: true, Size: -1}},
+		},
+	})
+	want := []string{
+		"pre Tx struct",
+		"pre Tx.Coins repeated",
+		"pre Tx.Memo bytes",
+		"post Tx.Memo bytes",
+		"post Tx struct",
+	}
+	if !reflect.DeepEqual(want, r.visits) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(r.visits, "\n"))
+	}
+}
+
+func TestWalkRoot(t *testing.T) {
+	// the paths of records other than StructRecord start empty.
+	var r recorder
+	Walk(&r, RepeatedRecord{Elem: OptionalRecord{Elem: ScalarRecord{Name: "int8"}}, Size: 2})
+	want := []string{
+		"pre  repeated",
+		"pre [] optional",
+		"pre [] scalar",
+		"post [] scalar",
+		"post [] optional",
+		"post  repeated",
+	}
+	if !reflect.DeepEqual(want, r.visits) {
+		t.Errorf("want:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(r.visits, "\n"))
+	}
+
+	r.visits = nil
+	Walk(&r, nil)
+	if len(r.visits) != 0 {
+		t.Errorf("nil root: want no visits, got %v", r.visits)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var paths []string
+	Inspect(walkTestRecord(), func(n *Node) bool {
+		if n.Record.Kind() == "struct" && n.Parent != nil {
+			// prune the Coin structs.
+			return false
+		}
+		paths = append(paths, n.Path)
+		return true
+	})
+	want := []string{"Tx", "Tx.Fee", "Tx.Coins", "Tx.Memo", "Tx.Memo"}
+	if !reflect.DeepEqual(want, paths) {
+		t.Errorf("want %v, got %v", want, paths)
+	}
+}
+
+func TestNode(t *testing.T) {
+	root := walkTestRecord()
+	nodes := make(map[string]*Node)
+	Inspect(root, func(n *Node) bool {
+		key := n.Path + " " + n.Record.Kind()
+		nodes[key] = n
+		return true
+	})
+
+	tt := []struct {
+		key    string
+		depth  int
+		field  string
+		parent string
+	}{
+		{"Tx struct", 0, "", ""},
+		{"Tx.Fee optional", 1, "Fee", "Tx struct"},
+		{"Tx.Fee struct", 2, "Fee", "Tx.Fee optional"},
+		{"Tx.Fee.Amount scalar", 3, "Amount", "Tx.Fee struct"},
+		{"Tx.Coins[] struct", 2, "Coins", "Tx.Coins repeated"},
+		{"Tx.Coins[].Denom bytes", 3, "Denom", "Tx.Coins[] struct"},
+		{"Tx.Memo scalar", 2, "Memo", "Tx.Memo named"},
+	}
+	for _, tc := range tt {
+		n := nodes[tc.key]
+		if n == nil {
+			t.Errorf("%s: not visited", tc.key)
+			continue
+		}
+		if d := n.Depth(); d != tc.depth {
+			t.Errorf("%s: want depth %d, got %d", tc.key, tc.depth, d)
+		}
+		var field string
+		if n.Field != nil {
+			field = n.Field.Name
+		}
+		if field != tc.field {
+			t.Errorf("%s: want field %q, got %q", tc.key, tc.field, field)
+		}
+		var parent string
+		if n.Parent != nil {
+			parent = n.Parent.Path + " " + n.Parent.Record.Kind()
+		}
+		if parent != tc.parent {
+			t.Errorf("%s: want parent %q, got %q", tc.key, tc.parent, parent)
+		}
+	}
+}
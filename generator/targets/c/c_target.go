@@ -0,0 +1,385 @@
+// Package ctarget generates C structs for the messages, together with their
+// encoders and decoders, as a header and a source file.
+//
+// The generated code never allocates: strings, bytes and slices are stored
+// inline in the structs, up to a maximum length set by a macro for each
+// field, which can be overridden when compiling.
+package ctarget
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/targets/internal/ident"
+	"github.com/thehowl/tomino/generator/targets/internal/indent"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+var tpl = template.Must(template.New("template.tmpl").
+	Funcs(template.FuncMap{
+		"throw": func(s string, args ...any) (string, error) {
+			return "", fmt.Errorf(s, args...)
+		},
+		"field": func(m *wire.Message, f *wire.Field) valueCtx {
+			return valueCtx{Field: f, Msg: m, Expr: "msg->" + fieldName(f), Omit: f.OmitEmpty}
+		},
+		"tagString":   tagString,
+		"fieldName":   fieldName,
+		"messageType": messageType,
+		"members":     members,
+		"maxMacros":   maxMacros,
+		"guard":       guard,
+	}).
+	Parse(templateSource))
+
+// valueCtx is the parameter of the templates encoding and decoding values.
+type valueCtx struct {
+	*wire.Field
+	// Msg is the message containing the field.
+	Msg *wire.Message
+	// Expr is the C lvalue of the value.
+	Expr string
+	// Omit is set if empty values should be omitted.
+	Omit bool
+	// Elem is set for the elements of repeated fields, which are never
+	// omitted.
+	Elem bool
+}
+
+// Element returns the context for an element of the repeated field, with the
+// given expression.
+func (v valueCtx) Element(expr string) valueCtx {
+	return valueCtx{Field: v.Field, Msg: v.Msg, Expr: expr, Elem: true}
+}
+
+// Member returns the expression of the member holding the field, before
+// indexing into its elements; it is empty if the field has no member.
+func (v valueCtx) Member() string {
+	if !hasMember(v.Field) {
+		return ""
+	}
+	return v.Expr
+}
+
+// Max returns the macro with the maximum number of elements of a slice.
+func (v valueCtx) Max() string {
+	return maxMacro(v.Msg, v.Field, "")
+}
+
+// ElemMax returns the macro with the maximum length of a variable-length
+// bytes value.
+func (v valueCtx) ElemMax() string {
+	if v.Repeated != nil {
+		return maxMacro(v.Msg, v.Field, "_ELEM")
+	}
+	return maxMacro(v.Msg, v.Field, "")
+}
+
+// NonEmpty returns the condition for v.Expr to be non-empty.
+func (v valueCtx) NonEmpty() string {
+	switch v.Value.Kind {
+	case wire.KindBytes:
+		return v.Expr + ".len != 0"
+	}
+	return v.Expr + " != 0"
+}
+
+// Write returns the statement writing the value of v, without its tag.
+func (v valueCtx) Write() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "tomino_put_uvarint(w, (" + scalarType(v.Value) + ")" + v.Expr + ");", nil
+	case wire.KindVarint:
+		return "tomino_put_uvarint(w, tomino_zigzag(" + v.Expr + "));", nil
+	case wire.KindBool:
+		return "tomino_put_uvarint(w, " + v.Expr + " ? 1 : 0);", nil
+	case wire.KindFixed64:
+		return "tomino_put_fixed64(w, (uint64_t)" + v.Expr + ");", nil
+	case wire.KindFixed32:
+		return "tomino_put_fixed32(w, (uint32_t)" + v.Expr + ");", nil
+	case wire.KindFloat64:
+		return "tomino_put_fixed64(w, tomino_double_bits(" + v.Expr + "));", nil
+	case wire.KindFloat32:
+		return "tomino_put_fixed32(w, tomino_float_bits(" + v.Expr + "));", nil
+	case wire.KindBytes:
+		switch {
+		case v.Value.Size == 0:
+			return "tomino_put_bytes(w, NULL, 0, 0);", nil
+		case v.Value.Size > 0:
+			size := strconv.FormatInt(v.Value.Size, 10)
+			return "tomino_put_bytes(w, " + v.Expr + ", " + size + ", " + size + ");", nil
+		}
+		return "tomino_put_bytes(w, (const uint8_t *)" + v.Expr + ".data, " + v.Expr + ".len, " + v.ElemMax() + ");", nil
+	}
+	return "", fmt.Errorf("no writer for kind %s", v.Value.Kind)
+}
+
+// Read returns the statement reading a value of v into v.Expr.
+func (v valueCtx) Read() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		t := scalarType(v.Value)
+		return "{\nuint64_t u;\nTOMINO_TRY(tomino_read_uvarint(r, &u, " + maxValue(t) + "));\n" +
+			v.Expr + " = (" + t + ")u;\n}", nil
+	case wire.KindVarint:
+		t := scalarType(v.Value)
+		return "{\nint64_t s;\nTOMINO_TRY(tomino_read_varint(r, &s, " + minValue(t) + ", " + maxValue(t) + "));\n" +
+			v.Expr + " = (" + t + ")s;\n}", nil
+	case wire.KindBool:
+		return "TOMINO_TRY(tomino_read_bool(r, &" + v.Expr + "));", nil
+	case wire.KindFixed64:
+		return "{\nuint64_t u;\nTOMINO_TRY(tomino_read_fixed64(r, &u));\n" +
+			v.Expr + " = (" + scalarType(v.Value) + ")u;\n}", nil
+	case wire.KindFixed32:
+		return "{\nuint32_t u;\nTOMINO_TRY(tomino_read_fixed32(r, &u));\n" +
+			v.Expr + " = (" + scalarType(v.Value) + ")u;\n}", nil
+	case wire.KindFloat64:
+		return "TOMINO_TRY(tomino_read_double(r, &" + v.Expr + "));", nil
+	case wire.KindFloat32:
+		return "TOMINO_TRY(tomino_read_float(r, &" + v.Expr + "));", nil
+	case wire.KindBytes:
+		switch {
+		case v.Value.Size == 0:
+			return "TOMINO_TRY(tomino_read_byte_array(r, NULL, 0));", nil
+		case v.Value.Size > 0:
+			return "TOMINO_TRY(tomino_read_byte_array(r, " + v.Expr + ", " + strconv.FormatInt(v.Value.Size, 10) + "));", nil
+		case v.Value.String:
+			return "TOMINO_TRY(tomino_read_string(r, " + v.Expr + ".data, &" + v.Expr + ".len, " + v.ElemMax() + "));", nil
+		}
+		return "TOMINO_TRY(tomino_read_bytes(r, " + v.Expr + ".data, &" + v.Expr + ".len, " + v.ElemMax() + "));", nil
+	case wire.KindMessage:
+		return "{\nsize_t end;\nTOMINO_TRY(tomino_read_len(r, &end));\n" +
+			"TOMINO_TRY(tomino_read_" + v.Value.Message.Name + "(r, end, &" + v.Expr + "));\n}", nil
+	}
+	return "", fmt.Errorf("no reader for kind %s", v.Value.Kind)
+}
+
+// Options are the options for the C target.
+type Options struct {
+	// Header is the name of the header file, included by the source file.
+	// If empty, the source file doesn't include it, and is meant to be
+	// appended to the header.
+	Header string
+}
+
+// data is the parameter of the main templates.
+type data struct {
+	Options
+	Plan *wire.Plan
+}
+
+// WriteHeader generates the header declaring the C structs, encoders and
+// decoders for the given messages, and writes it to w. The capacity of the
+// strings, bytes and slices in the structs is set by the TOMINO_MAX_* macros
+// it defines, which can be overridden before including it.
+func WriteHeader(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	return write(w, "header", messages, opts)
+}
+
+// Write generates the source file defining the C encoders and decoders for
+// the given messages, and writes it to w. It includes opts.Header, which must
+// be generated by [WriteHeader] from the same messages.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	return write(w, "source", messages, opts)
+}
+
+func write(w io.Writer, name string, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+	if len(plan.Interfaces) > 0 {
+		return fmt.Errorf("interfaces are not supported by the C target: %s", plan.Interfaces[0].Source)
+	}
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, name, data{opts, plan}); err != nil {
+		return err
+	}
+	_, err = w.Write(indent.Braces(buf.Bytes(), "\t"))
+	return err
+}
+
+// tagString returns b as a C string literal, followed by its length, as
+// arguments to a function call.
+func tagString(b []byte) string {
+	var bld strings.Builder
+	bld.WriteByte('"')
+	for _, c := range b {
+		fmt.Fprintf(&bld, `\x%02x`, c)
+	}
+	bld.WriteString(`", `)
+	bld.WriteString(strconv.Itoa(len(b)))
+	return bld.String()
+}
+
+var reIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var keywords = map[string]bool{
+	"auto": true, "bool": true, "break": true, "case": true, "char": true,
+	"const": true, "continue": true, "default": true, "do": true,
+	"double": true, "else": true, "enum": true, "extern": true, "false": true,
+	"float": true, "for": true, "goto": true, "if": true, "inline": true,
+	"int": true, "long": true, "register": true, "restrict": true,
+	"return": true, "short": true, "signed": true, "sizeof": true,
+	"static": true, "struct": true, "switch": true, "true": true,
+	"typedef": true, "union": true, "unsigned": true, "void": true,
+	"volatile": true, "while": true,
+}
+
+// fieldName returns the name of the field's member, converting it to
+// snake_case.
+func fieldName(f *wire.Field) string {
+	name := ident.Snake(f.Name)
+	switch {
+	case keywords[name]:
+		return name + "_"
+	case !reIdent.MatchString(name):
+		return "field_" + strconv.Itoa(int(f.BinFieldNum))
+	}
+	return name
+}
+
+// maxMacro returns the name of the macro with a maximum length of the field.
+func maxMacro(m *wire.Message, f *wire.Field, suffix string) string {
+	return "TOMINO_MAX_" + strings.ToUpper(ident.Snake(m.Name)+"_"+fieldName(f)) + suffix
+}
+
+// maxMacros returns the macros with the maximum lengths of the fields of m.
+func maxMacros(m *wire.Message) []string {
+	var macros []string
+	for _, f := range m.Fields {
+		if !hasMember(f) {
+			continue
+		}
+		if f.Repeated != nil && f.Repeated.Size < 0 {
+			macros = append(macros, maxMacro(m, f, ""))
+		}
+		if f.Value.Kind == wire.KindBytes && f.Value.Size < 0 {
+			if f.Repeated != nil {
+				macros = append(macros, maxMacro(m, f, "_ELEM"))
+			} else {
+				macros = append(macros, maxMacro(m, f, ""))
+			}
+		}
+	}
+	return macros
+}
+
+// hasMember returns whether the field has a member in the struct: zero-length
+// arrays are not allowed in C.
+func hasMember(f *wire.Field) bool {
+	if f.Repeated != nil {
+		return f.Repeated.Size != 0
+	}
+	return f.Value.Kind != wire.KindBytes || f.Value.Size != 0
+}
+
+// messageType returns the C type of a message.
+func messageType(m *wire.Message) string {
+	if m.WellKnown != wire.WellKnownNone {
+		return "tomino_timestamp"
+	}
+	return m.Name
+}
+
+// scalarType returns the C type of a scalar value.
+func scalarType(v wire.Value) string {
+	switch v.Scalar {
+	case "bool":
+		return "bool"
+	case "float64":
+		return "double"
+	case "float32":
+		return "float"
+	case "int":
+		return "int64_t"
+	case "uint", "uintptr":
+		return "uint64_t"
+	case "byte":
+		return "uint8_t"
+	}
+	// intN and uintN.
+	return v.Scalar + "_t"
+}
+
+// maxValue returns the macro with the maximum value of an integer type.
+func maxValue(t string) string {
+	return strings.ToUpper(strings.TrimSuffix(t, "_t")) + "_MAX"
+}
+
+// minValue returns the macro with the minimum value of a signed integer type.
+func minValue(t string) string {
+	return strings.ToUpper(strings.TrimSuffix(t, "_t")) + "_MIN"
+}
+
+// valueDecl returns the declaration of name holding a value of v, where max
+// is the maximum length of variable-length bytes.
+func valueDecl(v wire.Value, name, max string) string {
+	switch v.Kind {
+	case wire.KindBytes:
+		switch {
+		case v.Size >= 0:
+			return "uint8_t " + name + "[" + strconv.FormatInt(v.Size, 10) + "]"
+		case v.String:
+			// with space for a NUL terminator.
+			return "struct {\nsize_t len;\nchar data[" + max + " + 1];\n} " + name
+		}
+		return "struct {\nsize_t len;\nuint8_t data[" + max + "];\n} " + name
+	case wire.KindMessage:
+		return messageType(v.Message) + " " + name
+	}
+	return scalarType(v) + " " + name
+}
+
+// members returns the declarations of the members holding the field f of m.
+func members(m *wire.Message, f *wire.Field) (string, error) {
+	name := fieldName(f)
+	if !hasMember(f) {
+		return "/* " + name + ": zero-length array, not stored. */", nil
+	}
+	var decls []string
+	if f.Optional {
+		decls = append(decls, "bool has_"+name+";")
+	}
+	switch {
+	case f.Repeated == nil:
+		decls = append(decls, valueDecl(f.Value, name, maxMacro(m, f, ""))+";")
+	case f.Value.Kind == wire.KindBytes && f.Value.Size == 0:
+		return "", fmt.Errorf("field %s: repeated zero-length byte arrays are not supported by the C target", f.Name)
+	case f.Repeated.Size > 0:
+		size := "[" + strconv.FormatInt(f.Repeated.Size, 10) + "]"
+		decls = append(decls, valueDecl(f.Value, name+size, maxMacro(m, f, "_ELEM"))+";")
+		if f.Repeated.ElemOptional {
+			decls = append(decls, "bool "+name+"_present"+size+";")
+		}
+	default:
+		max := "[" + maxMacro(m, f, "") + "]"
+		s := "struct {\nsize_t len;\n" + valueDecl(f.Value, "data"+max, maxMacro(m, f, "_ELEM")) + ";\n"
+		if f.Repeated.ElemOptional {
+			s += "bool present" + max + ";\n"
+		}
+		decls = append(decls, s+"} "+name+";")
+	}
+	return strings.Join(decls, "\n"), nil
+}
+
+var reNonIdent = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// guard returns the include guard for the header.
+func guard(header string) string {
+	if header == "" {
+		return "TOMINO_GEN_H"
+	}
+	return strings.ToUpper(reNonIdent.ReplaceAllString(header, "_"))
+}
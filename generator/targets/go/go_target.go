@@ -1,14 +1,18 @@
 package gotarget
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"go/format"
 	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/wire"
 
 	_ "embed"
 )
@@ -31,6 +35,15 @@ var tpl = template.Must(template.New("template.tmpl").
 			l := binary.PutUvarint(buf[:], n)
 			return buf[:l]
 		},
+		"message": func(m *wire.Message, expr string) MessageCtx {
+			return MessageCtx{Message: m, Expr: expr}
+		},
+		// overridden by Write.
+		"purego":   func() bool { return false },
+		"aminotag": aminoTag,
+		// execute executes the template with the given name, if it is
+		// defined; overridden by Write.
+		"execute": func(name string, data any) (string, error) { return "", nil },
 		"gotag": func(b []uint8) string {
 			if len(b) == 1 {
 				// one-byte tag: since go evaluates constant expressions at compile time,
@@ -55,6 +68,255 @@ var tpl = template.Must(template.New("template.tmpl").
 	}).
 	Parse(templateSource))
 
-func Write(w io.Writer, messages []ir.StructRecord) error {
-	return tpl.ExecuteTemplate(w, "main", messages)
+// aminoTag returns the value of the `amino` struct tag for the field, or an
+// empty string if it has none, so that the generated types can also be used
+// with amino.
+func aminoTag(f ir.StructField) string {
+	var opts []string
+	for _, s := range [...]string{"unsafe", "write_empty", "nil_elements"} {
+		if f.Has(s) {
+			opts = append(opts, s)
+		}
+	}
+	return strings.Join(opts, ",")
+}
+
+// MessageCtx is the parameter of the templates encoding and decoding
+// messages.
+type MessageCtx struct {
+	*wire.Message
+	// Expr is the Go expression of the message's value.
+	Expr string
+}
+
+// Member returns the expression of a field of the message.
+func (m MessageCtx) Member(name string) string {
+	// selectors dereference pointers automatically.
+	return strings.TrimPrefix(m.Expr, "*") + "." + name
+}
+
+// Field returns the EncoderCtx of one of the message's fields.
+func (m MessageCtx) Field(f *wire.Field) EncoderCtx {
+	return EncoderCtx{Field: f, Expr: m.Member(f.Name)}
+}
+
+// EncoderCtx is the parameter of the templates encoding and decoding fields
+// and values.
+type EncoderCtx struct {
+	*wire.Field
+	// Expr is the Go expression of the value being encoded.
+	Expr string
+	// Elem is set when encoding an element of a repeated field.
+	Elem bool
+}
+
+// Omit returns whether empty values should be omitted.
+// Elements of repeated fields are never omitted.
+func (e EncoderCtx) Omit() bool {
+	return e.OmitEmpty && !e.Elem
+}
+
+// Deref returns the context for the value pointed to by e.Expr.
+func (e EncoderCtx) Deref() EncoderCtx {
+	e.Expr = "*" + e.Expr
+	return e
+}
+
+// Array returns the expression of the value as an addressable operand,
+// suitable for slicing or ranging over it.
+func (e EncoderCtx) Array() string {
+	if strings.HasPrefix(e.Expr, "*") {
+		return "(" + e.Expr + ")"
+	}
+	return e.Expr
+}
+
+// Addr returns the expression of a pointer to the value.
+func (e EncoderCtx) Addr() string {
+	if strings.HasPrefix(e.Expr, "*") {
+		return e.Expr[1:]
+	}
+	return "&" + e.Expr
+}
+
+// Streamed returns whether ReadMessageFrom decodes the values of the field as
+// they are read: they are messages, with fields or unknown fields to keep.
+func (e EncoderCtx) Streamed() bool {
+	m := e.Value.Message
+	return m != nil && (len(m.Fields) > 0 || m.Record.KeepUnknown) &&
+		(e.Repeated == nil || e.Repeated.Size != 0)
+}
+
+// ElemRecord returns the record of the elements of the repeated field.
+func (e EncoderCtx) ElemRecord() ir.Record {
+	rec := e.Record
+	if or, ok := rec.(ir.OptionalRecord); ok {
+		rec = or.Elem
+	}
+	return rec.(ir.RepeatedRecord).Elem
+}
+
+// Element returns the context for an element of the repeated field,
+// with the given expression.
+func (e EncoderCtx) Element(expr string) EncoderCtx {
+	return EncoderCtx{Field: e.Field, Expr: expr, Elem: true}
+}
+
+// Feature is an optional part of the generated code, like the JSON encoders,
+// which is generated by another package; see the subpackages of this one.
+type Feature struct {
+	// Name of the feature. The template named Name+".message" is executed
+	// after the methods of each message, with the *wire.Message as its
+	// parameter, and the one named Name+".file" at the end of the file, with
+	// the *File; both are optional.
+	Name string
+	// Template is the source of the templates of the feature, which can use
+	// the templates and functions of the Go target, as well as Funcs.
+	Template string
+	// Funcs are the additional functions used by Template.
+	Funcs template.FuncMap
+	// Imports returns the packages imported by the code of the feature.
+	Imports func(f *File) []string
+}
+
+// File is the parameter of the template generating the whole file.
+type File struct {
+	Options
+	Plan    *wire.Plan
+	Imports []string
+	Strings bool
+	Unknown bool
+	Any     bool
+}
+
+// Enabled returns whether the feature with the given name is enabled.
+func (f *File) Enabled(name string) bool {
+	return slices.ContainsFunc(f.Features, func(ft Feature) bool { return ft.Name == name })
+}
+
+// Options are the options for the Go target.
+type Options struct {
+	// Package is the name of the package of the generated file.
+	// Defaults to "tomtypes".
+	Package string
+	// PureGo avoids importing unsafe, converting floating points using
+	// package math instead.
+	PureGo bool
+	// Gno generates code for Gno realms and packages. It implies PureGo, as
+	// Gno doesn't support unsafe.
+	Gno bool
+	// Features are the optional parts of the generated code, in the order
+	// they are written.
+	Features []Feature
+}
+
+// Write generates the Go encoders and decoders for the given messages, and
+// writes them to w. Each message is declared as a struct with the Message
+// suffix (URLMessage for URL), and the methods to encode and decode it
+// without reflection, together with those of opts.Features.
+// The output is formatted using go/format; if the generated code does not
+// parse, Write returns an error and does not write anything to w.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+
+	if opts.Package == "" {
+		opts.Package = "tomtypes"
+	}
+	if opts.Gno {
+		opts.PureGo = true
+	}
+	file := &File{Options: opts, Plan: plan, Strings: hasStrings(plan), Unknown: hasUnknown(plan), Any: hasAny(plan)}
+	file.Imports = imports(file)
+
+	t := template.Must(tpl.Clone())
+	t.Funcs(template.FuncMap{
+		"purego": func() bool { return opts.PureGo },
+		"execute": func(name string, data any) (string, error) {
+			if t.Lookup(name) == nil {
+				return "", nil
+			}
+			var buf strings.Builder
+			err := t.ExecuteTemplate(&buf, name, data)
+			return buf.String(), err
+		},
+	})
+	for _, f := range opts.Features {
+		if _, err := t.Funcs(f.Funcs).New(f.Name).Parse(f.Template); err != nil {
+			return fmt.Errorf("feature %s: %w", f.Name, err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := t.ExecuteTemplate(&buf, "main", file); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("generated code is not valid Go (this is a bug in tomgen): %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// hasUnknown returns whether any of the messages keeps its unknown fields.
+func hasUnknown(plan *wire.Plan) bool {
+	return slices.ContainsFunc(plan.Messages, func(m *wire.Message) bool {
+		return m.Record.KeepUnknown
+	})
+}
+
+// hasAny returns whether the file encodes google.protobuf.Any, for the
+// interfaces or for the Any methods of the messages with a type URL.
+func hasAny(plan *wire.Plan) bool {
+	return len(plan.Interfaces) > 0 || slices.ContainsFunc(plan.Messages, func(m *wire.Message) bool {
+		return m.TypeURL != ""
+	})
+}
+
+// HasKind returns whether any of the fields of the messages has a value
+// for which fn returns true.
+func HasKind(plan *wire.Plan, fn func(v wire.Value) bool) bool {
+	for _, m := range plan.All {
+		for _, f := range m.Fields {
+			if fn(f.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasStrings returns whether any of the messages contains strings.
+func hasStrings(plan *wire.Plan) bool {
+	return HasKind(plan, func(v wire.Value) bool {
+		return v.Kind == wire.KindBytes && v.String
+	})
+}
+
+// imports returns the packages imported by the generated code.
+func imports(f *File) []string {
+	imports := []string{"errors", "io"}
+	if f.Any {
+		imports = append(imports, "fmt")
+	}
+	for _, ft := range f.Features {
+		if ft.Imports != nil {
+			imports = append(imports, ft.Imports(f)...)
+		}
+	}
+	floats := HasKind(f.Plan, func(v wire.Value) bool {
+		return v.Kind == wire.KindFloat64 || v.Kind == wire.KindFloat32
+	})
+	switch {
+	case floats && f.PureGo:
+		imports = append(imports, "math")
+	case f.PureGo:
+	case floats || hasStrings(f.Plan):
+		// strings are aliased by UnmarshalBinaryNoCopy.
+		imports = append(imports, "unsafe")
+	}
+	slices.Sort(imports)
+	return slices.Compact(imports)
 }
@@ -0,0 +1,410 @@
+// Package gojson is the feature of the Go target generating the JSON
+// encoders and decoders of the messages: MarshalJSON, AppendJSON, SignBytes,
+// AppendCanonicalJSON and UnmarshalJSON. They follow amino's JSON encoding.
+package gojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	gotarget "github.com/thehowl/tomino/generator/targets/go"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+// Feature generates the JSON methods of the messages which don't have
+// SkipJSON set.
+var Feature = gotarget.Feature{
+	Name:     "json",
+	Template: templateSource,
+	Funcs: template.FuncMap{
+		"jsonmessage": func(m *wire.Message, expr string) messageCtx {
+			return messageCtx{MessageCtx: gotarget.MessageCtx{Message: m, Expr: expr}}
+		},
+		"hasjson":     HasJSON,
+		"jsonkey":     jsonKey,
+		"jsontypeurl": jsonTypeURL,
+		// jsonimplementations returns the implementations of an interface
+		// which have JSON methods.
+		"jsonimplementations": func(it *wire.Interface) []wire.Implementation {
+			return slices.DeleteFunc(slices.Clone(it.Implementations), func(impl wire.Implementation) bool {
+				return impl.Record.SkipJSON
+			})
+		},
+		"jsonanymessages": AnyMessages,
+		// jsonquoted returns whether amino encodes integers of the given type
+		// as JSON strings: it does for 64-bit integers, which can't be
+		// represented exactly by JavaScript numbers.
+		"jsonquoted": func(scalar string) bool {
+			switch scalar {
+			case "int64", "uint64", "int", "uint":
+				return true
+			}
+			return false
+		},
+		"jsonfallible": jsonFallible,
+		"jsonsorted":   jsonSorted,
+		// bitsize returns the bit size of an integer or float type, as used
+		// by strconv. It is 0 for int and uint.
+		"bitsize": func(scalar string) int {
+			n, _ := strconv.Atoi(strings.TrimLeft(scalar, "uintfloa"))
+			return n
+		},
+		"unsigned": func(scalar string) bool { return strings.HasPrefix(scalar, "u") },
+	},
+	Imports: func(f *gotarget.File) []string {
+		if !HasJSON(f.Plan) {
+			return nil
+		}
+		return []string{"encoding/base64", "strconv", "time", "unicode/utf8"}
+	},
+}
+
+// HasJSON returns whether JSON encoders are generated for any of the messages.
+func HasJSON(plan *wire.Plan) bool {
+	return slices.ContainsFunc(plan.Messages, func(m *wire.Message) bool {
+		return !m.Record.SkipJSON
+	})
+}
+
+// jsonKey returns the Go string literal of the JSON key of a field, preceded
+// by a comma if comma is ",".
+func jsonKey(comma, name string) (string, error) {
+	key, err := json.Marshal(name)
+	if err != nil {
+		return "", err
+	}
+	return goString(comma, string(key)+":"), nil
+}
+
+// jsonTypeURL returns the Go string literal of the "@type" member of the JSON
+// object of an interface value, holding url, preceded by a comma if comma is
+// ",".
+func jsonTypeURL(comma, url string) (string, error) {
+	v, err := json.Marshal(url)
+	if err != nil {
+		return "", err
+	}
+	return goString(comma, `"@type":`+string(v)), nil
+}
+
+// goString returns the Go string literal of s, preceded by a comma if comma is
+// ",".
+func goString(comma, s string) string {
+	if comma == "," {
+		s = "," + s
+	}
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// AnyMessages returns the messages which are encoded in JSON with a "@type"
+// member, as the values of interfaces or by MarshalJSONAny: the messages with
+// JSON encoders and a type URL.
+func AnyMessages(plan *wire.Plan) []*wire.Message {
+	var msgs []*wire.Message
+	for _, m := range plan.Messages {
+		if m.TypeURL != "" && !m.Record.SkipJSON {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// jsonFallible returns whether encoding the message in JSON can fail, as it
+// contains floating points (which can be NaN or infinite), times (which can
+// be out of range) or interfaces (which can hold values of other types).
+func jsonFallible(m *wire.Message) bool {
+	if m.WellKnown == wire.WellKnownTime {
+		return true
+	}
+	for _, f := range m.Fields {
+		switch f.Value.Kind {
+		case wire.KindFloat64, wire.KindFloat32, wire.KindAny:
+			return true
+		case wire.KindMessage:
+			if jsonFallible(f.Value.Message) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonSorted returns whether the fields of the message, and of the messages
+// it contains, are already sorted by their JSON name, so that the JSON
+// encoding is also the canonical one. The values of interfaces are encoded by
+// other functions, which are passed whether to sort them.
+func jsonSorted(m *wire.Message) bool {
+	if m.WellKnown != wire.WellKnownNone {
+		// encoded as strings.
+		return true
+	}
+	if !slices.IsSortedFunc(m.Fields, compareJSONNames) {
+		return false
+	}
+	for _, f := range m.Fields {
+		switch {
+		case f.Value.Kind == wire.KindAny:
+			return false
+		case f.Value.Kind == wire.KindMessage && !jsonSorted(f.Value.Message):
+			return false
+		}
+	}
+	return true
+}
+
+func compareJSONNames(a, b *wire.Field) int {
+	return strings.Compare(a.JSONName, b.JSONName)
+}
+
+// zeroTimeSeconds are the seconds since the Unix epoch of the zero time.Time.
+const zeroTimeSeconds = -62135596800
+
+// jsonNonEmpty returns a Go expression which is true if the value of expr is
+// not empty for amino's JSON omitempty, or "false" if it is always empty.
+// Nil pointers, zero-length slices, arrays and strings (also when pointed to),
+// and values equal to the zero value of their type are empty.
+func jsonNonEmpty(expr string, rec ir.Record) string {
+	switch rec := rec.(type) {
+	case ir.OptionalRecord:
+		var size int64
+		switch elem := rec.Elem.(type) {
+		case ir.BytesRecord:
+			size = elem.Size
+		case ir.RepeatedRecord:
+			size = elem.Size
+		default:
+			return expr + " != nil"
+		}
+		switch size {
+		case -1:
+			return fmt.Sprintf("%[1]s != nil && len(*%[1]s) != 0", expr)
+		case 0:
+			return "false"
+		}
+		return expr + " != nil"
+	case ir.BytesRecord:
+		if rec.Size == -1 {
+			return "len(" + expr + ") != 0"
+		}
+	case ir.RepeatedRecord:
+		if rec.Size == -1 {
+			return "len(" + expr + ") != 0"
+		}
+	}
+	return nonZero(expr, rec)
+}
+
+// nonZero returns a Go expression which is true if the value of expr is not
+// deeply equal to the zero value of its type, or "false" if it always is.
+func nonZero(expr string, rec ir.Record) string {
+	switch rec := rec.(type) {
+	case ir.ScalarRecord:
+		if rec.Name == "bool" {
+			return expr
+		}
+		return expr + " != 0"
+	case ir.OptionalRecord, ir.AnyRecord:
+		return expr + " != nil"
+	case ir.BytesRecord:
+		switch {
+		case rec.String:
+			return expr + ` != ""`
+		case rec.Size == -1:
+			return expr + " != nil"
+		case rec.Size == 0:
+			return "false"
+		}
+		return fmt.Sprintf("%s != [%d]byte{}", expr, rec.Size)
+	case ir.RepeatedRecord:
+		switch rec.Size {
+		case -1:
+			return expr + " != nil"
+		case 0:
+			return "false"
+		}
+		if sr, ok := rec.Elem.(ir.ScalarRecord); ok {
+			return fmt.Sprintf("%s != [%d]%s{}", expr, rec.Size, sr.Name)
+		}
+		i := indexVar(expr)
+		el := nonZero(expr+"["+i+"]", rec.Elem)
+		if el == "false" {
+			return el
+		}
+		return fmt.Sprintf("func() bool { for %[1]s := range %[2]s { if %[3]s { return true } }; return false }()", i, expr, el)
+	case ir.StructRecord:
+		if rec.Source == string(wire.WellKnownTime) {
+			return fmt.Sprintf("int64(%[1]s.Seconds) != %[2]d || %[1]s.Nanoseconds != 0", expr, zeroTimeSeconds)
+		}
+		var parts []string
+		for _, f := range rec.Fields {
+			if p := nonZero(expr+"."+f.Name, f.Record); p != "false" {
+				parts = append(parts, p)
+			}
+		}
+		if len(parts) == 0 {
+			return "false"
+		}
+		return strings.Join(parts, " || ")
+	}
+	panic(fmt.Sprintf("nonZero: unsupported record %T", rec))
+}
+
+// indexVar returns the name of the index variable for ranging over expr,
+// which is different for each level of nesting.
+func indexVar(expr string) string {
+	if d := strings.Count(expr, "["); d > 0 {
+		return "i" + strconv.Itoa(d)
+	}
+	return "i"
+}
+
+// messageCtx is the parameter of the templates encoding and decoding
+// messages in JSON.
+type messageCtx struct {
+	gotarget.MessageCtx
+	// Sorted is set when encoding canonical JSON, where the fields are
+	// sorted by their JSON name.
+	Sorted bool
+	// TypeURL is set when encoding the message as the value of an interface,
+	// whose object has a "@type" member.
+	TypeURL string
+}
+
+// Sort returns the context for encoding the message as canonical JSON.
+func (m messageCtx) Sort() messageCtx {
+	m.Sorted = true
+	return m
+}
+
+// Any returns the context for encoding the message as the value of an
+// interface.
+func (m messageCtx) Any() messageCtx {
+	m.TypeURL = m.Message.TypeURL
+	return m
+}
+
+// Field returns the encoderCtx of one of the message's fields.
+func (m messageCtx) Field(f *wire.Field) encoderCtx {
+	return encoderCtx{EncoderCtx: m.MessageCtx.Field(f), Sorted: m.Sorted}
+}
+
+// JSONFields returns the encoderCtx of each of the message's fields, with
+// Comma set for encoding them in a JSON object. If TypeURL is set, they
+// include the "@type" member, which comes first, or in its sorted position
+// when encoding canonical JSON.
+func (m messageCtx) JSONFields() []encoderCtx {
+	const (
+		never = iota
+		maybe
+		always
+	)
+	wfields := m.Fields
+	if m.Sorted {
+		wfields = slices.Clone(wfields)
+		slices.SortStableFunc(wfields, compareJSONNames)
+	}
+	fields := make([]encoderCtx, 0, len(wfields)+1)
+	for _, f := range wfields {
+		fields = append(fields, m.Field(f))
+	}
+	if m.TypeURL != "" {
+		pos := 0
+		if m.Sorted {
+			pos, _ = slices.BinarySearchFunc(fields, "@type", func(e encoderCtx, name string) int {
+				return strings.Compare(e.JSONName, name)
+			})
+		}
+		fields = slices.Insert(fields, pos, encoderCtx{TypeURL: m.TypeURL})
+	}
+	// whether a field was written before the current one.
+	written := never
+	for i := range fields {
+		e := &fields[i]
+		switch written {
+		case maybe:
+			e.Comma = "?"
+		case always:
+			e.Comma = ","
+		}
+		ne := ""
+		if e.TypeURL == "" {
+			ne = e.JSONNonEmpty()
+		}
+		switch ne {
+		case "":
+			written = always
+		case "false":
+		default:
+			written = max(written, maybe)
+		}
+	}
+	return fields
+}
+
+// encoderCtx is the parameter of the templates encoding and decoding fields
+// and values in JSON.
+type encoderCtx struct {
+	gotarget.EncoderCtx
+	// Comma is set when encoding a field in a JSON object: it is "," if it
+	// is always preceded by another field, and "?" if it depends on whether
+	// the preceding fields are omitted.
+	Comma string
+	// Sorted is set when encoding canonical JSON. See [messageCtx.Sorted].
+	Sorted bool
+	// TypeURL is only set for the "@type" member of the JSON object of an
+	// interface value, which has no Field. See [messageCtx.TypeURL].
+	TypeURL string
+}
+
+// JSONNonEmpty returns the expression for checking that the field is not empty
+// if it has the omitempty JSON option, or an empty string otherwise.
+// See [jsonNonEmpty].
+func (e encoderCtx) JSONNonEmpty() string {
+	if !e.Has("json_omit_empty") {
+		return ""
+	}
+	return jsonNonEmpty(e.Expr, e.Record)
+}
+
+// NonNil returns whether the value is known not to be nil when encoding it in
+// JSON, as it would have been omitted.
+func (e encoderCtx) NonNil() bool {
+	return e.Has("json_omit_empty") && !e.Elem
+}
+
+// Index returns the name of the index variable for ranging over the repeated
+// field.
+func (e encoderCtx) Index() string {
+	return indexVar(e.Expr)
+}
+
+// Deref returns the context for the value pointed to by e.Expr.
+func (e encoderCtx) Deref() encoderCtx {
+	e.EncoderCtx = e.EncoderCtx.Deref()
+	return e
+}
+
+// Element returns the context for an element of the repeated field,
+// with the given expression.
+func (e encoderCtx) Element(expr string) encoderCtx {
+	return encoderCtx{EncoderCtx: e.EncoderCtx.Element(expr), Sorted: e.Sorted}
+}
+
+// Message returns the context for encoding the value of the field, which is
+// an embedded message, in JSON.
+func (e encoderCtx) Message() messageCtx {
+	return messageCtx{MessageCtx: gotarget.MessageCtx{Message: e.Value.Message, Expr: e.Expr}, Sorted: e.Sorted}
+}
@@ -0,0 +1,42 @@
+// Package goregistry is the feature of the Go target generating the
+// package-level functions which mirror amino's API, like Marshal and
+// Unmarshal, and dispatch to the methods of the messages with a type switch.
+// It is not supported with Gno.
+package goregistry
+
+import (
+	"text/template"
+
+	gotarget "github.com/thehowl/tomino/generator/targets/go"
+	"github.com/thehowl/tomino/generator/targets/go/gojson"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+// Feature generates the registry functions. The JSON functions, like
+// MarshalJSON, are only generated together with [gojson.Feature].
+var Feature = gotarget.Feature{
+	Name:     "registry",
+	Template: templateSource,
+	Funcs: template.FuncMap{
+		"registryjson": registryJSON,
+		"registryjsonany": func(f *gotarget.File) []*wire.Message {
+			return gojson.AnyMessages(f.Plan)
+		},
+	},
+	Imports: func(f *gotarget.File) []string {
+		if registryJSON(f) {
+			return []string{"bytes", "encoding/json", "fmt"}
+		}
+		return []string{"fmt"}
+	},
+}
+
+// registryJSON returns whether the JSON functions are generated.
+func registryJSON(f *gotarget.File) bool {
+	return f.Enabled(gojson.Feature.Name) && gojson.HasJSON(f.Plan)
+}
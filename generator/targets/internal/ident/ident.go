@@ -0,0 +1,28 @@
+// Package ident converts Go identifiers to the naming conventions of the
+// target languages.
+package ident
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Snake converts the Go identifier s to snake_case. Acronyms are kept in a
+// single word, so URLPath becomes url_path.
+func Snake(s string) string {
+	var bld strings.Builder
+	rs := []rune(s)
+	for i, r := range rs {
+		if unicode.IsUpper(r) {
+			// start a new word after a lowercase letter or digit, or at the
+			// last uppercase letter of an acronym (the "P" in URLPath).
+			if i > 0 && (!unicode.IsUpper(rs[i-1]) ||
+				(i+1 < len(rs) && unicode.IsLower(rs[i+1]))) && rs[i-1] != '_' {
+				bld.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		bld.WriteRune(r)
+	}
+	return bld.String()
+}
@@ -0,0 +1,80 @@
+// Package indent re-indents generated code for the targets which don't have
+// a formatter available in Go, like go/format for the Go target.
+package indent
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Braces re-indents src using unit, according to the brackets opened and
+// closed on each line. It also removes trailing whitespace and consecutive
+// blank lines.
+// This only works if the code never places unbalanced brackets in strings
+// or comments.
+func Braces(src []byte, unit string) []byte {
+	var (
+		buf   bytes.Buffer
+		depth int
+		blank = true
+	)
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if !blank {
+				buf.WriteByte('\n')
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		// closing brackets at the start of the line reduce the indentation
+		// of the line itself.
+		closing := len(line) - len(strings.TrimLeft(line, "})]"))
+		buf.WriteString(strings.Repeat(unit, max(depth-closing, 0)))
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		depth += strings.Count(line, "{") + strings.Count(line, "(") + strings.Count(line, "[") -
+			strings.Count(line, "}") - strings.Count(line, ")") - strings.Count(line, "]")
+	}
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+}
+
+// Blocks re-indents src using unit, for languages where blocks are delimited
+// by indentation. A line ending with ":" opens a block, and a line
+// containing only "}" closes it, and is removed from the output.
+// Blank lines are removed inside blocks, and at most two consecutive blank
+// lines are kept at the top level.
+// This only works if the code never has lines ending with ":" inside
+// multi-line strings.
+func Blocks(src []byte, unit string) []byte {
+	var (
+		buf   bytes.Buffer
+		depth int
+		blank = 2
+	)
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "}":
+			depth = max(depth-1, 0)
+			continue
+		case line == "":
+			if depth == 0 && blank < 2 {
+				buf.WriteByte('\n')
+				blank++
+			}
+			continue
+		}
+		blank = 0
+
+		buf.WriteString(strings.Repeat(unit, depth))
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if strings.HasSuffix(line, ":") && !strings.HasPrefix(line, "#") {
+			depth++
+		}
+	}
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+}
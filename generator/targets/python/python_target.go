@@ -0,0 +1,333 @@
+// Package pytarget generates Python dataclasses for the messages, together
+// with their encoders and decoders. The generated code only depends on the
+// standard library.
+//
+// The template is followed by the runtime in runtime.py, which is copied
+// as-is to the end of the output.
+package pytarget
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/targets/internal/ident"
+	"github.com/thehowl/tomino/generator/targets/internal/indent"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+var (
+	//go:embed template.tmpl
+	templateSource string
+	//go:embed runtime.py
+	runtime []byte
+)
+
+var tpl = template.Must(template.New("template.tmpl").
+	Funcs(template.FuncMap{
+		"throw": func(s string, args ...any) (string, error) {
+			return "", fmt.Errorf(s, args...)
+		},
+		"field": func(f *wire.Field, expr string) valueCtx {
+			return valueCtx{Field: f, Expr: expr + "." + fieldName(f), Omit: f.OmitEmpty}
+		},
+		"tagBytes":     tagBytes,
+		"emptyRecord":  emptyRecord,
+		"snake":        ident.Snake,
+		"fieldName":    fieldName,
+		"fieldType":    fieldType,
+		"fieldDefault": fieldDefault,
+		"fieldZero":    fieldZero,
+		"zero":         zero,
+		"messageType":  messageType,
+	}).
+	Parse(templateSource))
+
+// valueCtx is the parameter of the templates encoding and decoding values.
+type valueCtx struct {
+	*wire.Field
+	// Expr is the Python expression of the value.
+	Expr string
+	// Omit is set if empty values should be omitted.
+	Omit bool
+	// Elem is set for the elements of repeated fields, which are never
+	// omitted.
+	Elem bool
+}
+
+// Element returns the context for an element of the repeated field, with the
+// given expression.
+func (v valueCtx) Element(expr string) valueCtx {
+	return valueCtx{Field: v.Field, Expr: expr, Elem: true}
+}
+
+// NonEmpty returns the condition for v.Expr to be non-empty.
+func (v valueCtx) NonEmpty() string {
+	return v.Expr
+}
+
+// Write returns the statement writing the value of v, without its tag.
+func (v valueCtx) Write() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "w.uint(" + v.Expr + ", " + bits(v.Value) + ")", nil
+	case wire.KindVarint:
+		return "w.int(" + v.Expr + ", " + bits(v.Value) + ")", nil
+	case wire.KindBool:
+		return "w.bool(" + v.Expr + ")", nil
+	case wire.KindFixed64, wire.KindFixed32, wire.KindFloat64, wire.KindFloat32:
+		return "w.fixed(" + fixedStruct(v.Value) + ", " + v.Expr + ")", nil
+	case wire.KindBytes:
+		switch {
+		case v.Value.String:
+			return "w.string(" + v.Expr + ")", nil
+		case v.Value.Size >= 0:
+			return "w.byte_array(" + v.Expr + ", " + strconv.FormatInt(v.Value.Size, 10) + ")", nil
+		}
+		return "w.bytes(" + v.Expr + ")", nil
+	}
+	return "", fmt.Errorf("no writer for kind %s", v.Value.Kind)
+}
+
+// Read returns the expression reading a value of v.
+func (v valueCtx) Read() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "r.uint(" + bits(v.Value) + ")", nil
+	case wire.KindVarint:
+		return "r.int(" + bits(v.Value) + ")", nil
+	case wire.KindBool:
+		return "r.bool()", nil
+	case wire.KindFixed64, wire.KindFixed32, wire.KindFloat64, wire.KindFloat32:
+		return "r.fixed(" + fixedStruct(v.Value) + ")", nil
+	case wire.KindBytes:
+		switch {
+		case v.Value.String:
+			return "r.string()", nil
+		case v.Value.Size >= 0:
+			return "r.byte_array(" + strconv.FormatInt(v.Value.Size, 10) + ")", nil
+		}
+		return "r.bytes()", nil
+	case wire.KindMessage:
+		return "_read_" + ident.Snake(v.Value.Message.Name) + "(r, r.end())", nil
+	}
+	return "", fmt.Errorf("no reader for kind %s", v.Value.Kind)
+}
+
+// Options are the options for the Python target.
+type Options struct{}
+
+// Write generates a Python module with a dataclass for each of the given
+// messages and its encode_x and decode_x functions, followed by the runtime
+// they share, and writes it to w. The module only imports the standard
+// library.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+	if len(plan.Interfaces) > 0 {
+		return fmt.Errorf("interfaces are not supported by the Python target: %s", plan.Interfaces[0].Source)
+	}
+
+	data := struct {
+		Options
+		Plan *wire.Plan
+	}{opts, plan}
+
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "main", data); err != nil {
+		return err
+	}
+	out := append(indent.Blocks(buf.Bytes(), "    "), "\n\n"...)
+	_, err = w.Write(append(out, runtime...))
+	return err
+}
+
+// tagBytes returns b as a Python bytes literal.
+func tagBytes(b []byte) string {
+	var bld strings.Builder
+	bld.WriteString(`b"`)
+	for _, c := range b {
+		fmt.Fprintf(&bld, `\x%02x`, c)
+	}
+	bld.WriteByte('"')
+	return bld.String()
+}
+
+// emptyRecord returns the bytes of a len record of length 0 with the given
+// tag, as a Python bytes literal.
+func emptyRecord(tag []byte) string {
+	return tagBytes(append(tag[:len(tag):len(tag)], 0))
+}
+
+var reIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reserved are the keywords, and the names used in the type hints and
+// defaults in the body of the dataclasses, which fields can't shadow.
+var reserved = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true,
+	"assert": true, "async": true, "await": true, "break": true,
+	"class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true,
+	"global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true,
+	"raise": true, "return": true, "try": true, "while": true, "with": true,
+	"yield": true,
+
+	"bool": true, "bytes": true, "dataclasses": true, "datetime": true,
+	"float": true, "int": true, "list": true, "range": true, "str": true,
+}
+
+// fieldName returns the name of the field in Python, converting it to
+// snake_case.
+func fieldName(f *wire.Field) string {
+	name := ident.Snake(f.Name)
+	switch {
+	case reserved[name]:
+		return name + "_"
+	case !reIdent.MatchString(name):
+		// non-ASCII identifiers are allowed, but uncommon; stay on the
+		// safe side.
+		return "field_" + strconv.Itoa(int(f.BinFieldNum))
+	}
+	return name
+}
+
+// bits returns the size in bits of an integer value.
+func bits(v wire.Value) string {
+	switch v.Scalar {
+	case "int", "uint":
+		return "64"
+	case "byte":
+		return "8"
+	}
+	// intN and uintN.
+	return strings.TrimPrefix(strings.TrimPrefix(v.Scalar, "u"), "int")
+}
+
+// fixedStruct returns the runtime's struct.Struct for a fixed-size value.
+func fixedStruct(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindFloat64:
+		return "_DOUBLE"
+	case wire.KindFloat32:
+		return "_FLOAT"
+	}
+	s := "_FIXED" + bits(v)
+	if !strings.HasPrefix(v.Scalar, "u") {
+		s = "_SFIXED" + bits(v)
+	}
+	return s
+}
+
+// messageType returns the Python type of a message.
+func messageType(m *wire.Message) string {
+	switch m.WellKnown {
+	case wire.WellKnownTime:
+		return "datetime.datetime"
+	case wire.WellKnownDuration:
+		return "datetime.timedelta"
+	}
+	return m.Name
+}
+
+// valueType returns the Python type of a value.
+func valueType(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "bool"
+	case wire.KindFloat64, wire.KindFloat32:
+		return "float"
+	case wire.KindBytes:
+		if v.String {
+			return "str"
+		}
+		return "bytes"
+	case wire.KindMessage:
+		return messageType(v.Message)
+	}
+	return "int"
+}
+
+// fieldType returns the Python type hint of a field.
+func fieldType(f *wire.Field) string {
+	t := valueType(f.Value)
+	if f.Repeated != nil {
+		if f.Repeated.ElemOptional {
+			t = "Optional[" + t + "]"
+		}
+		t = "List[" + t + "]"
+	}
+	if f.Optional {
+		return "Optional[" + t + "]"
+	}
+	return t
+}
+
+// zero returns the expression of the zero value of v.
+func zero(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "False"
+	case wire.KindFloat64, wire.KindFloat32:
+		return "0.0"
+	case wire.KindBytes:
+		switch {
+		case v.String:
+			return `""`
+		case v.Size > 0:
+			return `b"\x00" * ` + strconv.FormatInt(v.Size, 10)
+		}
+		return `b""`
+	case wire.KindMessage:
+		switch v.Message.WellKnown {
+		case wire.WellKnownTime:
+			return "_EPOCH"
+		case wire.WellKnownDuration:
+			return "datetime.timedelta()"
+		}
+		return v.Message.Name + "()"
+	}
+	return "0"
+}
+
+// fieldZero returns the expression of the zero value of a non-optional
+// field.
+func fieldZero(f *wire.Field) string {
+	switch {
+	case f.Repeated == nil:
+		return zero(f.Value)
+	case f.Repeated.Size < 0:
+		return "[]"
+	case f.Repeated.ElemOptional:
+		return "[None] * " + strconv.FormatInt(f.Repeated.Size, 10)
+	case f.Value.Kind == wire.KindMessage && f.Value.Message.WellKnown == wire.WellKnownNone:
+		// messages are mutable, so each element needs its own instance.
+		return "[" + zero(f.Value) + " for _ in range(" + strconv.FormatInt(f.Repeated.Size, 10) + ")]"
+	default:
+		return "[" + zero(f.Value) + "] * " + strconv.FormatInt(f.Repeated.Size, 10)
+	}
+}
+
+// fieldDefault returns the default of the field in the dataclass.
+func fieldDefault(f *wire.Field) string {
+	switch {
+	case f.Optional:
+		return "None"
+	case f.Repeated != nil && f.Repeated.Size < 0:
+		return "dataclasses.field(default_factory=list)"
+	case f.Repeated != nil:
+		return "dataclasses.field(default_factory=lambda: " + fieldZero(f) + ")"
+	case f.Value.Kind == wire.KindMessage && f.Value.Message.WellKnown == wire.WellKnownNone:
+		return "dataclasses.field(default_factory=" + f.Value.Message.Name + ")"
+	}
+	return zero(f.Value)
+}
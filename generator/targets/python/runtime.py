@@ -0,0 +1,269 @@
+# ---
+# encoding runtime
+
+
+class DecodeError(ValueError):
+    """DecodeError is raised by the decoders on invalid input."""
+
+
+_FIXED64 = struct.Struct("<Q")
+_SFIXED64 = struct.Struct("<q")
+_FIXED32 = struct.Struct("<I")
+_SFIXED32 = struct.Struct("<i")
+_DOUBLE = struct.Struct("<d")
+_FLOAT = struct.Struct("<f")
+
+
+def _uvarint(x: int) -> bytes:
+    b = bytearray()
+    while x > 0x7F:
+        b.append((x & 0x7F) | 0x80)
+        x >>= 7
+    b.append(x)
+    return bytes(b)
+
+
+def _check_length(x, n: int) -> None:
+    if len(x) != n:
+        raise ValueError(f"tomino: expected array of length {n}, got {len(x)}")
+
+
+def _div_trunc(x: int, y: int) -> int:
+    # integer division rounding towards zero, like in Go.
+    q = abs(x) // y
+    return -q if x < 0 else q
+
+
+class _Timestamp:
+    """_Timestamp is the encoding of amino's well-known time types.
+
+    The seconds and nanoseconds are signed, but are encoded as unsigned
+    integers of 64 and 32 bits. Python's datetime types have a precision of
+    microseconds, so the nanoseconds are truncated when decoding.
+    """
+
+    __slots__ = ("seconds", "nanoseconds")
+
+    def __init__(self, seconds: int = 0, nanoseconds: int = 0) -> None:
+        self.seconds = seconds
+        self.nanoseconds = nanoseconds
+
+    @classmethod
+    def from_time(cls, t: datetime.datetime) -> _Timestamp:
+        if t.tzinfo is None:
+            # naive datetimes are assumed to be in UTC.
+            t = t.replace(tzinfo=datetime.timezone.utc)
+        d = t - _EPOCH
+        return cls.from_parts(d.days * 86400 + d.seconds, d.microseconds * 1000)
+
+    @classmethod
+    def from_duration(cls, d: datetime.timedelta) -> _Timestamp:
+        ns = d // datetime.timedelta(microseconds=1) * 1000
+        s = _div_trunc(ns, 1_000_000_000)
+        return cls.from_parts(s, ns - s * 1_000_000_000)
+
+    @classmethod
+    def from_parts(cls, s: int, ns: int) -> _Timestamp:
+        return cls(s & 0xFFFFFFFFFFFFFFFF, ns & 0xFFFFFFFF)
+
+    def parts(self) -> tuple:
+        s, ns = self.seconds, self.nanoseconds
+        if s >= 1 << 63:
+            s -= 1 << 64
+        if ns >= 1 << 31:
+            ns -= 1 << 32
+        return s, ns
+
+    def time(self) -> datetime.datetime:
+        s, ns = self.parts()
+        if not 0 <= ns < 1_000_000_000:
+            raise DecodeError(f"tomino: invalid nanoseconds {ns} in time")
+        try:
+            return _EPOCH + datetime.timedelta(seconds=s, microseconds=ns // 1000)
+        except OverflowError:
+            raise DecodeError(f"tomino: time out of range ({s} seconds)") from None
+
+    def duration(self) -> datetime.timedelta:
+        s, ns = self.parts()
+        try:
+            return datetime.timedelta(microseconds=_div_trunc(s * 1_000_000_000 + ns, 1000))
+        except OverflowError:
+            raise DecodeError(f"tomino: duration out of range ({s} seconds)") from None
+
+
+class _Writer:
+    def __init__(self) -> None:
+        self.buf = bytearray()
+
+    def raw(self, b: bytes) -> None:
+        self.buf += b
+
+    def uvarint(self, x: int) -> None:
+        self.buf += _uvarint(x)
+
+    def uint(self, x: int, bits: int) -> None:
+        if not 0 <= x < 1 << bits:
+            raise ValueError(f"tomino: {x} overflows a {bits}-bit unsigned integer")
+        self.uvarint(x)
+
+    def int(self, x: int, bits: int) -> None:
+        if not -(1 << (bits - 1)) <= x < 1 << (bits - 1):
+            raise ValueError(f"tomino: {x} overflows a {bits}-bit integer")
+        # zig-zag encoding.
+        self.uvarint((x << 1) ^ (x >> 63))
+
+    def bool(self, x: bool) -> None:
+        self.buf.append(1 if x else 0)
+
+    def fixed(self, s: struct.Struct, x) -> None:
+        self.buf += s.pack(x)
+
+    def bytes(self, x: bytes) -> None:
+        self.uvarint(len(x))
+        self.buf += x
+
+    def string(self, x: str) -> None:
+        try:
+            b = x.encode("utf-8")
+        except UnicodeEncodeError:
+            raise ValueError("tomino: string with unpaired surrogates can't be encoded as UTF-8") from None
+        self.bytes(b)
+
+    def byte_array(self, x: bytes, n: int) -> None:
+        _check_length(x, n)
+        self.bytes(x)
+
+    def begin(self, tag: bytes) -> int:
+        """Writes the tag of a length-prefixed value, and returns its position,
+        to be passed to end once the value has been written."""
+        start = len(self.buf)
+        self.buf += tag
+        return start
+
+    def end(self, start: int, tag_len: int, omit_empty: bool) -> None:
+        """Inserts the length of the value started with begin.
+        If the value is empty and omit_empty is set, the tag is removed
+        instead."""
+        value_start = start + tag_len
+        size = len(self.buf) - value_start
+        if size == 0 and omit_empty:
+            del self.buf[start:]
+            return
+        self.buf[value_start:value_start] = _uvarint(size)
+
+
+class _Reader:
+    def __init__(self, buf: bytes) -> None:
+        self.buf = memoryview(buf).cast("B")
+        self.pos = 0
+
+    def need(self, n: int) -> None:
+        if self.pos + n > len(self.buf):
+            raise DecodeError("tomino: unexpected end of input")
+
+    def uvarint(self) -> int:
+        x = 0
+        for shift in range(0, 70, 7):
+            self.need(1)
+            b = self.buf[self.pos]
+            self.pos += 1
+            x |= (b & 0x7F) << shift
+            if b < 0x80:
+                if x >> 64:
+                    break
+                return x
+        raise DecodeError("tomino: varint overflows a 64-bit integer")
+
+    def tag(self) -> tuple:
+        x = self.uvarint()
+        return x >> 3, x & 7
+
+    def expect(self, wt: int, want: int) -> None:
+        if wt != want:
+            raise DecodeError(f"tomino: invalid wire type {wt}, expected {want}")
+
+    def skip(self, wt: int) -> None:
+        if wt == 0:
+            self.uvarint()
+        elif wt == 1:
+            self.need(8)
+            self.pos += 8
+        elif wt == 2:
+            self.pos = self.end()
+        elif wt == 5:
+            self.need(4)
+            self.pos += 4
+        else:
+            raise DecodeError(f"tomino: invalid wire type {wt}")
+
+    def end(self) -> int:
+        """Reads the length prefix of a value, and returns the position where
+        the value ends."""
+        n = self.uvarint()
+        self.need(n)
+        return self.pos + n
+
+    def done(self, end: int) -> None:
+        if self.pos != end:
+            raise DecodeError("tomino: value overflows its length prefix")
+
+    def nil(self) -> bool:
+        """Reports whether the next value is empty, consuming its length
+        prefix if so. Nil elements of repeated fields are encoded as empty
+        values."""
+        self.need(1)
+        if self.buf[self.pos] != 0:
+            return False
+        self.pos += 1
+        return True
+
+    def index(self, i: int, n: int) -> int:
+        if i >= n:
+            raise DecodeError(f"tomino: too many elements for array of length {n}")
+        return i
+
+    def uint(self, bits: int) -> int:
+        x = self.uvarint()
+        if x >> bits:
+            raise DecodeError(f"tomino: varint overflows a {bits}-bit unsigned integer")
+        return x
+
+    def int(self, bits: int) -> int:
+        x = self.uvarint()
+        x = (x >> 1) ^ -(x & 1)
+        if not -(1 << (bits - 1)) <= x < 1 << (bits - 1):
+            raise DecodeError(f"tomino: varint overflows a {bits}-bit integer")
+        return x
+
+    def bool(self) -> bool:
+        # like in amino, bools are a single byte of value 0 or 1.
+        self.need(1)
+        b = self.buf[self.pos]
+        self.pos += 1
+        if b > 1:
+            raise DecodeError("tomino: invalid bool")
+        return b == 1
+
+    def fixed(self, s: struct.Struct):
+        self.need(s.size)
+        (x,) = s.unpack_from(self.buf, self.pos)
+        self.pos += s.size
+        return x
+
+    def bytes(self) -> bytes:
+        end = self.end()
+        b = bytes(self.buf[self.pos : end])
+        self.pos = end
+        return b
+
+    def string(self) -> str:
+        try:
+            return self.bytes().decode("utf-8")
+        except UnicodeDecodeError:
+            raise DecodeError("tomino: invalid UTF-8 string") from None
+
+    def byte_array(self, n: int) -> bytes:
+        b = self.bytes()
+        if len(b) != n:
+            raise DecodeError(f"tomino: expected array of length {n}, got {len(b)}")
+        return b
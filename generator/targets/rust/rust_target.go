@@ -0,0 +1,374 @@
+// Package rusttarget generates Rust structs for the messages, together with
+// their encoders and decoders. The generated code supports no_std, only
+// depending on the alloc crate.
+package rusttarget
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/targets/internal/ident"
+	"github.com/thehowl/tomino/generator/targets/internal/indent"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+var tpl = template.Must(template.New("template.tmpl").
+	Funcs(template.FuncMap{
+		"throw": func(s string, args ...any) (string, error) {
+			return "", fmt.Errorf(s, args...)
+		},
+		"field": func(f *wire.Field) valueCtx {
+			return valueCtx{Field: f, Expr: "self." + fieldName(f), Omit: f.OmitEmpty}
+		},
+		"tagBytes":       tagBytes,
+		"fieldName":      fieldName,
+		"fieldType":      fieldType,
+		"fieldDefault":   fieldDefault,
+		"presentDefault": presentDefault,
+		"written":        written,
+		"decoded":        decoded,
+		"usesTimestamp":  usesTimestamp,
+		"list":           func(v ...any) []any { return v },
+	}).
+	Parse(templateSource))
+
+// valueCtx is the parameter of the templates encoding and decoding values.
+type valueCtx struct {
+	*wire.Field
+	// Expr is the Rust place expression of the value.
+	Expr string
+	// Omit is set if empty values should be omitted.
+	Omit bool
+	// Elem is set for the elements of repeated fields, which are never
+	// omitted.
+	Elem bool
+	// Scoped is set if the value is already in its own block, like the
+	// payload of an optional or the body of a loop.
+	Scoped bool
+}
+
+// Element returns the context for an element of the repeated field, with the
+// given expression.
+func (v valueCtx) Element(expr string) valueCtx {
+	return valueCtx{Field: v.Field, Expr: expr, Elem: true, Scoped: true}
+}
+
+// Unwrap returns the context for the payload of an optional, bound by
+// reference to name.
+func (v valueCtx) Unwrap(name string) valueCtx {
+	v.Expr = "*" + name
+	v.Scoped = true
+	return v
+}
+
+// PackedElement returns the context for the elements of a packed field,
+// bound by value to el; None elements are encoded as zero values.
+func (v valueCtx) PackedElement() valueCtx {
+	if v.Repeated.ElemOptional {
+		return v.Element("el.unwrap_or(" + zero(v.Value) + ")")
+	}
+	return v.Element("el")
+}
+
+// Recv returns the expression of the value as the receiver of a method call.
+// Method calls dereference references automatically.
+func (v valueCtx) Recv() string {
+	return strings.TrimPrefix(v.Expr, "*")
+}
+
+// Ref returns the expression of a reference to the value.
+func (v valueCtx) Ref() string {
+	if strings.HasPrefix(v.Expr, "*") {
+		return v.Expr[1:]
+	}
+	return "&" + v.Expr
+}
+
+// Uses returns whether encoding the value refers to v.Expr, which is not the
+// case for messages without fields.
+func (v valueCtx) Uses() bool {
+	return v.Value.Kind != wire.KindMessage || len(v.Value.Message.Fields) != 0 ||
+		v.Value.Message.WellKnown != wire.WellKnownNone
+}
+
+// ByteArray returns whether the value is a byte array, which is always
+// written, like the other arrays.
+func (v valueCtx) ByteArray() bool {
+	return v.Value.Kind == wire.KindBytes && v.Value.Size >= 0
+}
+
+// NonEmpty returns the condition for v.Expr to be non-empty.
+func (v valueCtx) NonEmpty() string {
+	switch v.Value.Kind {
+	case wire.KindBool:
+		return v.Expr
+	case wire.KindBytes:
+		return "!" + v.Recv() + ".is_empty()"
+	}
+	return v.Expr + " != 0"
+}
+
+// Write returns the statement writing v.Expr to buf, excluding the tag.
+// Messages are written by the templates, as they need a length prefix.
+func (v valueCtx) Write() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "tomino::put_uvarint(buf, " + v.Expr + " as u64);", nil
+	case wire.KindVarint:
+		return "tomino::put_uvarint(buf, tomino::zigzag(" + v.Expr + " as i64));", nil
+	case wire.KindBool:
+		return "buf.push(" + v.Expr + " as u8);", nil
+	case wire.KindFixed64, wire.KindFixed32, wire.KindFloat64, wire.KindFloat32:
+		return "buf.extend_from_slice(&" + v.Recv() + ".to_le_bytes());", nil
+	case wire.KindBytes:
+		if v.Value.String {
+			return "tomino::put_bytes(buf, " + v.Recv() + ".as_bytes());", nil
+		}
+		return "tomino::put_bytes(buf, " + v.Ref() + ");", nil
+	}
+	return "", fmt.Errorf("no writer for kind %s", v.Value.Kind)
+}
+
+// Read returns the expression reading a value of v from the reader r.
+func (v valueCtx) Read(r string) (string, error) {
+	t := valueType(v.Value)
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		if t == "u64" {
+			return r + ".uvarint()?", nil
+		}
+		return r + ".uint::<" + t + ">()?", nil
+	case wire.KindVarint:
+		return r + ".int::<" + t + ">()?", nil
+	case wire.KindBool:
+		return r + ".bool()?", nil
+	case wire.KindFixed64, wire.KindFloat64:
+		return t + "::from_le_bytes(" + r + ".fixed::<8>()?)", nil
+	case wire.KindFixed32, wire.KindFloat32:
+		return t + "::from_le_bytes(" + r + ".fixed::<4>()?)", nil
+	case wire.KindBytes, wire.KindMessage:
+		return v.ReadBytes(r + ".bytes()?")
+	}
+	return "", fmt.Errorf("no reader for kind %s", v.Value.Kind)
+}
+
+// ReadBytes returns the expression decoding a length-delimited value of v
+// from the byte slice b.
+func (v valueCtx) ReadBytes(b string) (string, error) {
+	switch v.Value.Kind {
+	case wire.KindBytes:
+		switch {
+		case v.Value.String:
+			return "tomino::string(" + b + ")?", nil
+		case v.Value.Size >= 0:
+			return "tomino::byte_array::<" + strconv.FormatInt(v.Value.Size, 10) + ">(" + b + ")?", nil
+		}
+		return b + ".to_vec()", nil
+	case wire.KindMessage:
+		return valueType(v.Value) + "::decode(" + b + ")?", nil
+	}
+	return "", fmt.Errorf("kind %s is not length-delimited", v.Value.Kind)
+}
+
+// Options are the options for the Rust target.
+type Options struct{}
+
+// Write generates a no_std Rust module, with a struct for each of the given
+// messages implementing encode, encode_to_vec and decode, and writes it to w.
+// The module only depends on the alloc crate.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+	if len(plan.Interfaces) > 0 {
+		return fmt.Errorf("interfaces are not supported by the Rust target: %s", plan.Interfaces[0].Source)
+	}
+
+	data := struct {
+		Options
+		Plan *wire.Plan
+	}{opts, plan}
+
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "main", data); err != nil {
+		return err
+	}
+	_, err = w.Write(indent.Braces(buf.Bytes(), "    "))
+	return err
+}
+
+// tagBytes returns a slice literal of the bytes of a tag, followed by extra.
+func tagBytes(b []byte, extra ...byte) string {
+	b = append(b[:len(b):len(b)], extra...)
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = fmt.Sprintf("0x%02x", c)
+	}
+	return "&[" + strings.Join(s, ", ") + "]"
+}
+
+var reIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// keywords are the Rust keywords, which must be used as raw identifiers.
+var keywords = map[string]bool{
+	"abstract": true, "as": true, "async": true, "await": true, "become": true,
+	"box": true, "break": true, "const": true, "continue": true, "do": true,
+	"dyn": true, "else": true, "enum": true, "extern": true, "false": true,
+	"final": true, "fn": true, "for": true, "gen": true, "if": true,
+	"impl": true, "in": true, "let": true, "loop": true, "macro": true,
+	"match": true, "mod": true, "move": true, "mut": true, "override": true,
+	"priv": true, "pub": true, "ref": true, "return": true, "static": true,
+	"struct": true, "trait": true, "true": true, "try": true, "type": true,
+	"typeof": true, "unsafe": true, "unsized": true, "use": true,
+	"virtual": true, "where": true, "while": true, "yield": true,
+}
+
+// fieldName returns the name of the field in Rust, converting it to
+// snake_case.
+func fieldName(f *wire.Field) string {
+	name := ident.Snake(f.Name)
+	switch {
+	case name == "self" || name == "super" || name == "crate":
+		// can't be raw identifiers.
+		return name + "_"
+	case keywords[name]:
+		return "r#" + name
+	case !reIdent.MatchString(name):
+		// non-ASCII identifiers are allowed, but uncommon; stay on the
+		// safe side.
+		return "field_" + strconv.Itoa(int(f.BinFieldNum))
+	}
+	return name
+}
+
+// valueType returns the Rust type of a value.
+func valueType(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBytes:
+		switch {
+		case v.String:
+			return "String"
+		case v.Size >= 0:
+			return "[u8; " + strconv.FormatInt(v.Size, 10) + "]"
+		}
+		return "Vec<u8>"
+	case wire.KindMessage:
+		if v.Message.WellKnown != wire.WellKnownNone {
+			return "Timestamp"
+		}
+		return v.Message.Name
+	}
+	switch v.Scalar {
+	case "bool":
+		return "bool"
+	case "float64":
+		return "f64"
+	case "float32":
+		return "f32"
+	case "int":
+		return "i64"
+	case "uint":
+		return "u64"
+	case "byte":
+		return "u8"
+	}
+	// intN and uintN map to iN and uN.
+	return strings.Replace(strings.Replace(v.Scalar, "uint", "u", 1), "int", "i", 1)
+}
+
+// fieldType returns the Rust type of a field.
+// Recursive types are rejected by the generator, so optionals never need to
+// be boxed.
+func fieldType(f *wire.Field) string {
+	t := valueType(f.Value)
+	if f.Repeated != nil {
+		if f.Repeated.ElemOptional {
+			t = "Option<" + t + ">"
+		}
+		if f.Repeated.Size < 0 {
+			t = "Vec<" + t + ">"
+		} else {
+			t = "[" + t + "; " + strconv.FormatInt(f.Repeated.Size, 10) + "]"
+		}
+	}
+	if f.Optional {
+		return "Option<" + t + ">"
+	}
+	return t
+}
+
+// zero returns the expression of the zero value of a scalar.
+func zero(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "false"
+	case wire.KindFloat64, wire.KindFloat32:
+		return "0.0"
+	}
+	return "0"
+}
+
+// fieldDefault returns the default value of a field.
+func fieldDefault(f *wire.Field) string {
+	if f.Optional {
+		return "None"
+	}
+	return presentDefault(f)
+}
+
+// presentDefault returns the default value of a field, ignoring whether it
+// is optional.
+func presentDefault(f *wire.Field) string {
+	switch {
+	case f.Repeated != nil && f.Repeated.Size >= 0:
+		// Default is only implemented for arrays of up to 32 elements.
+		return "core::array::from_fn(|_| Default::default())"
+	case f.Repeated == nil && f.Value.Kind == wire.KindBytes && f.Value.Size >= 0:
+		return "[0; " + strconv.FormatInt(f.Value.Size, 10) + "]"
+	}
+	return "Default::default()"
+}
+
+// written returns whether the encoder of m writes any of its fields.
+func written(m *wire.Message) bool {
+	for _, f := range m.Fields {
+		if !f.NeverWritten() {
+			return true
+		}
+	}
+	return false
+}
+
+// decoded returns whether the decoder of m sets any of its fields; zero-size
+// arrays are always skipped.
+func decoded(m *wire.Message) bool {
+	for _, f := range m.Fields {
+		if f.Repeated == nil || f.Repeated.Size != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// usesTimestamp returns whether any of the messages is a well-known type,
+// represented using Timestamp.
+func usesTimestamp(messages []*wire.Message) bool {
+	for _, m := range messages {
+		if m.WellKnown != wire.WellKnownNone {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+// Package tstarget generates TypeScript encoders and decoders, together with
+// the interfaces describing the messages.
+package tstarget
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/targets/internal/indent"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+var tpl = template.Must(template.New("template.tmpl").
+	Funcs(template.FuncMap{
+		"throw": func(s string, args ...any) (string, error) {
+			return "", fmt.Errorf(s, args...)
+		},
+		"field": func(f *wire.Field, expr string) valueCtx {
+			return valueCtx{Field: f, Expr: expr + prop(f.JSONName), Omit: f.OmitEmpty}
+		},
+		"tag":       tag,
+		"prop":      prop,
+		"key":       key,
+		"fieldType": fieldType,
+		"valueType": valueType,
+		"zero":      zero,
+		"fieldZero": fieldZero,
+		"method":    method,
+	}).
+	Parse(templateSource))
+
+// valueCtx is the parameter of the templates encoding and decoding values.
+type valueCtx struct {
+	*wire.Field
+	// Expr is the TypeScript expression of the value.
+	Expr string
+	// Omit is set if empty values should be omitted.
+	Omit bool
+	// Elem is set for the elements of repeated fields, which are never
+	// omitted.
+	Elem bool
+}
+
+// Element returns the context for an element of the repeated field, with the
+// given expression.
+func (v valueCtx) Element(expr string) valueCtx {
+	return valueCtx{Field: v.Field, Expr: expr, Elem: true}
+}
+
+// BitSize returns the size in bits of 8- and 16-bit integers, which are
+// range-checked when decoding; it returns "" for any other value.
+func (v valueCtx) BitSize() string {
+	switch v.Value.Scalar {
+	case "int8", "uint8":
+		return "8"
+	case "int16", "uint16":
+		return "16"
+	}
+	return ""
+}
+
+// NonEmpty returns the condition for v.Expr to be non-empty.
+func (v valueCtx) NonEmpty() string {
+	switch v.Value.Kind {
+	case wire.KindBool:
+		return v.Expr
+	case wire.KindBytes:
+		return v.Expr + ".length !== 0"
+	}
+	if isBigInt(v.Value) {
+		return v.Expr + " !== 0n"
+	}
+	return v.Expr + " !== 0"
+}
+
+// Options are the options for the TypeScript target.
+type Options struct{}
+
+// Write generates a TypeScript module with an interface for each of the given
+// messages, and the functions to create, encode and decode it, followed by
+// the runtime they share, and writes it to w.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+	if len(plan.Interfaces) > 0 {
+		return fmt.Errorf("interfaces are not supported by the TypeScript target: %s", plan.Interfaces[0].Source)
+	}
+
+	data := struct {
+		Options
+		Plan *wire.Plan
+	}{opts, plan}
+
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "main", data); err != nil {
+		return err
+	}
+	_, err = w.Write(indent.Braces(buf.Bytes(), "\t"))
+	return err
+}
+
+// tag returns the bytes of a tag, as arguments to a function call.
+func tag(b []byte) string {
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = fmt.Sprintf("0x%02x", c)
+	}
+	return strings.Join(s, ", ")
+}
+
+var reIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// prop returns the accessor for the property name.
+func prop(name string) string {
+	if reIdent.MatchString(name) {
+		return "." + name
+	}
+	return "[" + strconv.Quote(name) + "]"
+}
+
+// key returns name as the key of a property in a type or object literal.
+func key(name string) string {
+	if reIdent.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// isBigInt returns whether the value is represented as a bigint.
+func isBigInt(v wire.Value) bool {
+	switch v.Scalar {
+	case "int", "int64", "uint", "uint64":
+		return true
+	}
+	return false
+}
+
+// valueType returns the TypeScript type of a value.
+func valueType(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "boolean"
+	case wire.KindBytes:
+		if v.String {
+			return "string"
+		}
+		return "Uint8Array"
+	case wire.KindMessage:
+		return v.Message.Name
+	}
+	if isBigInt(v) {
+		return "bigint"
+	}
+	return "number"
+}
+
+// fieldType returns the TypeScript type of a field, excluding the optional
+// marker.
+func fieldType(f *wire.Field) string {
+	t := valueType(f.Value)
+	if f.Repeated == nil {
+		return t
+	}
+	if f.Repeated.ElemOptional {
+		return "(" + t + " | null)[]"
+	}
+	return t + "[]"
+}
+
+// zero returns the expression of the zero value of v.
+func zero(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "false"
+	case wire.KindBytes:
+		switch {
+		case v.String:
+			return `""`
+		case v.Size >= 0:
+			return "new Uint8Array(" + strconv.FormatInt(v.Size, 10) + ")"
+		}
+		return "new Uint8Array(0)"
+	case wire.KindMessage:
+		return "default" + v.Message.Name + "()"
+	}
+	if isBigInt(v) {
+		return "0n"
+	}
+	return "0"
+}
+
+// fieldZero returns the expression of the zero value of a non-optional
+// field.
+func fieldZero(f *wire.Field) string {
+	switch {
+	case f.Repeated == nil:
+		return zero(f.Value)
+	case f.Repeated.Size < 0:
+		return "[]"
+	case f.Repeated.ElemOptional:
+		return "Array.from({ length: " + strconv.FormatInt(f.Repeated.Size, 10) + " }, () => null)"
+	default:
+		return "Array.from({ length: " + strconv.FormatInt(f.Repeated.Size, 10) + " }, () => " + zero(f.Value) + ")"
+	}
+}
+
+// method returns the name of the runtime methods reading and writing a
+// scalar or bytes value.
+func method(v wire.Value) (string, error) {
+	switch v.Kind {
+	case wire.KindBool:
+		return "bool", nil
+	case wire.KindUvarint:
+		if isBigInt(v) {
+			return "uint64", nil
+		}
+		return "uint32", nil
+	case wire.KindVarint:
+		if isBigInt(v) {
+			return "int64", nil
+		}
+		return "int32", nil
+	case wire.KindFixed64:
+		if v.Scalar == "int64" {
+			return "sfixed64", nil
+		}
+		return "fixed64", nil
+	case wire.KindFixed32:
+		if v.Scalar == "int32" {
+			return "sfixed32", nil
+		}
+		return "fixed32", nil
+	case wire.KindFloat64:
+		return "double", nil
+	case wire.KindFloat32:
+		return "float", nil
+	case wire.KindBytes:
+		if v.String {
+			return "string", nil
+		}
+		return "bytes", nil
+	}
+	return "", fmt.Errorf("no runtime method for kind %s", v.Kind)
+}
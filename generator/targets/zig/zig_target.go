@@ -0,0 +1,414 @@
+// Package zigtarget generates Zig structs for the messages, together with
+// their encoders and decoders.
+package zigtarget
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thehowl/tomino/generator/ir"
+	"github.com/thehowl/tomino/generator/targets/internal/indent"
+	"github.com/thehowl/tomino/generator/wire"
+
+	_ "embed"
+)
+
+//go:embed template.tmpl
+var templateSource string
+
+var tpl = template.Must(template.New("template.tmpl").
+	Funcs(template.FuncMap{
+		"throw": func(s string, args ...any) (string, error) {
+			return "", fmt.Errorf(s, args...)
+		},
+		"field": func(f *wire.Field) valueCtx {
+			return valueCtx{Field: f, Expr: "self." + ident(f.JSONName), Omit: f.OmitEmpty}
+		},
+		"tagBytes":       tagBytes,
+		"ident":          ident,
+		"fieldType":      fieldType,
+		"fieldDefault":   fieldDefault,
+		"presentDefault": presentDefault,
+		"elemType":       elemType,
+		"written":        written,
+		"decoded":        decoded,
+		"allocates":      allocates,
+	}).
+	Parse(templateSource))
+
+// valueCtx is the parameter of the templates encoding and decoding values.
+type valueCtx struct {
+	*wire.Field
+	// Expr is the Zig expression of the value.
+	Expr string
+	// Omit is set if empty values should be omitted.
+	Omit bool
+	// Elem is set for the elements of repeated fields, which are never
+	// omitted.
+	Elem bool
+	// Scoped is set if the value is the payload of an optional, so it is
+	// already in its own block.
+	Scoped bool
+}
+
+// Element returns the context for an element of the repeated field, with the
+// given expression.
+func (v valueCtx) Element(expr string) valueCtx {
+	return valueCtx{Field: v.Field, Expr: expr, Elem: true}
+}
+
+// PackedElement returns the context for the elements of a packed field,
+// captured as el; null elements are encoded as zero values.
+func (v valueCtx) PackedElement() valueCtx {
+	if v.Repeated.ElemOptional {
+		return v.Element("el orelse " + zero(v.Value))
+	}
+	return v.Element("el")
+}
+
+// Unwrap returns the context for the payload of an optional, captured as
+// expr.
+func (v valueCtx) Unwrap(expr string) valueCtx {
+	v.Expr = expr
+	v.Scoped = true
+	return v
+}
+
+// NonEmpty returns the condition for v.Expr to be non-empty.
+func (v valueCtx) NonEmpty() string {
+	switch v.Value.Kind {
+	case wire.KindBool:
+		return v.Expr
+	case wire.KindBytes:
+		return v.Expr + ".len != 0"
+	}
+	return v.Expr + " != 0"
+}
+
+// Uses returns whether encoding the value refers to v.Expr, which is not the
+// case for messages without fields.
+func (v valueCtx) Uses() bool {
+	return v.Value.Kind != wire.KindMessage || len(v.Value.Message.Fields) != 0
+}
+
+// ByteArray returns whether the value is a byte array, which is always
+// written, like the other arrays.
+func (v valueCtx) ByteArray() bool {
+	return v.Value.Kind == wire.KindBytes && v.Value.Size >= 0
+}
+
+// Constant returns whether the encoded size of the value is the same for all
+// values, so it does not depend on v.Expr.
+func (v valueCtx) Constant() bool {
+	switch v.Value.Kind {
+	case wire.KindBool, wire.KindFixed64, wire.KindFixed32, wire.KindFloat64, wire.KindFloat32:
+		return true
+	}
+	return v.ByteArray() || !v.Uses()
+}
+
+// SizeUses returns whether the encoded size of the field refers to v.Expr.
+func (v valueCtx) SizeUses() bool {
+	if v.Repeated != nil {
+		return true
+	}
+	// empty values of constant size are only checked when omitted.
+	return !v.Constant() || (v.Omit && v.Value.Omittable() && !v.ByteArray())
+}
+
+// Size returns the expression of the encoded size of v.Expr, excluding the
+// tag.
+func (v valueCtx) Size() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "tomino.uvarintSize(" + v.Expr + ")", nil
+	case wire.KindVarint:
+		return "tomino.uvarintSize(tomino.zigzag(" + v.Expr + "))", nil
+	case wire.KindBool:
+		return "1", nil
+	case wire.KindFixed64, wire.KindFloat64:
+		return "8", nil
+	case wire.KindFixed32, wire.KindFloat32:
+		return "4", nil
+	case wire.KindBytes:
+		if v.Value.Size >= 0 {
+			return "tomino.lenSize(" + strconv.FormatInt(v.Value.Size, 10) + ")", nil
+		}
+		return "tomino.lenSize(" + v.Expr + ".len)", nil
+	case wire.KindMessage:
+		if !v.Uses() {
+			return "1", nil
+		}
+		return "tomino.lenSize(" + v.Expr + ".encodedSize())", nil
+	}
+	return "", fmt.Errorf("no size for kind %s", v.Value.Kind)
+}
+
+// Write returns the statement writing v.Expr to writer, excluding the tag.
+func (v valueCtx) Write() (string, error) {
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "try tomino.writeUvarint(writer, " + v.Expr + ");", nil
+	case wire.KindVarint:
+		return "try tomino.writeUvarint(writer, tomino.zigzag(" + v.Expr + "));", nil
+	case wire.KindBool:
+		return "try writer.writeByte(@intFromBool(" + v.Expr + "));", nil
+	case wire.KindFixed64, wire.KindFloat64:
+		return "try writer.writeInt(u64, @bitCast(" + v.Expr + "), .little);", nil
+	case wire.KindFixed32, wire.KindFloat32:
+		return "try writer.writeInt(u32, @bitCast(" + v.Expr + "), .little);", nil
+	case wire.KindBytes:
+		if v.Value.Size >= 0 {
+			return "try tomino.writeBytes(writer, &" + v.Expr + ");", nil
+		}
+		return "try tomino.writeBytes(writer, " + v.Expr + ");", nil
+	case wire.KindMessage:
+		return "try tomino.writeUvarint(writer, " + v.Expr + ".encodedSize());\n" +
+			"try " + v.Expr + ".encode(writer);", nil
+	}
+	return "", fmt.Errorf("no writer for kind %s", v.Value.Kind)
+}
+
+// Read returns the expression reading a value of v using the reader r.
+func (v valueCtx) Read(r string) (string, error) {
+	t := valueType(v.Value)
+	switch v.Value.Kind {
+	case wire.KindUvarint:
+		return "try tomino.cast(" + t + ", try " + r + ".uvarint())", nil
+	case wire.KindVarint:
+		return "try tomino.cast(" + t + ", tomino.unzigzag(try " + r + ".uvarint()))", nil
+	case wire.KindBool:
+		return "try " + r + ".boolean()", nil
+	case wire.KindFixed64, wire.KindFloat64:
+		return "@as(" + t + ", @bitCast(try " + r + ".fixed(u64)))", nil
+	case wire.KindFixed32, wire.KindFloat32:
+		return "@as(" + t + ", @bitCast(try " + r + ".fixed(u32)))", nil
+	case wire.KindBytes:
+		if v.Value.Size >= 0 {
+			return "try tomino.byteArray(" + strconv.FormatInt(v.Value.Size, 10) + ", try " + r + ".bytes())", nil
+		}
+		return "try allocator.dupe(u8, try " + r + ".bytes())", nil
+	case wire.KindMessage:
+		return "try " + v.Value.Message.Name + ".decode(allocator, try " + r + ".bytes())", nil
+	}
+	return "", fmt.Errorf("no reader for kind %s", v.Value.Kind)
+}
+
+// Options are the options for the Zig target.
+type Options struct{}
+
+// Write generates a Zig source file with a struct for each of the given
+// messages, with methods to encode it to a writer and decode it using an
+// allocator, and writes it to w.
+func Write(w io.Writer, messages []ir.StructRecord, opts Options) error {
+	plan, err := wire.Lower(messages)
+	if err != nil {
+		return err
+	}
+	if len(plan.Interfaces) > 0 {
+		return fmt.Errorf("interfaces are not supported by the Zig target: %s", plan.Interfaces[0].Source)
+	}
+
+	data := struct {
+		Options
+		Plan *wire.Plan
+	}{opts, plan}
+
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "main", data); err != nil {
+		return err
+	}
+	_, err = w.Write(indent.Braces(buf.Bytes(), "    "))
+	return err
+}
+
+// tagBytes returns a slice literal of the bytes of a tag, followed by extra.
+func tagBytes(b []byte, extra ...byte) string {
+	b = append(b[:len(b):len(b)], extra...)
+	s := make([]string, len(b))
+	for i, c := range b {
+		s[i] = fmt.Sprintf("0x%02x", c)
+	}
+	if len(s) == 1 {
+		return "&[_]u8{" + s[0] + "}"
+	}
+	return "&[_]u8{ " + strings.Join(s, ", ") + " }"
+}
+
+var (
+	reIdent     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	rePrimitive = regexp.MustCompile(`^[iu][0-9]+$`)
+)
+
+// keywords are the Zig keywords and primitive names, which can't be used as
+// bare identifiers.
+var keywords = map[string]bool{
+	"addrspace": true, "align": true, "allowzero": true, "and": true,
+	"anyframe": true, "anytype": true, "asm": true, "async": true,
+	"await": true, "break": true, "callconv": true, "catch": true,
+	"comptime": true, "const": true, "continue": true, "defer": true,
+	"else": true, "enum": true, "errdefer": true, "error": true,
+	"export": true, "extern": true, "fn": true, "for": true, "if": true,
+	"inline": true, "linksection": true, "noalias": true,
+	"noinline": true, "nosuspend": true, "opaque": true, "or": true,
+	"orelse": true, "packed": true, "pub": true, "resume": true,
+	"return": true, "struct": true, "suspend": true, "switch": true,
+	"test": true, "threadlocal": true, "try": true, "union": true,
+	"unreachable": true, "usingnamespace": true, "var": true,
+	"volatile": true, "while": true,
+
+	"anyerror": true, "anyopaque": true, "bool": true, "comptime_float": true,
+	"comptime_int": true, "f16": true, "f32": true, "f64": true, "f80": true,
+	"f128": true, "false": true, "isize": true, "noreturn": true,
+	"null": true, "true": true, "type": true, "undefined": true,
+	"usize": true, "void": true,
+}
+
+// ident returns name as a Zig identifier, quoting it if necessary.
+func ident(name string) string {
+	if reIdent.MatchString(name) && !keywords[name] && !rePrimitive.MatchString(name) {
+		return name
+	}
+	return "@" + strconv.Quote(name)
+}
+
+// valueType returns the Zig type of a value.
+func valueType(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBytes:
+		if v.Size >= 0 {
+			return "[" + strconv.FormatInt(v.Size, 10) + "]u8"
+		}
+		return "[]const u8"
+	case wire.KindMessage:
+		return v.Message.Name
+	}
+	switch v.Scalar {
+	case "bool":
+		return "bool"
+	case "float64":
+		return "f64"
+	case "float32":
+		return "f32"
+	case "int":
+		return "i64"
+	case "uint":
+		return "u64"
+	case "byte":
+		return "u8"
+	}
+	// intN and uintN map to iN and uN.
+	return strings.Replace(strings.Replace(v.Scalar, "uint", "u", 1), "int", "i", 1)
+}
+
+// elemType returns the Zig type of the elements of f, or of its value if it
+// is not repeated.
+func elemType(f *wire.Field) string {
+	t := valueType(f.Value)
+	if f.Repeated != nil && f.Repeated.ElemOptional {
+		return "?" + t
+	}
+	return t
+}
+
+// fieldType returns the Zig type of a field.
+func fieldType(f *wire.Field) string {
+	t := elemType(f)
+	switch {
+	case f.Repeated == nil:
+	case f.Repeated.Size < 0:
+		t = "[]const " + t
+	default:
+		t = "[" + strconv.FormatInt(f.Repeated.Size, 10) + "]" + t
+	}
+	if f.Optional {
+		return "?" + t
+	}
+	return t
+}
+
+// zero returns the expression of the zero value of v.
+func zero(v wire.Value) string {
+	switch v.Kind {
+	case wire.KindBool:
+		return "false"
+	case wire.KindBytes:
+		if v.Size > 0 {
+			return "[_]u8{0} ** " + strconv.FormatInt(v.Size, 10)
+		}
+		if v.Size == 0 {
+			return ".{}"
+		}
+		return `""`
+	case wire.KindMessage:
+		return ".{}"
+	}
+	return "0"
+}
+
+// fieldDefault returns the default value of a field.
+func fieldDefault(f *wire.Field) string {
+	if f.Optional {
+		return "null"
+	}
+	return presentDefault(f)
+}
+
+// presentDefault returns the default value of a field, ignoring whether it
+// is optional.
+func presentDefault(f *wire.Field) string {
+	switch {
+	case f.Repeated == nil:
+		return zero(f.Value)
+	case f.Repeated.Size < 0:
+		return "&.{}"
+	case f.Repeated.Size == 0:
+		return ".{}"
+	case f.Repeated.ElemOptional:
+		return "[_]" + elemType(f) + "{null} ** " + strconv.FormatInt(f.Repeated.Size, 10)
+	default:
+		return "[_]" + elemType(f) + "{" + zero(f.Value) + "} ** " + strconv.FormatInt(f.Repeated.Size, 10)
+	}
+}
+
+// written returns whether the encoder of m writes any of its fields.
+// Zig rejects unused parameters, so encoders which don't have to discard
+// them explicitly.
+func written(m *wire.Message) bool {
+	for _, f := range m.Fields {
+		if !f.NeverWritten() {
+			return true
+		}
+	}
+	return false
+}
+
+// decoded returns whether the decoder of m sets any of its fields; zero-size
+// arrays are always skipped.
+func decoded(m *wire.Message) bool {
+	for _, f := range m.Fields {
+		if f.Repeated == nil || f.Repeated.Size != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// allocates returns whether the decoder of m uses its allocator.
+func allocates(m *wire.Message) bool {
+	for _, f := range m.Fields {
+		switch {
+		case f.Repeated != nil && f.Repeated.Size == 0:
+		case f.Repeated != nil && f.Repeated.Size < 0,
+			f.Value.Kind == wire.KindMessage,
+			f.Value.Kind == wire.KindBytes && f.Value.Size < 0:
+			return true
+		}
+	}
+	return false
+}
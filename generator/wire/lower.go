@@ -0,0 +1,251 @@
+package wire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+// Lower lowers the given messages into a wire plan.
+//
+// The messages must be valid, like those returned by generator.Parse; Lower
+// doesn't validate them again. Records which cannot be encoded, like repeated
+// records directly containing other repeated records, and interfaces which
+// cannot hold any of the messages, are reported as [ir.ValidationErrors].
+func Lower(messages []ir.StructRecord) (*Plan, error) {
+	l := &lowerer{
+		plan:       &Plan{Messages: make([]*Message, 0, len(messages))},
+		bySource:   make(map[sourceKey]*Message),
+		names:      make(map[string]bool),
+		interfaces: make(map[string]*Interface),
+		ifaceNames: make(map[string]bool),
+		ifacePaths: make(map[*Interface]string),
+	}
+	for _, msg := range messages {
+		m := l.message(msg.Name, msg.Name, msg)
+		m.TypeURL = msg.TypeURL
+		l.plan.Messages = append(l.plan.Messages, m)
+	}
+	// the implementations are resolved once all the messages are lowered.
+	for _, it := range l.plan.Interfaces {
+		l.implementations(it)
+	}
+	if err := l.errs.Err(); err != nil {
+		return nil, err
+	}
+	return l.plan, nil
+}
+
+type lowerer struct {
+	plan *Plan
+	errs ir.ValidationErrors
+	// named structs which have already been lowered.
+	bySource map[sourceKey]*Message
+	// names in use.
+	names map[string]bool
+	// interfaces which have already been lowered, by interfaceKey.
+	interfaces map[string]*Interface
+	// names in use by the interfaces.
+	ifaceNames map[string]bool
+	// path of the first field containing each interface, for errors.
+	ifacePaths map[*Interface]string
+}
+
+// sourceKey identifies a named struct. The same struct is lowered once for
+// the messages keeping their unknown fields, and once for the others.
+type sourceKey struct {
+	source      string
+	keepUnknown bool
+}
+
+func (l *lowerer) errorf(path, format string, args ...any) {
+	l.errs = append(l.errs, &ir.ValidationError{Path: path, Err: fmt.Errorf(format, args...)})
+}
+
+// message lowers rec. name is the name to use if the struct is anonymous.
+func (l *lowerer) message(path, name string, rec ir.StructRecord) *Message {
+	key := sourceKey{rec.Source, rec.KeepUnknown}
+	if rec.Source != "" {
+		if m, ok := l.bySource[key]; ok {
+			return m
+		}
+	}
+	if rec.Name != "" {
+		name = rec.Name
+	}
+
+	m := &Message{
+		Name:      l.uniqueName(name),
+		Source:    rec.Source,
+		Record:    rec,
+		Fields:    make([]*Field, 0, len(rec.Fields)),
+		WellKnown: WellKnown(rec.Source),
+	}
+	if m.WellKnown != WellKnownTime && m.WellKnown != WellKnownDuration {
+		m.WellKnown = WellKnownNone
+	}
+	if rec.Source != "" {
+		// register before lowering the fields, so the message is only lowered
+		// once. (recursive types are rejected by the generator).
+		l.bySource[key] = m
+	}
+
+	for _, fld := range rec.Fields {
+		fldPath := fld.Name
+		if path != "" {
+			fldPath = path + "." + fld.Name
+		}
+		m.Fields = append(m.Fields, l.field(fldPath, m.Name, fld))
+	}
+	l.plan.All = append(l.plan.All, m)
+	return m
+}
+
+// uniqueName returns name, or name followed by a number if it is already in
+// use by another message.
+func (l *lowerer) uniqueName(name string) string {
+	return uniqueName(l.names, name)
+}
+
+// uniqueName returns name, or name followed by a number if it is already in
+// names, and adds it to names.
+func uniqueName(names map[string]bool, name string) string {
+	res := name
+	for i := 2; names[res]; i++ {
+		res = name + strconv.Itoa(i)
+	}
+	names[res] = true
+	return res
+}
+
+// iface lowers rec. name is the name to use if the interface is not named,
+// like interface{ Route() string }.
+func (l *lowerer) iface(path, name string, rec ir.AnyRecord) *Interface {
+	key := rec.Source + " " + strings.Join(rec.Subset, ",")
+	if it, ok := l.interfaces[key]; ok {
+		return it
+	}
+	// x.Msg is named Msg; any and error are named Any and Error.
+	if id := rec.Source[strings.LastIndexByte(rec.Source, '.')+1:]; token.IsIdentifier(id) {
+		name = strings.ToUpper(id[:1]) + id[1:]
+	}
+	it := &Interface{Name: uniqueName(l.ifaceNames, name), Source: rec.Source, Record: rec}
+	l.interfaces[key] = it
+	l.plan.Interfaces = append(l.plan.Interfaces, it)
+	l.ifacePaths[it] = path
+	return it
+}
+
+// implementations sets the Implementations of it, from the messages of the
+// plan in the Subset of its record.
+func (l *lowerer) implementations(it *Interface) {
+	path := l.ifacePaths[it]
+	urls := make(map[string]string, len(it.Record.Subset))
+	for _, src := range it.Record.Subset {
+		impl := Implementation{Pointer: strings.HasPrefix(src, "*")}
+		src = strings.TrimPrefix(src, "*")
+		pos := slices.IndexFunc(l.plan.Messages, func(m *Message) bool { return m.Source == src })
+		if pos < 0 {
+			l.errorf(path, "type %s of interface %s is not one of the messages", src, it.Source)
+			continue
+		}
+		impl.Message = l.plan.Messages[pos]
+		switch other, dup := urls[impl.TypeURL]; {
+		case impl.TypeURL == "":
+			l.errorf(path, "type %s of interface %s has no type URL", src, it.Source)
+		case dup:
+			l.errorf(path, "type %s of interface %s has the same type URL as %s: %s", src, it.Source, other, impl.TypeURL)
+		default:
+			urls[impl.TypeURL] = src
+			it.Implementations = append(it.Implementations, impl)
+		}
+	}
+	if len(it.Record.Subset) == 0 {
+		l.errorf(path, "interface %s is not implemented by any of the messages", it.Source)
+	}
+}
+
+func (l *lowerer) field(path, msgName string, fld ir.StructField) *Field {
+	f := &Field{
+		StructField: fld,
+		OmitEmpty:   fld.TagFlag&ir.WriteEmpty == 0,
+	}
+
+	rec := fld.Record
+	if or, ok := rec.(ir.OptionalRecord); ok {
+		f.Optional = true
+		rec = or.Elem
+	}
+	if rr, ok := rec.(ir.RepeatedRecord); ok {
+		f.Repeated = &Repeated{Size: rr.Size}
+		path += "[]"
+		rec = rr.Elem
+		if or, ok := rec.(ir.OptionalRecord); ok {
+			f.Repeated.ElemOptional = true
+			rec = or.Elem
+		}
+		if _, ok := rec.(ir.RepeatedRecord); ok {
+			l.errorf(path, "repeated records of repeated records are not supported")
+			return f
+		}
+	}
+
+	f.Value = l.value(path, msgName+fld.Name, fld.WithRecord(rec))
+	f.WireType = f.Value.WireType
+	if f.Repeated != nil {
+		// scalars are packed in a single len record; the other elements have
+		// a len record each.
+		f.Repeated.Packed = f.Value.WireType != ir.WireLen
+		f.WireType = ir.WireLen
+	}
+	f.Tag = binary.AppendUvarint(nil, uint64(fld.BinFieldNum)<<3|uint64(f.WireType))
+	return f
+}
+
+// value lowers the record of fld, which must have been stripped of
+// OptionalRecord and RepeatedRecord.
+func (l *lowerer) value(path, name string, fld ir.StructField) Value {
+	v := Value{Record: fld.Record, Size: -1}
+	switch rec := fld.Record.(type) {
+	case ir.ScalarRecord:
+		v.Scalar = rec.Name
+		v.WireType = fld.WireType()
+		switch {
+		case rec.Name == "bool":
+			v.Kind = KindBool
+		case rec.Name == "float64":
+			v.Kind = KindFloat64
+		case rec.Name == "float32":
+			v.Kind = KindFloat32
+		case fld.TagFlag&ir.BinFixed64 != 0:
+			v.Kind = KindFixed64
+		case fld.TagFlag&ir.BinFixed32 != 0:
+			v.Kind = KindFixed32
+		case rec.IsUnsigned():
+			v.Kind = KindUvarint
+		default:
+			v.Kind = KindVarint
+		}
+	case ir.BytesRecord:
+		v.Kind = KindBytes
+		v.WireType = ir.WireLen
+		v.Size = rec.Size
+		v.String = rec.String
+	case ir.StructRecord:
+		v.Kind = KindMessage
+		v.WireType = ir.WireLen
+		v.Message = l.message(path, name, rec)
+	case ir.AnyRecord:
+		v.Kind = KindAny
+		v.WireType = ir.WireLen
+		v.Interface = l.iface(path, name, rec)
+	default:
+		l.errorf(path, "%s records are not supported", fld.Record.Kind())
+	}
+	return v
+}
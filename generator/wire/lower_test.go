@@ -0,0 +1,359 @@
+package wire
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+func TestLowerValues(t *testing.T) {
+	var (
+		int64Rec  = ir.ScalarRecord{Name: "int64"}
+		uint32Rec = ir.ScalarRecord{Name: "uint32"}
+	)
+	tt := []struct {
+		name   string
+		rec    ir.Record
+		flag   ir.TagFlag
+		kind   Kind
+		wire   ir.WireType
+		tag    string
+		packed bool
+	}{
+		{name: "varint", rec: int64Rec, kind: KindVarint, wire: ir.WireVarint, tag: "08"},
+		{name: "uvarint", rec: uint32Rec, kind: KindUvarint, wire: ir.WireVarint, tag: "08"},
+		{name: "bool", rec: ir.ScalarRecord{Name: "bool"}, kind: KindBool, wire: ir.WireVarint, tag: "08"},
+		{name: "fixed64", rec: int64Rec, flag: ir.BinFixed64, kind: KindFixed64, wire: ir.WireI64, tag: "09"},
+		{name: "fixed32", rec: uint32Rec, flag: ir.BinFixed32, kind: KindFixed32, wire: ir.WireI32, tag: "0d"},
+		{name: "float64", rec: ir.ScalarRecord{Name: "float64"}, flag: ir.Unsafe, kind: KindFloat64, wire: ir.WireI64, tag: "09"},
+		{name: "float32", rec: ir.ScalarRecord{Name: "float32"}, flag: ir.Unsafe, kind: KindFloat32, wire: ir.WireI32, tag: "0d"},
+		{name: "string", rec: ir.BytesRecord{String: true, Size: -1}, kind: KindBytes, wire: ir.WireLen, tag: "0a"},
+		{name: "bytes", rec: ir.BytesRecord{Size: 4}, kind: KindBytes, wire: ir.WireLen, tag: "0a"},
+		{name: "pointer", rec: ir.OptionalRecord{Elem: int64Rec}, kind: KindVarint, wire: ir.WireVarint, tag: "08"},
+		{
+			name: "packed", rec: ir.RepeatedRecord{Elem: int64Rec, Size: -1},
+			kind: KindVarint, wire: ir.WireLen, tag: "0a", packed: true,
+		},
+		{
+			name: "packed fixed64", rec: ir.RepeatedRecord{Elem: int64Rec, Size: 2}, flag: ir.BinFixed64,
+			kind: KindFixed64, wire: ir.WireLen, tag: "0a", packed: true,
+		},
+		{
+			name: "packed pointers", rec: ir.RepeatedRecord{Elem: ir.OptionalRecord{Elem: int64Rec}, Size: -1},
+			kind: KindVarint, wire: ir.WireLen, tag: "0a", packed: true,
+		},
+		{
+			name: "unpacked", rec: ir.RepeatedRecord{Elem: ir.BytesRecord{String: true, Size: -1}, Size: -1},
+			kind: KindBytes, wire: ir.WireLen, tag: "0a",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := Lower([]ir.StructRecord{{
+				Name:   "Msg",
+				Fields: []ir.StructField{{Name: "F", JSONName: "F", BinFieldNum: 1, Record: tc.rec, TagFlag: tc.flag}},
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			f := plan.Messages[0].Fields[0]
+			if f.Value.Kind != tc.kind {
+				t.Errorf("want kind %s, got %s", tc.kind, f.Value.Kind)
+			}
+			if f.WireType != tc.wire {
+				t.Errorf("want wire type %s, got %s", tc.wire, f.WireType)
+			}
+			if tag := hex.EncodeToString(f.Tag); tag != tc.tag {
+				t.Errorf("want tag %s, got %s", tc.tag, tag)
+			}
+			if f.Repeated != nil && f.Repeated.Packed != tc.packed {
+				t.Errorf("want packed = %v, got %v", tc.packed, f.Repeated.Packed)
+			}
+			if !f.OmitEmpty {
+				t.Errorf("want OmitEmpty")
+			}
+		})
+	}
+}
+
+func TestLowerField(t *testing.T) {
+	plan, err := Lower([]ir.StructRecord{{
+		Name: "Msg",
+		Fields: []ir.StructField{
+			{
+				Name: "Ptrs", JSONName: "Ptrs", BinFieldNum: 20, TagFlag: ir.WriteEmpty,
+				Record: ir.OptionalRecord{Elem: ir.RepeatedRecord{
+					Elem: ir.OptionalRecord{Elem: ir.BytesRecord{Size: -1}},
+					Size: 3,
+				}},
+			},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := plan.Messages[0].Fields[0]
+	switch {
+	case !f.Optional:
+		t.Error("want Optional")
+	case f.Repeated == nil:
+		t.Fatal("want Repeated")
+	case *f.Repeated != Repeated{Size: 3, ElemOptional: true}:
+		t.Errorf("unexpected Repeated: %+v", *f.Repeated)
+	case f.OmitEmpty:
+		t.Error("want OmitEmpty = false, with write_empty")
+	}
+	// field 20 needs a two-byte tag.
+	if tag := hex.EncodeToString(f.Tag); tag != "a201" {
+		t.Errorf("want tag a201, got %s", tag)
+	}
+	if f.Value.Record != (ir.BytesRecord{Size: -1}) {
+		t.Errorf("want the record of the elements, got %#v", f.Value.Record)
+	}
+}
+
+func TestLowerMessages(t *testing.T) {
+	coin := ir.StructRecord{
+		Name:   "Coin",
+		Source: "x.Coin",
+		Fields: []ir.StructField{{Name: "Denom", JSONName: "Denom", BinFieldNum: 1, Record: ir.BytesRecord{String: true, Size: -1}}},
+	}
+	otherCoin := coin
+	otherCoin.Source = "y.Coin"
+	anon := ir.StructRecord{
+		Fields: []ir.StructField{{Name: "A", JSONName: "A", BinFieldNum: 1, Record: ir.ScalarRecord{Name: "bool"}}},
+	}
+	tx := ir.StructRecord{
+		Name:   "Tx",
+		Source: "x.Tx",
+		Fields: []ir.StructField{
+			{Name: "Fee", JSONName: "Fee", BinFieldNum: 1, Record: coin},
+			{Name: "Coins", JSONName: "Coins", BinFieldNum: 2, Record: ir.RepeatedRecord{Elem: coin, Size: -1}},
+			{Name: "Other", JSONName: "Other", BinFieldNum: 3, Record: ir.OptionalRecord{Elem: otherCoin}},
+			{Name: "Anon", JSONName: "Anon", BinFieldNum: 4, Record: anon},
+		},
+	}
+	plan, err := Lower([]ir.StructRecord{tx, coin})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(msgs []*Message) string {
+		s := make([]string, len(msgs))
+		for i, m := range msgs {
+			s[i] = m.Name
+		}
+		return strings.Join(s, " ")
+	}
+	if got := names(plan.Messages); got != "Tx Coin" {
+		t.Errorf("Messages: want Tx Coin, got %s", got)
+	}
+	// dependencies come first; the second Coin is renamed, and the anonymous
+	// struct is named after the message and the field.
+	if got := names(plan.All); got != "Coin Coin2 TxAnon Tx" {
+		t.Errorf("All: want Coin Coin2 TxAnon Tx, got %s", got)
+	}
+
+	fields := plan.Messages[0].Fields
+	fee, coins := fields[0].Value.Message, fields[1].Value.Message
+	if fee != coins || fee != plan.Messages[1] {
+		t.Error("the messages of the same named struct should be shared")
+	}
+	if fields[2].Value.Message.Source != "y.Coin" {
+		t.Errorf("unexpected message for Other: %+v", fields[2].Value.Message)
+	}
+}
+
+func TestLowerWellKnown(t *testing.T) {
+	tm := ir.StructRecord{
+		Name:   "Time",
+		Source: "time.Time",
+		Fields: []ir.StructField{{Name: "Seconds", JSONName: "seconds", BinFieldNum: 1, Record: ir.ScalarRecord{Name: "uint64"}}},
+	}
+	notWellKnown := tm
+	notWellKnown.Source = "time.Month"
+	plan, err := Lower([]ir.StructRecord{{
+		Name: "Msg",
+		Fields: []ir.StructField{
+			{Name: "T", JSONName: "T", BinFieldNum: 1, Record: tm},
+			{Name: "M", JSONName: "M", BinFieldNum: 2, Record: notWellKnown},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := plan.Messages[0].Fields
+	if wk := fields[0].Value.Message.WellKnown; wk != WellKnownTime {
+		t.Errorf("want %q, got %q", WellKnownTime, wk)
+	}
+	if wk := fields[1].Value.Message.WellKnown; wk != WellKnownNone {
+		t.Errorf("want no well-known type, got %q", wk)
+	}
+}
+
+func TestLowerErrors(t *testing.T) {
+	tt := []struct {
+		name string
+		rec  ir.Record
+		want string
+	}{
+		{
+			name: "repeated of repeated",
+			rec: ir.RepeatedRecord{
+				Elem: ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int64"}, Size: -1},
+				Size: -1,
+			},
+			want: "Msg.F[]: repeated records of repeated records are not supported",
+		},
+		{
+			name: "repeated of optional repeated",
+			rec: ir.RepeatedRecord{
+				Elem: ir.OptionalRecord{Elem: ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int64"}, Size: -1}},
+				Size: 2,
+			},
+			want: "Msg.F[]: repeated records of repeated records are not supported",
+		},
+		{
+			name: "any without implementations",
+			rec:  ir.AnyRecord{Source: "x.Msg"},
+			want: "Msg.F: interface x.Msg is not implemented by any of the messages",
+		},
+		{
+			name: "any of another type",
+			rec:  ir.AnyRecord{Source: "x.Msg", Subset: []string{"*x.Send"}},
+			want: "Msg.F: type x.Send of interface x.Msg is not one of the messages",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Lower([]ir.StructRecord{{
+				Name:   "Msg",
+				Fields: []ir.StructField{{Name: "F", JSONName: "F", BinFieldNum: 1, Record: tc.rec}},
+			}})
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("want error %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestNeverWritten(t *testing.T) {
+	tt := []struct {
+		name string
+		rec  ir.Record
+		flag ir.TagFlag
+		want bool
+	}{
+		{name: "scalar", rec: ir.ScalarRecord{Name: "int64"}},
+		{name: "empty struct", rec: ir.StructRecord{}, want: true},
+		{name: "empty struct with write_empty", rec: ir.StructRecord{}, flag: ir.WriteEmpty},
+		{name: "empty struct with unknown fields", rec: ir.StructRecord{KeepUnknown: true}},
+		{name: "zero-length bytes", rec: ir.BytesRecord{Size: 0}, want: true},
+		{name: "slice", rec: ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int64"}, Size: -1}},
+		{name: "zero-length packed", rec: ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int64"}, Size: 0}, want: true},
+		{
+			name: "zero-length packed with write_empty",
+			rec:  ir.RepeatedRecord{Elem: ir.ScalarRecord{Name: "int64"}, Size: 0}, flag: ir.WriteEmpty,
+		},
+		{
+			name: "zero-length unpacked with write_empty",
+			rec:  ir.RepeatedRecord{Elem: ir.BytesRecord{Size: -1}, Size: 0}, flag: ir.WriteEmpty, want: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := Lower([]ir.StructRecord{{
+				Name:   "Msg",
+				Fields: []ir.StructField{{Name: "F", JSONName: "F", BinFieldNum: 1, Record: tc.rec, TagFlag: tc.flag}},
+			}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := plan.Messages[0].Fields[0].NeverWritten(); got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLowerKeepUnknown(t *testing.T) {
+	coin := ir.StructRecord{
+		Name:   "Coin",
+		Source: "x.Coin",
+		Fields: []ir.StructField{{Name: "Denom", JSONName: "Denom", BinFieldNum: 1, Record: ir.BytesRecord{String: true, Size: -1}}},
+	}
+	unknownCoin := coin
+	unknownCoin.KeepUnknown = true
+	plan, err := Lower([]ir.StructRecord{coin, unknownCoin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same struct is a different message when it keeps its unknown fields.
+	if a, b := plan.Messages[0], plan.Messages[1]; a == b || a.Record.KeepUnknown || !b.Record.KeepUnknown {
+		t.Errorf("want Coin and Coin2 with KeepUnknown, got %s and %s (KeepUnknown: %t)", a.Name, b.Name, b.Record.KeepUnknown)
+	}
+}
+
+func TestLowerInterfaces(t *testing.T) {
+	str := ir.BytesRecord{String: true, Size: -1}
+	send := ir.StructRecord{
+		Name:    "Send",
+		Source:  "x.Send",
+		TypeURL: "/x.Send",
+		Fields:  []ir.StructField{{Name: "To", JSONName: "to", BinFieldNum: 1, Record: str}},
+	}
+	call := ir.StructRecord{
+		Name:    "Call",
+		Source:  "x.Call",
+		TypeURL: "/vm.Call",
+		Fields:  []ir.StructField{{Name: "Func", JSONName: "func", BinFieldNum: 1, Record: str}},
+	}
+	msg := ir.AnyRecord{Source: "x.Msg", Subset: []string{"x.Send", "*x.Call"}}
+	tx := ir.StructRecord{
+		Name:    "Tx",
+		Source:  "x.Tx",
+		TypeURL: "/x.Tx",
+		Fields: []ir.StructField{
+			{Name: "Msgs", JSONName: "msgs", BinFieldNum: 1, Record: ir.RepeatedRecord{Elem: msg, Size: -1}},
+			{Name: "Last", JSONName: "last", BinFieldNum: 2, Record: msg},
+			{Name: "Memo", JSONName: "memo", BinFieldNum: 3, Record: ir.AnyRecord{Source: "any", Subset: []string{"x.Send"}}},
+		},
+	}
+	plan, err := Lower([]ir.StructRecord{tx, send, call})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := plan.Messages[0].Fields
+	msgs := fields[0]
+	if msgs.Value.Kind != KindAny || msgs.Repeated == nil || msgs.Repeated.Packed || hex.EncodeToString(msgs.Tag) != "0a" {
+		t.Errorf("Msgs: want unpacked any elements with tag 0a, got %+v", msgs)
+	}
+	// the same interface is lowered once.
+	if it := fields[1].Value.Interface; it != msgs.Value.Interface || len(plan.Interfaces) != 2 {
+		t.Fatalf("want the interfaces Msg and Any, got %d", len(plan.Interfaces))
+	}
+	it := plan.Interfaces[0]
+	if it.Name != "Msg" || plan.Interfaces[1].Name != "Any" {
+		t.Errorf("want the interfaces Msg and Any, got %s and %s", it.Name, plan.Interfaces[1].Name)
+	}
+	if len(it.Implementations) != 2 {
+		t.Fatalf("want 2 implementations, got %d", len(it.Implementations))
+	}
+	if impl := it.Implementations[0]; impl.Message != plan.Messages[1] || impl.Pointer || impl.TypeURL != "/x.Send" {
+		t.Errorf("want Send, got %s (pointer: %t, URL: %s)", impl.Name, impl.Pointer, impl.TypeURL)
+	}
+	if impl := it.Implementations[1]; impl.Message != plan.Messages[2] || !impl.Pointer || impl.TypeURL != "/vm.Call" {
+		t.Errorf("want *Call, got %s (pointer: %t, URL: %s)", impl.Name, impl.Pointer, impl.TypeURL)
+	}
+
+	// the concrete types are identified by their type URL.
+	call.TypeURL = send.TypeURL
+	_, err = Lower([]ir.StructRecord{tx, send, call})
+	const want = "Tx.Msgs[]: type x.Call of interface x.Msg has the same type URL as x.Send: /x.Send"
+	if err == nil || err.Error() != want {
+		t.Errorf("want error %q, got %v", want, err)
+	}
+}
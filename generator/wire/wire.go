@@ -0,0 +1,240 @@
+// Package wire lowers the IR into a wire plan: a description of how each
+// message is laid out in amino's binary encoding, independent of the target
+// language.
+//
+// The IR describes the types; the wire plan describes how to encode and decode
+// them. Targets should generate code from the plan rather than re-deriving
+// the amino semantics (wire types, packing, omission of empty values) from
+// the IR, so that they cannot drift apart.
+package wire
+
+import (
+	"fmt"
+
+	"github.com/thehowl/tomino/generator/ir"
+)
+
+// Plan is the wire plan for a set of messages.
+type Plan struct {
+	// Messages are the plans for the lowered records, in the order they
+	// were given to [Lower].
+	Messages []*Message
+	// All contains all messages in the plan, including the embedded ones, in
+	// dependency order: each message comes after the messages embedded in
+	// it. Named structs appearing multiple times share the same Message.
+	All []*Message
+	// Interfaces are the interfaces contained in the messages, in the order
+	// they are first found.
+	Interfaces []*Interface
+}
+
+// Message is the wire plan of a StructRecord.
+type Message struct {
+	// Name of the message, unique within the plan. For anonymous structs,
+	// it is derived from the name of the parent message and of the field.
+	Name string
+	// Source is the Go type the message was generated from, if any.
+	Source string
+	// Record is the original record.
+	Record ir.StructRecord
+	// Fields, in the order they are encoded.
+	Fields []*Field
+	// WellKnown is set for amino's well-known types.
+	WellKnown WellKnown
+	// TypeURL identifies the message when it is the value of an interface.
+	// It is only set for the messages in Plan.Messages.
+	TypeURL string
+}
+
+// Interface is the wire plan of an interface. Its values are encoded as a
+// google.protobuf.Any: a message containing the TypeURL of the concrete type
+// as field 1, and the encoded value as field 2, omitted if empty.
+type Interface struct {
+	// Name of the interface, unique among the interfaces of the plan.
+	Name string
+	// Source is the Go type of the interface.
+	Source string
+	// Record is the original record.
+	Record ir.AnyRecord
+	// Implementations are the messages the interface can hold, from
+	// Plan.Messages, each with a distinct TypeURL.
+	Implementations []Implementation
+}
+
+// Implementation is a message held by an interface.
+type Implementation struct {
+	*Message
+	// Pointer is set if the interface holds pointers to the message.
+	Pointer bool
+}
+
+// WellKnown identifies amino's "well-known" types, which have a specific
+// representation in the target languages.
+type WellKnown string
+
+const (
+	WellKnownNone     WellKnown = ""
+	WellKnownTime     WellKnown = "time.Time"
+	WellKnownDuration WellKnown = "time.Duration"
+)
+
+// Field is the wire plan of a struct field.
+//
+// A field is encoded as zero or more records, each starting with the bytes
+// in Tag. When Repeated is nil, the field is a single value, encoded using
+// Value. When Repeated is set, Value is the encoding of each element.
+//
+// A single value is omitted if it is empty, unless OmitEmpty is false.
+// A value is empty if it is:
+//
+//   - zero, for KindUvarint, KindVarint, KindFixed64 and KindFixed32;
+//   - false, for KindBool;
+//   - of length 0, for KindBytes (byte arrays with a size are never empty);
+//   - encoded in 0 bytes, for KindMessage;
+//   - nil, for KindAny.
+//
+// Floating points are never empty: amino writes them even when they are 0 (or
+// -0, or NaN), as their bits. See [Value.Omittable].
+//
+// Optional fields are additionally omitted when nil.
+//
+// Packed repeated fields are written as a single len record, containing all
+// the values with no tags, and are omitted if they have no elements and
+// OmitEmpty is true. Unpacked repeated fields are written as a record for each
+// element; elements are never omitted. Nil elements are written as a len
+// record of length 0.
+type Field struct {
+	ir.StructField
+	// Tag bytes preceding each record of the field.
+	Tag []byte
+	// WireType of each record of the field.
+	WireType ir.WireType
+	// Optional is set for pointer fields. Nil values are omitted.
+	Optional bool
+	// Repeated is set for slices and arrays.
+	Repeated *Repeated
+	// Value is the encoding of the field's value, or of each element of a
+	// repeated field.
+	Value Value
+	// OmitEmpty determines whether empty values are omitted.
+	// It is true unless the field has the `amino:"write_empty"` tag.
+	OmitEmpty bool
+}
+
+// NeverWritten returns true if the field will never be written on the wire,
+// as its value is always empty, and OmitEmpty is true. This is the case
+// for empty structs which don't keep their unknown fields, and zero-length
+// arrays (for unpacked repeated fields, regardless of OmitEmpty, as they are
+// written as a record per element).
+func (f *Field) NeverWritten() bool {
+	if f.Repeated != nil && f.Repeated.Size == 0 {
+		return f.OmitEmpty || !f.Repeated.Packed
+	}
+	if !f.OmitEmpty {
+		return false
+	}
+	switch {
+	case f.Repeated != nil:
+		return false
+	case f.Value.Kind == KindMessage:
+		return len(f.Value.Message.Fields) == 0 && !f.Value.Message.Record.KeepUnknown
+	case f.Value.Kind == KindBytes:
+		return f.Value.Size == 0
+	}
+	return false
+}
+
+// Repeated describes the encoding of a slice or array.
+type Repeated struct {
+	// Size of the array, or -1 for slices.
+	Size int64
+	// Packed is set if the elements are written together in a single len
+	// record.
+	Packed bool
+	// ElemOptional is set if the elements are pointers.
+	ElemOptional bool
+}
+
+// Value describes the encoding of a single value.
+type Value struct {
+	Kind Kind
+	// WireType of the value.
+	WireType ir.WireType
+	// Record is the IR record of the value.
+	Record ir.Record
+	// Scalar is the name of the scalar type of the value, for scalar kinds
+	// (ie. uint64, int16, bool).
+	Scalar string
+	// Size is the size of byte arrays, for KindBytes; -1 otherwise.
+	Size int64
+	// String is set for KindBytes if the value is a string.
+	//
+	// Like in amino, strings are written as their bytes, which are not
+	// required to be valid UTF-8. Targets whose string type can hold any
+	// bytes (Go, Gno, C and Zig) decode them unchanged. The others (Rust,
+	// TypeScript and Python) return a decoding error for invalid UTF-8, and
+	// an encoding error for strings which can't be encoded as UTF-8, like
+	// those with unpaired surrogates; they never replace them with U+FFFD.
+	String bool
+	// Message is the plan of the embedded message, for KindMessage.
+	Message *Message
+	// Interface is the plan of the interface, for KindAny.
+	Interface *Interface
+}
+
+// Omittable returns whether the value may be omitted when empty, ie. whether
+// it is not a floating point.
+func (v Value) Omittable() bool {
+	return v.Kind != KindFloat64 && v.Kind != KindFloat32
+}
+
+// LengthPrefixed returns whether the value is preceded by its length.
+func (v Value) LengthPrefixed() bool {
+	return v.WireType == ir.WireLen
+}
+
+// Kind is the kind of encoding used for a value.
+type Kind int
+
+const (
+	// Unsigned integers, as a varint.
+	KindUvarint Kind = iota
+	// Signed integers, as a zig-zag encoded varint.
+	KindVarint
+	// Booleans, as a varint of value 0 or 1.
+	KindBool
+	// 64-bit integers, in 8 bytes (little endian).
+	KindFixed64
+	// 32-bit integers, in 4 bytes (little endian).
+	KindFixed32
+	// float64, as the IEEE 754 bits in 8 bytes (little endian).
+	KindFloat64
+	// float32, as the IEEE 754 bits in 4 bytes (little endian).
+	KindFloat32
+	// Strings and bytes, prefixed by their length.
+	KindBytes
+	// Embedded messages, prefixed by their length.
+	KindMessage
+	// Interfaces, as an embedded google.protobuf.Any.
+	KindAny
+)
+
+var kindNames = [...]string{
+	KindUvarint: "uvarint",
+	KindVarint:  "varint",
+	KindBool:    "bool",
+	KindFixed64: "fixed64",
+	KindFixed32: "fixed32",
+	KindFloat64: "float64",
+	KindFloat32: "float32",
+	KindBytes:   "bytes",
+	KindMessage: "message",
+	KindAny:     "any",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
@@ -14,17 +14,32 @@ import (
 	tomtypes "github.com/thehowl/tomino/tests/golden"
 )
 
+// testPackage registers the types with amino, to encode them in Anys with the
+// type URLs used by tomino: "/tomtypes.TestType" and so on.
+var testPackage = amino.RegisterPackage(amino.NewPackage(
+	"github.com/thehowl/tomino/tests/golden",
+	"tomtypes",
+	amino.GetCallersDirname(),
+).WithTypes(
+	tomtypes.TestType{},
+	tomtypes.Cat{},
+	&tomtypes.Dog{}, // pointer-preferred, as only *Dog implements Animal
+	tomtypes.Fish{},
+))
+
 func ptrTo[T any](v T) *T {
 	return &v
 }
 
-func TestMarshalerCompatibility(t *testing.T) {
+// compatMessages returns the messages which are encoded both with amino and
+// tomino, and checked to produce the same output.
+func compatMessages() map[string]tomtypes.TestTypeMessage {
 	// deterministic, good random source
 	rnd := rand.New(rand.NewChaCha8(
 		sha256.Sum256([]byte("the quick brown fox jumps over the lazy dog.")),
 	))
 
-	tm := map[string]tomtypes.TestTypeMessage{
+	return map[string]tomtypes.TestTypeMessage{
 		"empty":     {},
 		"ptr_0":     {IntPtr: ptrTo(0)},
 		"ptr_123":   {IntPtr: ptrTo(123)},
@@ -47,6 +62,10 @@ func TestMarshalerCompatibility(t *testing.T) {
 		}{{1, 5}, {0, 4}, {1337, 0}}},
 		"fixed": {FixedUint: 0xdeadbeef},
 	}
+}
+
+func TestMarshalerCompatibility(t *testing.T) {
+	tm := compatMessages()
 
 	for _, name := range sortedMapKeys(tm) {
 		v := tm[name]
@@ -72,6 +91,215 @@ func TestMarshalerCompatibility(t *testing.T) {
 	}
 }
 
+func TestUnmarshalerCompatibility(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			aminoRes, err := amino.Marshal(v)
+			require.NoError(t, err)
+
+			var aminoDec, tominoDec tomtypes.TestTypeMessage
+			require.NoError(t, amino.Unmarshal(aminoRes, &aminoDec))
+			require.NoError(t, tominoDec.UnmarshalBinary(aminoRes))
+			assert.Equal(t, aminoDec, tominoDec)
+
+			// re-encoding should produce the same bytes.
+			tominoRes, err := tominoDec.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes))
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"truncated varint", "20", "unexpected end of input"},
+		{"truncated bytes", "2a0501", "unexpected end of input"},
+		{"array length", "320100", "invalid array length"},
+		{"wire type", "210000000000000000", "invalid wire type"},
+		{"uint8 overflow", "208002", "overflows"},
+		{"varint overflow", "20ffffffffffffffffff02", "overflows"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.TestTypeMessage
+			assert.ErrorContains(t, msg.UnmarshalBinary(b), tc.err)
+		})
+	}
+}
+
+func TestUnmarshalBoolErrors(t *testing.T) {
+	// like in amino, bools must be a single byte of value 0 or 1.
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"truncated", "30", "unexpected end of input"},
+		{"two", "3002", "invalid bool"},
+		{"non-minimal", "308100", "invalid bool"},
+		{"nested", "8201020802", "invalid bool"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.JSONTypeMessage
+			assert.ErrorContains(t, msg.UnmarshalBinary(b), tc.err)
+		})
+	}
+
+	var msg tomtypes.JSONTypeMessage
+	require.NoError(t, msg.UnmarshalBinary([]byte{0x30, 0x01}))
+	assert.True(t, msg.Bool)
+}
+
+func TestUnmarshalInvalidUTF8(t *testing.T) {
+	// amino keeps the bytes of strings, even if they are not valid UTF-8.
+	b := []byte{0x12, 0x01, 0xff}
+	var orig tomtypes.JSONType
+	require.NoError(t, amino.Unmarshal(b, &orig))
+	assert.Equal(t, "\xff", orig.Name)
+
+	var msg tomtypes.JSONTypeMessage
+	require.NoError(t, msg.UnmarshalBinary(b))
+	assert.Equal(t, "\xff", msg.Name)
+	enc, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	want, err := amino.Marshal(orig)
+	require.NoError(t, err)
+	assert.Equal(t, want, enc)
+}
+
+func TestSizedCompatibility(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			aminoRes, err := amino.MarshalSized(v)
+			require.NoError(t, err)
+
+			tominoRes, err := v.MarshalBinarySized()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes))
+
+			var aminoDec, tominoDec tomtypes.TestTypeMessage
+			require.NoError(t, amino.UnmarshalSized(aminoRes, &aminoDec))
+			require.NoError(t, tominoDec.UnmarshalBinarySized(aminoRes, 0))
+			assert.Equal(t, aminoDec, tominoDec)
+		})
+	}
+}
+
+func TestUnmarshalSizedErrors(t *testing.T) {
+	tt := []struct {
+		name    string
+		input   string
+		maxSize int
+		err     string
+	}{
+		{"empty", "", 0, "unexpected end of input"},
+		{"truncated", "0320", 0, "unexpected end of input"},
+		{"trailing data", "01200000", 0, "trailing data"},
+		{"too large", "022001", 1, "exceeds the maximum size"},
+		{"inner error", "0120", 0, "unexpected end of input"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.TestTypeMessage
+			assert.ErrorContains(t, msg.UnmarshalBinarySized(b, tc.maxSize), tc.err)
+		})
+	}
+
+	// the prefix may be exactly maxSize.
+	var msg tomtypes.TestTypeMessage
+	require.NoError(t, msg.UnmarshalBinarySized([]byte{0x02, 0x20, 0x01}, 2))
+	assert.Equal(t, uint8(1), msg.Byte)
+}
+
+func TestAnyCompatibility(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			orig := toOriginal[tomtypes.TestType](v)
+			aminoRes, err := amino.MarshalAny(orig)
+			require.NoError(t, err)
+			tominoRes, err := v.MarshalAny()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes))
+
+			var dec tomtypes.TestTypeMessage
+			require.NoError(t, dec.UnmarshalAny(aminoRes))
+			assert.Equal(t, v, dec)
+
+			aminoSized, err := amino.MarshalAnySized(orig)
+			require.NoError(t, err)
+			tominoSized, err := v.MarshalAnySized()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoSized), hex.EncodeToString(tominoSized))
+
+			dec = tomtypes.TestTypeMessage{}
+			require.NoError(t, dec.UnmarshalAnySized(aminoSized, 0))
+			assert.Equal(t, v, dec)
+		})
+	}
+}
+
+func TestAny(t *testing.T) {
+	// "/tomtypes.TestType", followed by the message.
+	const want = "0a12" + "2f746f6d74797065732e5465737454797065" + "12022001"
+	b, err := tomtypes.TestTypeMessage{Byte: 1}.MarshalAny()
+	require.NoError(t, err)
+	assert.Equal(t, want, hex.EncodeToString(b))
+
+	// the value is omitted for empty messages.
+	b, err = tomtypes.TestTypeMessage{}.AppendAnySized([]byte{0xff})
+	require.NoError(t, err)
+	assert.Equal(t, "ff"+"14"+"0a12"+"2f746f6d74797065732e5465737454797065", hex.EncodeToString(b))
+
+	var msg tomtypes.TestTypeMessage
+	require.NoError(t, msg.UnmarshalAny(b[2:]))
+	assert.Equal(t, tomtypes.TestTypeMessage{}, msg)
+
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"other type", "0a0d" + "2f746f6d74797065732e436174", `tomino: unknown type URL "/tomtypes.Cat" for github.com/thehowl/tomino/tests/golden.TestType`},
+		{"not an Any", "2001", "tomino: invalid google.protobuf.Any"},
+		{"trailing data", want + "00", "tomino: trailing data after the message"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.TestTypeMessage
+			assert.EqualError(t, msg.UnmarshalAny(b), tc.err)
+		})
+	}
+
+	// the prefix of UnmarshalAnySized is checked against maxSize.
+	b, err = hex.DecodeString("18" + want)
+	require.NoError(t, err)
+	assert.ErrorContains(t, msg.UnmarshalAnySized(b, 23), "exceeds the maximum size")
+	require.NoError(t, msg.UnmarshalAnySized(b, 24))
+	assert.Equal(t, uint8(1), msg.Byte)
+}
+
 func randBytes(r *rand.Rand, n int) []byte {
 	b := make([]byte, n)
 	p := r.Uint64()
@@ -94,13 +322,14 @@ func sortedMapKeys[K cmp.Ordered, V any, M ~map[K]V](m M) (k []K) {
 	return k
 }
 
-func BenchmarkMarshalers(b *testing.B) {
+// benchMessages returns the messages used in the benchmarks.
+func benchMessages() map[string]tomtypes.TestTypeMessage {
 	// deterministic, good random source
 	rnd := rand.New(rand.NewChaCha8(
 		sha256.Sum256([]byte("the quick brown fox jumps over the lazy dog.")),
 	))
 
-	tm := map[string]tomtypes.TestTypeMessage{
+	return map[string]tomtypes.TestTypeMessage{
 		"empty":     {},
 		"ptr_-1337": {IntPtr: ptrTo(-1337)},
 		"time_duration": func() tomtypes.TestTypeMessage {
@@ -119,6 +348,10 @@ func BenchmarkMarshalers(b *testing.B) {
 		}{{1, 5}, {0, 4}, {1337, 0}}},
 		"fixed": {FixedUint: 0xdeadbeef},
 	}
+}
+
+func BenchmarkMarshalers(b *testing.B) {
+	tm := benchMessages()
 
 	b.Run("tomino", func(b *testing.B) {
 		for _, name := range sortedMapKeys(tm) {
@@ -161,3 +394,49 @@ func BenchmarkMarshalers(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkUnmarshalers(b *testing.B) {
+	tm := benchMessages()
+	encoded := make(map[string][]byte, len(tm))
+	for name, v := range tm {
+		buf, err := v.MarshalBinary()
+		if err != nil {
+			b.Fatal(err)
+		}
+		encoded[name] = buf
+	}
+
+	b.Run("tomino", func(b *testing.B) {
+		for _, name := range sortedMapKeys(encoded) {
+			buf := encoded[name]
+			b.Run(name, func(b *testing.B) {
+				var dst tomtypes.TestTypeMessage
+				for i := 0; i < b.N; i++ {
+					_ = dst.UnmarshalBinary(buf)
+				}
+			})
+		}
+	})
+	b.Run("tomino_nocopy", func(b *testing.B) {
+		for _, name := range sortedMapKeys(encoded) {
+			buf := encoded[name]
+			b.Run(name, func(b *testing.B) {
+				var dst tomtypes.TestTypeMessage
+				for i := 0; i < b.N; i++ {
+					_ = dst.UnmarshalBinaryNoCopy(buf)
+				}
+			})
+		}
+	})
+	b.Run("amino", func(b *testing.B) {
+		for _, name := range sortedMapKeys(encoded) {
+			buf := encoded[name]
+			b.Run(name, func(b *testing.B) {
+				var dst tomtypes.TestTypeMessage
+				for i := 0; i < b.N; i++ {
+					_ = amino.Unmarshal(buf, &dst)
+				}
+			})
+		}
+	})
+}
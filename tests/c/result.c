@@ -0,0 +1,1316 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+
+#include "result.h"
+
+#include <string.h>
+
+// ---
+// encoding runtime
+
+#define TOMINO_TRY(expr) \
+do { \
+	tomino_error err_ = (expr); \
+	if (err_ != TOMINO_OK) { \
+		return err_; \
+	} \
+} while (0)
+
+// tomino_writer writes to buf until cap, while still counting the bytes
+// which don't fit.
+typedef struct {
+	uint8_t *buf;
+	size_t cap;
+	size_t pos;
+	bool invalid;
+} tomino_writer;
+
+static inline void tomino_put_raw(tomino_writer *w, const char *b, size_t n) {
+	if (w->pos <= w->cap && n <= w->cap - w->pos) {
+		memcpy(w->buf + w->pos, b, n);
+	}
+	w->pos += n;
+}
+
+static inline void tomino_put_byte(tomino_writer *w, uint8_t b) {
+	if (w->pos < w->cap) {
+		w->buf[w->pos] = b;
+	}
+	w->pos++;
+}
+
+static inline void tomino_put_uvarint(tomino_writer *w, uint64_t x) {
+	while (x > 0x7f) {
+		tomino_put_byte(w, (uint8_t)(x | 0x80));
+		x >>= 7;
+	}
+	tomino_put_byte(w, (uint8_t)x);
+}
+
+static inline uint64_t tomino_zigzag(int64_t x) {
+	return ((uint64_t)x << 1) ^ (uint64_t)(x >> 63);
+}
+
+static inline void tomino_put_fixed64(tomino_writer *w, uint64_t x) {
+	for (int i = 0; i < 8; i++) {
+		tomino_put_byte(w, (uint8_t)(x >> (8 * i)));
+	}
+}
+
+static inline void tomino_put_fixed32(tomino_writer *w, uint32_t x) {
+	for (int i = 0; i < 4; i++) {
+		tomino_put_byte(w, (uint8_t)(x >> (8 * i)));
+	}
+}
+
+static inline uint64_t tomino_double_bits(double x) {
+	uint64_t u;
+	memcpy(&u, &x, sizeof(u));
+	return u;
+}
+
+static inline uint32_t tomino_float_bits(float x) {
+	uint32_t u;
+	memcpy(&u, &x, sizeof(u));
+	return u;
+}
+
+// tomino_check_len returns n, marking the encoding as invalid if it is
+// greater than max, in which case it returns max.
+static inline size_t tomino_check_len(tomino_writer *w, size_t n, size_t max) {
+	if (n > max) {
+		w->invalid = true;
+		return max;
+	}
+	return n;
+}
+
+static inline void tomino_put_bytes(tomino_writer *w, const uint8_t *b, size_t n, size_t max) {
+	n = tomino_check_len(w, n, max);
+	tomino_put_uvarint(w, n);
+	tomino_put_raw(w, (const char *)b, n);
+}
+
+static inline size_t tomino_uvarint_size(uint64_t x) {
+	size_t n = 1;
+	for (; x > 0x7f; x >>= 7) {
+		n++;
+	}
+	return n;
+}
+
+// tomino_begin writes the tag of a length-prefixed value, and returns its
+// position, to be passed to tomino_end once the value has been written.
+static inline size_t tomino_begin(tomino_writer *w, const char *tag, size_t n) {
+	size_t start = w->pos;
+	tomino_put_raw(w, tag, n);
+	return start;
+}
+
+// tomino_end inserts the length of the value started with tomino_begin.
+// If the value is empty and omit_empty is set, the tag is removed instead.
+static inline void tomino_end(tomino_writer *w, size_t start, size_t tag_len, bool omit_empty) {
+	size_t value_start = start + tag_len;
+	size_t size = w->pos - value_start;
+	if (size == 0 && omit_empty) {
+		w->pos = start;
+		return;
+	}
+	size_t n = tomino_uvarint_size(size);
+	if (w->pos + n <= w->cap) {
+		memmove(w->buf + value_start + n, w->buf + value_start, size);
+		size_t pos = w->pos;
+		w->pos = value_start;
+		tomino_put_uvarint(w, size);
+		w->pos = pos;
+	}
+	// otherwise, the buffer is too small: only count the bytes.
+	w->pos += n;
+}
+
+typedef struct {
+	const uint8_t *buf;
+	size_t len;
+	size_t pos;
+} tomino_reader;
+
+static inline tomino_error tomino_read_uvarint(tomino_reader *r, uint64_t *x, uint64_t max) {
+	uint64_t v = 0;
+	for (int shift = 0; shift < 70; shift += 7) {
+		if (r->pos >= r->len) {
+			return TOMINO_ERR_EOF;
+		}
+		uint8_t b = r->buf[r->pos++];
+		if (shift == 63 && b > 1) {
+			return TOMINO_ERR_OVERFLOW;
+		}
+		v |= (uint64_t)(b & 0x7f) << shift;
+		if (b < 0x80) {
+			if (v > max) {
+				return TOMINO_ERR_OVERFLOW;
+			}
+			*x = v;
+			return TOMINO_OK;
+		}
+	}
+	return TOMINO_ERR_OVERFLOW;
+}
+
+static inline tomino_error tomino_read_varint(tomino_reader *r, int64_t *x, int64_t min, int64_t max) {
+	uint64_t u;
+	TOMINO_TRY(tomino_read_uvarint(r, &u, UINT64_MAX));
+	int64_t v = (int64_t)(u >> 1) ^ -(int64_t)(u & 1);
+	if (v < min || v > max) {
+		return TOMINO_ERR_OVERFLOW;
+	}
+	*x = v;
+	return TOMINO_OK;
+}
+
+// tomino_read_bool reads a single byte, which like in amino must be 0 or 1.
+static inline tomino_error tomino_read_bool(tomino_reader *r, bool *x) {
+	if (r->pos >= r->len) {
+		return TOMINO_ERR_EOF;
+	}
+	uint8_t b = r->buf[r->pos++];
+	if (b > 1) {
+		return TOMINO_ERR_BOOL;
+	}
+	*x = b == 1;
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_tag(tomino_reader *r, uint64_t *num, int *wt) {
+	uint64_t x;
+	TOMINO_TRY(tomino_read_uvarint(r, &x, UINT64_MAX));
+	*num = x >> 3;
+	*wt = (int)(x & 7);
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_expect(int wt, int want) {
+	return wt == want ? TOMINO_OK : TOMINO_ERR_WIRE_TYPE;
+}
+
+// tomino_read_len reads the length prefix of a value, and sets end to the
+// position where the value ends.
+static inline tomino_error tomino_read_len(tomino_reader *r, size_t *end) {
+	uint64_t n;
+	TOMINO_TRY(tomino_read_uvarint(r, &n, UINT64_MAX));
+	if (n > r->len - r->pos) {
+		return TOMINO_ERR_EOF;
+	}
+	*end = r->pos + (size_t)n;
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_skip(tomino_reader *r, int wt) {
+	size_t n;
+	switch (wt) {
+		case 0: {
+			uint64_t x;
+			return tomino_read_uvarint(r, &x, UINT64_MAX);
+		}
+		case 1:
+		n = 8;
+		break;
+		case 2:
+		TOMINO_TRY(tomino_read_len(r, &n));
+		r->pos = n;
+		return TOMINO_OK;
+		case 5:
+		n = 4;
+		break;
+		default:
+		return TOMINO_ERR_WIRE_TYPE;
+	}
+	if (n > r->len - r->pos) {
+		return TOMINO_ERR_EOF;
+	}
+	r->pos += n;
+	return TOMINO_OK;
+}
+
+// tomino_read_nil reports whether the next value is empty, consuming its
+// length prefix if so. Nil elements of repeated fields are encoded as empty
+// values.
+static inline bool tomino_read_nil(tomino_reader *r) {
+	if (r->pos < r->len && r->buf[r->pos] == 0) {
+		r->pos++;
+		return true;
+	}
+	return false;
+}
+
+static inline tomino_error tomino_read_fixed64(tomino_reader *r, uint64_t *x) {
+	if (r->len - r->pos < 8) {
+		return TOMINO_ERR_EOF;
+	}
+	uint64_t v = 0;
+	for (int i = 0; i < 8; i++) {
+		v |= (uint64_t)r->buf[r->pos++] << (8 * i);
+	}
+	*x = v;
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_fixed32(tomino_reader *r, uint32_t *x) {
+	if (r->len - r->pos < 4) {
+		return TOMINO_ERR_EOF;
+	}
+	uint32_t v = 0;
+	for (int i = 0; i < 4; i++) {
+		v |= (uint32_t)r->buf[r->pos++] << (8 * i);
+	}
+	*x = v;
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_double(tomino_reader *r, double *x) {
+	uint64_t u;
+	TOMINO_TRY(tomino_read_fixed64(r, &u));
+	memcpy(x, &u, sizeof(u));
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_float(tomino_reader *r, float *x) {
+	uint32_t u;
+	TOMINO_TRY(tomino_read_fixed32(r, &u));
+	memcpy(x, &u, sizeof(u));
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_bytes(tomino_reader *r, uint8_t *b, size_t *n, size_t max) {
+	size_t end;
+	TOMINO_TRY(tomino_read_len(r, &end));
+	if (end - r->pos > max) {
+		return TOMINO_ERR_TOO_LONG;
+	}
+	*n = end - r->pos;
+	if (*n > 0) {
+		memcpy(b, r->buf + r->pos, *n);
+	}
+	r->pos = end;
+	return TOMINO_OK;
+}
+
+// tomino_read_string reads a string into s, which has room for max bytes
+// and a NUL terminator.
+static inline tomino_error tomino_read_string(tomino_reader *r, char *s, size_t *n, size_t max) {
+	TOMINO_TRY(tomino_read_bytes(r, (uint8_t *)s, n, max));
+	s[*n] = '\0';
+	return TOMINO_OK;
+}
+
+static inline tomino_error tomino_read_byte_array(tomino_reader *r, uint8_t *b, size_t size) {
+	size_t n;
+	TOMINO_TRY(tomino_read_bytes(r, b, &n, size));
+	return n == size ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static tomino_error tomino_read_Userinfo(tomino_reader *r, size_t end, Userinfo *msg) {
+	(void)msg;
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_URL(tomino_writer *w, const URL *msg) {
+	// field number 1
+	if (msg->scheme.len != 0) {
+		tomino_put_raw(w, "\x0a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->scheme.data, msg->scheme.len, TOMINO_MAX_URL_SCHEME);
+	}
+	// field number 2
+	if (msg->opaque.len != 0) {
+		tomino_put_raw(w, "\x12", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->opaque.data, msg->opaque.len, TOMINO_MAX_URL_OPAQUE);
+	}
+	// field number 3
+	// (always empty, skip as there is no write_empty)
+	// field number 4
+	if (msg->host.len != 0) {
+		tomino_put_raw(w, "\x22", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->host.data, msg->host.len, TOMINO_MAX_URL_HOST);
+	}
+	// field number 5
+	if (msg->path.len != 0) {
+		tomino_put_raw(w, "\x2a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->path.data, msg->path.len, TOMINO_MAX_URL_PATH);
+	}
+	// field number 6
+	if (msg->raw_path.len != 0) {
+		tomino_put_raw(w, "\x32", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->raw_path.data, msg->raw_path.len, TOMINO_MAX_URL_RAW_PATH);
+	}
+	// field number 7
+	if (msg->omit_host != 0) {
+		tomino_put_raw(w, "\x38", 1);
+		tomino_put_uvarint(w, msg->omit_host ? 1 : 0);
+	}
+	// field number 8
+	if (msg->force_query != 0) {
+		tomino_put_raw(w, "\x40", 1);
+		tomino_put_uvarint(w, msg->force_query ? 1 : 0);
+	}
+	// field number 9
+	if (msg->raw_query.len != 0) {
+		tomino_put_raw(w, "\x4a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->raw_query.data, msg->raw_query.len, TOMINO_MAX_URL_RAW_QUERY);
+	}
+	// field number 10
+	if (msg->fragment.len != 0) {
+		tomino_put_raw(w, "\x52", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->fragment.data, msg->fragment.len, TOMINO_MAX_URL_FRAGMENT);
+	}
+	// field number 11
+	if (msg->raw_fragment.len != 0) {
+		tomino_put_raw(w, "\x5a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->raw_fragment.data, msg->raw_fragment.len, TOMINO_MAX_URL_RAW_FRAGMENT);
+	}
+}
+
+static tomino_error tomino_read_URL(tomino_reader *r, size_t end, URL *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->scheme.data, &msg->scheme.len, TOMINO_MAX_URL_SCHEME));
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->opaque.data, &msg->opaque.len, TOMINO_MAX_URL_OPAQUE));
+				break;
+			}
+			case 3: {
+				msg->has_user = true;
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_Userinfo(r, end, &msg->user));
+				}
+				break;
+			}
+			case 4: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->host.data, &msg->host.len, TOMINO_MAX_URL_HOST));
+				break;
+			}
+			case 5: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->path.data, &msg->path.len, TOMINO_MAX_URL_PATH));
+				break;
+			}
+			case 6: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->raw_path.data, &msg->raw_path.len, TOMINO_MAX_URL_RAW_PATH));
+				break;
+			}
+			case 7: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				TOMINO_TRY(tomino_read_bool(r, &msg->omit_host));
+				break;
+			}
+			case 8: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				TOMINO_TRY(tomino_read_bool(r, &msg->force_query));
+				break;
+			}
+			case 9: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->raw_query.data, &msg->raw_query.len, TOMINO_MAX_URL_RAW_QUERY));
+				break;
+			}
+			case 10: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->fragment.data, &msg->fragment.len, TOMINO_MAX_URL_FRAGMENT));
+				break;
+			}
+			case 11: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->raw_fragment.data, &msg->raw_fragment.len, TOMINO_MAX_URL_RAW_FRAGMENT));
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_Time(tomino_writer *w, const tomino_timestamp *msg) {
+	// field number 1
+	if (msg->seconds != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, (uint64_t)msg->seconds);
+	}
+	// field number 2
+	if (msg->nanoseconds != 0) {
+		tomino_put_raw(w, "\x10", 1);
+		tomino_put_uvarint(w, (uint32_t)msg->nanoseconds);
+	}
+}
+
+static tomino_error tomino_read_Time(tomino_reader *r, size_t end, tomino_timestamp *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT64_MAX));
+					msg->seconds = (uint64_t)u;
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT32_MAX));
+					msg->nanoseconds = (uint32_t)u;
+				}
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_Duration(tomino_writer *w, const tomino_timestamp *msg) {
+	// field number 1
+	if (msg->seconds != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, (uint64_t)msg->seconds);
+	}
+	// field number 2
+	if (msg->nanoseconds != 0) {
+		tomino_put_raw(w, "\x10", 1);
+		tomino_put_uvarint(w, (uint32_t)msg->nanoseconds);
+	}
+}
+
+static tomino_error tomino_read_Duration(tomino_reader *r, size_t end, tomino_timestamp *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT64_MAX));
+					msg->seconds = (uint64_t)u;
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT32_MAX));
+					msg->nanoseconds = (uint32_t)u;
+				}
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_TestTypeSlice(tomino_writer *w, const TestTypeSlice *msg) {
+	// field number 1
+	if (msg->a != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, tomino_zigzag(msg->a));
+	}
+	// field number 2
+	if (msg->b != 0) {
+		tomino_put_raw(w, "\x10", 1);
+		tomino_put_uvarint(w, tomino_zigzag(msg->b));
+	}
+}
+
+static tomino_error tomino_read_TestTypeSlice(tomino_reader *r, size_t end, TestTypeSlice *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT64_MIN, INT64_MAX));
+					msg->a = (int64_t)s;
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT64_MIN, INT64_MAX));
+					msg->b = (int64_t)s;
+				}
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_TestType(tomino_writer *w, const TestType *msg) {
+	// field number 1
+	{
+		size_t start = tomino_begin(w, "\x0a", 1);
+		tomino_write_Time(w, &msg->time);
+		tomino_end(w, start, 1, true);
+	}
+	// field number 2
+	{
+		size_t start = tomino_begin(w, "\x12", 1);
+		tomino_write_Duration(w, &msg->duration);
+		tomino_end(w, start, 1, true);
+	}
+	// field number 3
+	if (msg->fixed_uint != 0) {
+		tomino_put_raw(w, "\x19", 1);
+		tomino_put_fixed64(w, (uint64_t)msg->fixed_uint);
+	}
+	// field number 4
+	if (msg->byte != 0) {
+		tomino_put_raw(w, "\x20", 1);
+		tomino_put_uvarint(w, (uint8_t)msg->byte);
+	}
+	// field number 5
+	if (msg->bytes.len != 0) {
+		tomino_put_raw(w, "\x2a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->bytes.data, msg->bytes.len, TOMINO_MAX_TEST_TYPE_BYTES);
+	}
+	// field number 6
+	if (msg->has_byte_arr) {
+		tomino_put_raw(w, "\x32", 1);
+		tomino_put_bytes(w, msg->byte_arr, 4, 4);
+	}
+	// field number 7
+	// (always empty, skip as there is no write_empty)
+	// field number 8
+	if (msg->has_int_ptr) {
+		if (msg->int_ptr != 0) {
+			tomino_put_raw(w, "\x40", 1);
+			tomino_put_uvarint(w, tomino_zigzag(msg->int_ptr));
+		}
+	}
+	// field number 9
+	for (size_t i = 0, n = tomino_check_len(w, msg->slice.len, TOMINO_MAX_TEST_TYPE_SLICE); i < n; i++) {
+		size_t start = tomino_begin(w, "\x4a", 1);
+		tomino_write_TestTypeSlice(w, &msg->slice.data[i]);
+		tomino_end(w, start, 1, false);
+	}
+}
+
+static tomino_error tomino_read_TestType(tomino_reader *r, size_t end, TestType *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_Time(r, end, &msg->time));
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_Duration(r, end, &msg->duration));
+				}
+				break;
+			}
+			case 3: {
+				TOMINO_TRY(tomino_expect(wt, 1));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_fixed64(r, &u));
+					msg->fixed_uint = (uint64_t)u;
+				}
+				break;
+			}
+			case 4: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT8_MAX));
+					msg->byte = (uint8_t)u;
+				}
+				break;
+			}
+			case 5: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_bytes(r, msg->bytes.data, &msg->bytes.len, TOMINO_MAX_TEST_TYPE_BYTES));
+				break;
+			}
+			case 6: {
+				msg->has_byte_arr = true;
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_byte_array(r, msg->byte_arr, 4));
+				break;
+			}
+			case 7: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_byte_array(r, NULL, 0));
+				break;
+			}
+			case 8: {
+				msg->has_int_ptr = true;
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT64_MIN, INT64_MAX));
+					msg->int_ptr = (int64_t)s;
+				}
+				break;
+			}
+			case 9: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				if (msg->slice.len >= TOMINO_MAX_TEST_TYPE_SLICE) {
+					return TOMINO_ERR_TOO_LONG;
+				}
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_TestTypeSlice(r, end, &msg->slice.data[msg->slice.len]));
+				}
+				msg->slice.len++;
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_FloatType(tomino_writer *w, const FloatType *msg) {
+	// field number 1
+	tomino_put_raw(w, "\x09", 1);
+	tomino_put_fixed64(w, tomino_double_bits(msg->float_));
+	// field number 2
+	tomino_put_raw(w, "\x15", 1);
+	tomino_put_fixed32(w, tomino_float_bits(msg->float32));
+	// field number 3
+	if (msg->has_float_ptr) {
+		tomino_put_raw(w, "\x19", 1);
+		tomino_put_fixed64(w, tomino_double_bits(msg->float_ptr));
+	}
+	// field number 4
+	if (msg->floats.len != 0) {
+		size_t start = tomino_begin(w, "\x22", 1);
+		for (size_t i = 0, n = tomino_check_len(w, msg->floats.len, TOMINO_MAX_FLOAT_TYPE_FLOATS); i < n; i++) {
+			tomino_put_fixed32(w, tomino_float_bits(msg->floats.data[i]));
+		}
+		tomino_end(w, start, 1, false);
+	}
+	// field number 5
+	{
+		size_t start = tomino_begin(w, "\x2a", 1);
+		for (size_t i = 0, n = 2; i < n; i++) {
+			tomino_put_fixed64(w, tomino_double_bits(msg->float_arr[i]));
+		}
+		tomino_end(w, start, 1, false);
+	}
+	// field number 6
+	if (msg->float_ptrs.len != 0) {
+		size_t start = tomino_begin(w, "\x32", 1);
+		for (size_t i = 0, n = tomino_check_len(w, msg->float_ptrs.len, TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS); i < n; i++) {
+			tomino_put_fixed64(w, tomino_double_bits((msg->float_ptrs.present[i] ? msg->float_ptrs.data[i] : 0)));
+		}
+		tomino_end(w, start, 1, false);
+	}
+}
+
+static tomino_error tomino_read_FloatType(tomino_reader *r, size_t end, FloatType *msg) {
+	size_t i_5 = 0;
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 1));
+				TOMINO_TRY(tomino_read_double(r, &msg->float_));
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 5));
+				TOMINO_TRY(tomino_read_float(r, &msg->float32));
+				break;
+			}
+			case 3: {
+				msg->has_float_ptr = true;
+				TOMINO_TRY(tomino_expect(wt, 1));
+				TOMINO_TRY(tomino_read_double(r, &msg->float_ptr));
+				break;
+			}
+			case 4: {
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (msg->floats.len >= TOMINO_MAX_FLOAT_TYPE_FLOATS) {
+							return TOMINO_ERR_TOO_LONG;
+						}
+						TOMINO_TRY(tomino_read_float(r, &msg->floats.data[msg->floats.len]));
+						msg->floats.len++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 5));
+					if (msg->floats.len >= TOMINO_MAX_FLOAT_TYPE_FLOATS) {
+						return TOMINO_ERR_TOO_LONG;
+					}
+					TOMINO_TRY(tomino_read_float(r, &msg->floats.data[msg->floats.len]));
+					msg->floats.len++;
+				}
+				break;
+			}
+			case 5: {
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (i_5 >= 2) {
+							return TOMINO_ERR_LENGTH;
+						}
+						TOMINO_TRY(tomino_read_double(r, &msg->float_arr[i_5]));
+						i_5++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 1));
+					if (i_5 >= 2) {
+						return TOMINO_ERR_LENGTH;
+					}
+					TOMINO_TRY(tomino_read_double(r, &msg->float_arr[i_5]));
+					i_5++;
+				}
+				break;
+			}
+			case 6: {
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (msg->float_ptrs.len >= TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS) {
+							return TOMINO_ERR_TOO_LONG;
+						}
+						msg->float_ptrs.present[msg->float_ptrs.len] = true;
+						TOMINO_TRY(tomino_read_double(r, &msg->float_ptrs.data[msg->float_ptrs.len]));
+						msg->float_ptrs.len++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 1));
+					if (msg->float_ptrs.len >= TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS) {
+						return TOMINO_ERR_TOO_LONG;
+					}
+					msg->float_ptrs.present[msg->float_ptrs.len] = true;
+					TOMINO_TRY(tomino_read_double(r, &msg->float_ptrs.data[msg->float_ptrs.len]));
+					msg->float_ptrs.len++;
+				}
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_JSONTypeStruct(tomino_writer *w, const JSONTypeStruct *msg) {
+	// field number 1
+	if (msg->a != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, (uint32_t)msg->a);
+	}
+	// field number 2
+	if (msg->b.len != 0) {
+		tomino_put_raw(w, "\x12", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->b.data, msg->b.len, TOMINO_MAX_JSON_TYPE_STRUCT_B);
+	}
+}
+
+static tomino_error tomino_read_JSONTypeStruct(tomino_reader *r, size_t end, JSONTypeStruct *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT32_MAX));
+					msg->a = (uint32_t)u;
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->b.data, &msg->b.len, TOMINO_MAX_JSON_TYPE_STRUCT_B));
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_JSONTypeStructs(tomino_writer *w, const JSONTypeStructs *msg) {
+	// field number 1
+	if (msg->c != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, msg->c ? 1 : 0);
+	}
+}
+
+static tomino_error tomino_read_JSONTypeStructs(tomino_reader *r, size_t end, JSONTypeStructs *msg) {
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				TOMINO_TRY(tomino_read_bool(r, &msg->c));
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+static void tomino_write_JSONType(tomino_writer *w, const JSONType *msg) {
+	// field number 1
+	if (msg->int8 != 0) {
+		tomino_put_raw(w, "\x08", 1);
+		tomino_put_uvarint(w, tomino_zigzag(msg->int8));
+	}
+	// field number 2
+	if (msg->name.len != 0) {
+		tomino_put_raw(w, "\x12", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->name.data, msg->name.len, TOMINO_MAX_JSON_TYPE_NAME);
+	}
+	// field number 3
+	if (msg->int32 != 0) {
+		tomino_put_raw(w, "\x18", 1);
+		tomino_put_uvarint(w, tomino_zigzag(msg->int32));
+	}
+	// field number 4
+	if (msg->int64 != 0) {
+		tomino_put_raw(w, "\x20", 1);
+		tomino_put_uvarint(w, tomino_zigzag(msg->int64));
+	}
+	// field number 5
+	if (msg->uint != 0) {
+		tomino_put_raw(w, "\x28", 1);
+		tomino_put_uvarint(w, (uint64_t)msg->uint);
+	}
+	// field number 6
+	if (msg->bool_ != 0) {
+		tomino_put_raw(w, "\x30", 1);
+		tomino_put_uvarint(w, msg->bool_ ? 1 : 0);
+	}
+	// field number 7
+	if (msg->bytes.len != 0) {
+		tomino_put_raw(w, "\x3a", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->bytes.data, msg->bytes.len, TOMINO_MAX_JSON_TYPE_BYTES);
+	}
+	// field number 8
+	for (size_t i = 0, n = tomino_check_len(w, msg->strings.len, TOMINO_MAX_JSON_TYPE_STRINGS); i < n; i++) {
+		tomino_put_raw(w, "\x42", 1);
+		tomino_put_bytes(w, (const uint8_t *)msg->strings.data[i].data, msg->strings.data[i].len, TOMINO_MAX_JSON_TYPE_STRINGS_ELEM);
+	}
+	// field number 9
+	if (msg->has_string_ptr) {
+		if (msg->string_ptr.len != 0) {
+			tomino_put_raw(w, "\x4a", 1);
+			tomino_put_bytes(w, (const uint8_t *)msg->string_ptr.data, msg->string_ptr.len, TOMINO_MAX_JSON_TYPE_STRING_PTR);
+		}
+	}
+	// field number 10
+	if (msg->has_slice_ptr) {
+		if (msg->slice_ptr.len != 0) {
+			size_t start = tomino_begin(w, "\x52", 1);
+			for (size_t i = 0, n = tomino_check_len(w, msg->slice_ptr.len, TOMINO_MAX_JSON_TYPE_SLICE_PTR); i < n; i++) {
+				tomino_put_uvarint(w, tomino_zigzag(msg->slice_ptr.data[i]));
+			}
+			tomino_end(w, start, 1, false);
+		}
+	}
+	// field number 11
+	if (msg->ptrs.len != 0) {
+		size_t start = tomino_begin(w, "\x5a", 1);
+		for (size_t i = 0, n = tomino_check_len(w, msg->ptrs.len, TOMINO_MAX_JSON_TYPE_PTRS); i < n; i++) {
+			tomino_put_uvarint(w, tomino_zigzag((msg->ptrs.present[i] ? msg->ptrs.data[i] : 0)));
+		}
+		tomino_end(w, start, 1, false);
+	}
+	// field number 12
+	{
+		size_t start = tomino_begin(w, "\x62", 1);
+		for (size_t i = 0, n = 2; i < n; i++) {
+			tomino_put_uvarint(w, (uint16_t)msg->arr[i]);
+		}
+		tomino_end(w, start, 1, false);
+	}
+	// field number 13
+	{
+		size_t start = tomino_begin(w, "\x6a", 1);
+		tomino_write_Time(w, &msg->time);
+		tomino_end(w, start, 1, true);
+	}
+	// field number 14
+	{
+		size_t start = tomino_begin(w, "\x72", 1);
+		tomino_write_Duration(w, &msg->duration);
+		tomino_end(w, start, 1, true);
+	}
+	// field number 15
+	{
+		size_t start = tomino_begin(w, "\x7a", 1);
+		tomino_write_JSONTypeStruct(w, &msg->struct_);
+		tomino_end(w, start, 1, true);
+	}
+	// field number 16
+	for (size_t i = 0, n = 1; i < n; i++) {
+		size_t start = tomino_begin(w, "\x82\x01", 2);
+		tomino_write_JSONTypeStructs(w, &msg->structs[i]);
+		tomino_end(w, start, 2, false);
+	}
+}
+
+static tomino_error tomino_read_JSONType(tomino_reader *r, size_t end, JSONType *msg) {
+	size_t i_12 = 0;
+	size_t i_16 = 0;
+	while (r->pos < end) {
+		uint64_t num;
+		int wt;
+		TOMINO_TRY(tomino_read_tag(r, &num, &wt));
+		switch (num) {
+			case 1: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT8_MIN, INT8_MAX));
+					msg->int8 = (int8_t)s;
+				}
+				break;
+			}
+			case 2: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->name.data, &msg->name.len, TOMINO_MAX_JSON_TYPE_NAME));
+				break;
+			}
+			case 3: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT32_MIN, INT32_MAX));
+					msg->int32 = (int32_t)s;
+				}
+				break;
+			}
+			case 4: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					int64_t s;
+					TOMINO_TRY(tomino_read_varint(r, &s, INT64_MIN, INT64_MAX));
+					msg->int64 = (int64_t)s;
+				}
+				break;
+			}
+			case 5: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				{
+					uint64_t u;
+					TOMINO_TRY(tomino_read_uvarint(r, &u, UINT64_MAX));
+					msg->uint = (uint64_t)u;
+				}
+				break;
+			}
+			case 6: {
+				TOMINO_TRY(tomino_expect(wt, 0));
+				TOMINO_TRY(tomino_read_bool(r, &msg->bool_));
+				break;
+			}
+			case 7: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_bytes(r, msg->bytes.data, &msg->bytes.len, TOMINO_MAX_JSON_TYPE_BYTES));
+				break;
+			}
+			case 8: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				if (msg->strings.len >= TOMINO_MAX_JSON_TYPE_STRINGS) {
+					return TOMINO_ERR_TOO_LONG;
+				}
+				TOMINO_TRY(tomino_read_string(r, msg->strings.data[msg->strings.len].data, &msg->strings.data[msg->strings.len].len, TOMINO_MAX_JSON_TYPE_STRINGS_ELEM));
+				msg->strings.len++;
+				break;
+			}
+			case 9: {
+				msg->has_string_ptr = true;
+				TOMINO_TRY(tomino_expect(wt, 2));
+				TOMINO_TRY(tomino_read_string(r, msg->string_ptr.data, &msg->string_ptr.len, TOMINO_MAX_JSON_TYPE_STRING_PTR));
+				break;
+			}
+			case 10: {
+				msg->has_slice_ptr = true;
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (msg->slice_ptr.len >= TOMINO_MAX_JSON_TYPE_SLICE_PTR) {
+							return TOMINO_ERR_TOO_LONG;
+						}
+						{
+							int64_t s;
+							TOMINO_TRY(tomino_read_varint(r, &s, INT16_MIN, INT16_MAX));
+							msg->slice_ptr.data[msg->slice_ptr.len] = (int16_t)s;
+						}
+						msg->slice_ptr.len++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 0));
+					if (msg->slice_ptr.len >= TOMINO_MAX_JSON_TYPE_SLICE_PTR) {
+						return TOMINO_ERR_TOO_LONG;
+					}
+					{
+						int64_t s;
+						TOMINO_TRY(tomino_read_varint(r, &s, INT16_MIN, INT16_MAX));
+						msg->slice_ptr.data[msg->slice_ptr.len] = (int16_t)s;
+					}
+					msg->slice_ptr.len++;
+				}
+				break;
+			}
+			case 11: {
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (msg->ptrs.len >= TOMINO_MAX_JSON_TYPE_PTRS) {
+							return TOMINO_ERR_TOO_LONG;
+						}
+						msg->ptrs.present[msg->ptrs.len] = true;
+						{
+							int64_t s;
+							TOMINO_TRY(tomino_read_varint(r, &s, INT32_MIN, INT32_MAX));
+							msg->ptrs.data[msg->ptrs.len] = (int32_t)s;
+						}
+						msg->ptrs.len++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 0));
+					if (msg->ptrs.len >= TOMINO_MAX_JSON_TYPE_PTRS) {
+						return TOMINO_ERR_TOO_LONG;
+					}
+					msg->ptrs.present[msg->ptrs.len] = true;
+					{
+						int64_t s;
+						TOMINO_TRY(tomino_read_varint(r, &s, INT32_MIN, INT32_MAX));
+						msg->ptrs.data[msg->ptrs.len] = (int32_t)s;
+					}
+					msg->ptrs.len++;
+				}
+				break;
+			}
+			case 12: {
+				if (wt == 2) {
+					size_t stop;
+					TOMINO_TRY(tomino_read_len(r, &stop));
+					while (r->pos < stop) {
+						if (i_12 >= 2) {
+							return TOMINO_ERR_LENGTH;
+						}
+						{
+							uint64_t u;
+							TOMINO_TRY(tomino_read_uvarint(r, &u, UINT16_MAX));
+							msg->arr[i_12] = (uint16_t)u;
+						}
+						i_12++;
+					}
+					if (r->pos != stop) {
+						return TOMINO_ERR_LENGTH;
+					}
+				} else {
+					TOMINO_TRY(tomino_expect(wt, 0));
+					if (i_12 >= 2) {
+						return TOMINO_ERR_LENGTH;
+					}
+					{
+						uint64_t u;
+						TOMINO_TRY(tomino_read_uvarint(r, &u, UINT16_MAX));
+						msg->arr[i_12] = (uint16_t)u;
+					}
+					i_12++;
+				}
+				break;
+			}
+			case 13: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_Time(r, end, &msg->time));
+				}
+				break;
+			}
+			case 14: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_Duration(r, end, &msg->duration));
+				}
+				break;
+			}
+			case 15: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_JSONTypeStruct(r, end, &msg->struct_));
+				}
+				break;
+			}
+			case 16: {
+				TOMINO_TRY(tomino_expect(wt, 2));
+				if (i_16 >= 1) {
+					return TOMINO_ERR_LENGTH;
+				}
+				{
+					size_t end;
+					TOMINO_TRY(tomino_read_len(r, &end));
+					TOMINO_TRY(tomino_read_JSONTypeStructs(r, end, &msg->structs[i_16]));
+				}
+				i_16++;
+				break;
+			}
+			default: {
+				TOMINO_TRY(tomino_skip(r, wt));
+				break;
+			}
+		}
+	}
+	return r->pos == end ? TOMINO_OK : TOMINO_ERR_LENGTH;
+}
+
+size_t tomino_encode_URL(const URL *msg, uint8_t *buf, size_t cap) {
+	tomino_writer w = {buf, cap, 0, false};
+	tomino_write_URL(&w, msg);
+	return w.invalid ? SIZE_MAX : w.pos;
+}
+
+tomino_error tomino_decode_URL(URL *msg, const uint8_t *buf, size_t len) {
+	tomino_reader r = {buf, len, 0};
+	memset(msg, 0, sizeof(*msg));
+	return tomino_read_URL(&r, len, msg);
+}
+
+size_t tomino_encode_TestType(const TestType *msg, uint8_t *buf, size_t cap) {
+	tomino_writer w = {buf, cap, 0, false};
+	tomino_write_TestType(&w, msg);
+	return w.invalid ? SIZE_MAX : w.pos;
+}
+
+tomino_error tomino_decode_TestType(TestType *msg, const uint8_t *buf, size_t len) {
+	tomino_reader r = {buf, len, 0};
+	memset(msg, 0, sizeof(*msg));
+	return tomino_read_TestType(&r, len, msg);
+}
+
+size_t tomino_encode_FloatType(const FloatType *msg, uint8_t *buf, size_t cap) {
+	tomino_writer w = {buf, cap, 0, false};
+	tomino_write_FloatType(&w, msg);
+	return w.invalid ? SIZE_MAX : w.pos;
+}
+
+tomino_error tomino_decode_FloatType(FloatType *msg, const uint8_t *buf, size_t len) {
+	tomino_reader r = {buf, len, 0};
+	memset(msg, 0, sizeof(*msg));
+	return tomino_read_FloatType(&r, len, msg);
+}
+
+size_t tomino_encode_JSONType(const JSONType *msg, uint8_t *buf, size_t cap) {
+	tomino_writer w = {buf, cap, 0, false};
+	tomino_write_JSONType(&w, msg);
+	return w.invalid ? SIZE_MAX : w.pos;
+}
+
+tomino_error tomino_decode_JSONType(JSONType *msg, const uint8_t *buf, size_t len) {
+	tomino_reader r = {buf, len, 0};
+	memset(msg, 0, sizeof(*msg));
+	return tomino_read_JSONType(&r, len, msg);
+}
@@ -0,0 +1,310 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+
+#ifndef RESULT_H
+#define RESULT_H
+
+#include <stdbool.h>
+#include <stddef.h>
+#include <stdint.h>
+
+#ifndef TOMINO_COMMON_DEFINED
+#define TOMINO_COMMON_DEFINED
+
+// TOMINO_API gives C linkage to the encoders and decoders in C++.
+#ifdef __cplusplus
+#define TOMINO_API extern "C"
+#else
+#define TOMINO_API
+#endif
+
+// TOMINO_MAX_LEN is the default maximum length of strings, bytes and slices,
+// which are stored inline in the structs. The maximum length of each field
+// can be set with the TOMINO_MAX_* macros.
+// The macros must have the same values when compiling the source file and
+// all the files including this header.
+#ifndef TOMINO_MAX_LEN
+#define TOMINO_MAX_LEN 64
+#endif
+
+// tomino_error is the result of the decoders.
+typedef enum {
+	TOMINO_OK = 0,
+	// The input ends in the middle of a value.
+	TOMINO_ERR_EOF,
+	// A varint, or a decoded integer, overflows its type.
+	TOMINO_ERR_OVERFLOW,
+	// A value has an unexpected wire type.
+	TOMINO_ERR_WIRE_TYPE,
+	// A value overflows its length prefix, or an array has the wrong length.
+	TOMINO_ERR_LENGTH,
+	// A string, bytes or slice is longer than its maximum length.
+	TOMINO_ERR_TOO_LONG,
+	// A bool is encoded as a byte other than 0 or 1.
+	TOMINO_ERR_BOOL,
+} tomino_error;
+
+// tomino_timestamp is the representation of time.Time (since the Unix epoch)
+// and time.Duration.
+typedef struct {
+	int64_t seconds;
+	int32_t nanoseconds;
+} tomino_timestamp;
+
+#endif // TOMINO_COMMON_DEFINED
+
+// Userinfo is the tomino message for the type
+// net/url.Userinfo
+typedef struct {
+	// C doesn't allow empty structs.
+	char unused_;
+} Userinfo;
+
+#ifndef TOMINO_MAX_URL_SCHEME
+#define TOMINO_MAX_URL_SCHEME TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_OPAQUE
+#define TOMINO_MAX_URL_OPAQUE TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_HOST
+#define TOMINO_MAX_URL_HOST TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_PATH
+#define TOMINO_MAX_URL_PATH TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_RAW_PATH
+#define TOMINO_MAX_URL_RAW_PATH TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_RAW_QUERY
+#define TOMINO_MAX_URL_RAW_QUERY TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_FRAGMENT
+#define TOMINO_MAX_URL_FRAGMENT TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_URL_RAW_FRAGMENT
+#define TOMINO_MAX_URL_RAW_FRAGMENT TOMINO_MAX_LEN
+#endif
+// URL is the tomino message for the type
+// net/url.URL
+typedef struct {
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_SCHEME + 1];
+	} scheme;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_OPAQUE + 1];
+	} opaque;
+	bool has_user;
+	Userinfo user;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_HOST + 1];
+	} host;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_PATH + 1];
+	} path;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_RAW_PATH + 1];
+	} raw_path;
+	bool omit_host;
+	bool force_query;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_RAW_QUERY + 1];
+	} raw_query;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_FRAGMENT + 1];
+	} fragment;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_URL_RAW_FRAGMENT + 1];
+	} raw_fragment;
+} URL;
+
+typedef struct {
+	int64_t a;
+	int64_t b;
+} TestTypeSlice;
+
+#ifndef TOMINO_MAX_TEST_TYPE_BYTES
+#define TOMINO_MAX_TEST_TYPE_BYTES TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_TEST_TYPE_SLICE
+#define TOMINO_MAX_TEST_TYPE_SLICE TOMINO_MAX_LEN
+#endif
+// TestType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.TestType
+typedef struct {
+	tomino_timestamp time;
+	tomino_timestamp duration;
+	uint64_t fixed_uint;
+	uint8_t byte;
+	struct {
+		size_t len;
+		uint8_t data[TOMINO_MAX_TEST_TYPE_BYTES];
+	} bytes;
+	bool has_byte_arr;
+	uint8_t byte_arr[4];
+	/* zero_arr: zero-length array, not stored. */
+	bool has_int_ptr;
+	int64_t int_ptr;
+	struct {
+		size_t len;
+		TestTypeSlice data[TOMINO_MAX_TEST_TYPE_SLICE];
+	} slice;
+} TestType;
+
+#ifndef TOMINO_MAX_FLOAT_TYPE_FLOATS
+#define TOMINO_MAX_FLOAT_TYPE_FLOATS TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS
+#define TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS TOMINO_MAX_LEN
+#endif
+// FloatType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.FloatType
+typedef struct {
+	double float_;
+	float float32;
+	bool has_float_ptr;
+	double float_ptr;
+	struct {
+		size_t len;
+		float data[TOMINO_MAX_FLOAT_TYPE_FLOATS];
+	} floats;
+	double float_arr[2];
+	struct {
+		size_t len;
+		double data[TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS];
+		bool present[TOMINO_MAX_FLOAT_TYPE_FLOAT_PTRS];
+	} float_ptrs;
+} FloatType;
+
+#ifndef TOMINO_MAX_JSON_TYPE_STRUCT_B
+#define TOMINO_MAX_JSON_TYPE_STRUCT_B TOMINO_MAX_LEN
+#endif
+typedef struct {
+	uint32_t a;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_JSON_TYPE_STRUCT_B + 1];
+	} b;
+} JSONTypeStruct;
+
+typedef struct {
+	bool c;
+} JSONTypeStructs;
+
+#ifndef TOMINO_MAX_JSON_TYPE_NAME
+#define TOMINO_MAX_JSON_TYPE_NAME TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_BYTES
+#define TOMINO_MAX_JSON_TYPE_BYTES TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_STRINGS
+#define TOMINO_MAX_JSON_TYPE_STRINGS TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_STRINGS_ELEM
+#define TOMINO_MAX_JSON_TYPE_STRINGS_ELEM TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_STRING_PTR
+#define TOMINO_MAX_JSON_TYPE_STRING_PTR TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_SLICE_PTR
+#define TOMINO_MAX_JSON_TYPE_SLICE_PTR TOMINO_MAX_LEN
+#endif
+#ifndef TOMINO_MAX_JSON_TYPE_PTRS
+#define TOMINO_MAX_JSON_TYPE_PTRS TOMINO_MAX_LEN
+#endif
+// JSONType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.JSONType
+typedef struct {
+	int8_t int8;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_JSON_TYPE_NAME + 1];
+	} name;
+	int32_t int32;
+	int64_t int64;
+	uint64_t uint;
+	bool bool_;
+	struct {
+		size_t len;
+		uint8_t data[TOMINO_MAX_JSON_TYPE_BYTES];
+	} bytes;
+	struct {
+		size_t len;
+		struct {
+			size_t len;
+			char data[TOMINO_MAX_JSON_TYPE_STRINGS_ELEM + 1];
+		} data[TOMINO_MAX_JSON_TYPE_STRINGS];
+	} strings;
+	bool has_string_ptr;
+	struct {
+		size_t len;
+		char data[TOMINO_MAX_JSON_TYPE_STRING_PTR + 1];
+	} string_ptr;
+	bool has_slice_ptr;
+	struct {
+		size_t len;
+		int16_t data[TOMINO_MAX_JSON_TYPE_SLICE_PTR];
+	} slice_ptr;
+	struct {
+		size_t len;
+		int32_t data[TOMINO_MAX_JSON_TYPE_PTRS];
+		bool present[TOMINO_MAX_JSON_TYPE_PTRS];
+	} ptrs;
+	uint16_t arr[2];
+	tomino_timestamp time;
+	tomino_timestamp duration;
+	JSONTypeStruct struct_;
+	JSONTypeStructs structs[1];
+} JSONType;
+
+// tomino_encode_URL encodes msg in amino's binary encoding into buf,
+// which has a capacity of cap bytes.
+// It returns the length of the encoding; if it is greater than cap, buf is too
+// small, and its contents are unspecified. If a length in msg is greater than
+// its maximum, it returns SIZE_MAX.
+TOMINO_API size_t tomino_encode_URL(const URL *msg, uint8_t *buf, size_t cap);
+
+// tomino_decode_URL decodes buf, of length len, from amino's binary
+// encoding into msg.
+TOMINO_API tomino_error tomino_decode_URL(URL *msg, const uint8_t *buf, size_t len);
+
+// tomino_encode_TestType encodes msg in amino's binary encoding into buf,
+// which has a capacity of cap bytes.
+// It returns the length of the encoding; if it is greater than cap, buf is too
+// small, and its contents are unspecified. If a length in msg is greater than
+// its maximum, it returns SIZE_MAX.
+TOMINO_API size_t tomino_encode_TestType(const TestType *msg, uint8_t *buf, size_t cap);
+
+// tomino_decode_TestType decodes buf, of length len, from amino's binary
+// encoding into msg.
+TOMINO_API tomino_error tomino_decode_TestType(TestType *msg, const uint8_t *buf, size_t len);
+
+// tomino_encode_FloatType encodes msg in amino's binary encoding into buf,
+// which has a capacity of cap bytes.
+// It returns the length of the encoding; if it is greater than cap, buf is too
+// small, and its contents are unspecified. If a length in msg is greater than
+// its maximum, it returns SIZE_MAX.
+TOMINO_API size_t tomino_encode_FloatType(const FloatType *msg, uint8_t *buf, size_t cap);
+
+// tomino_decode_FloatType decodes buf, of length len, from amino's binary
+// encoding into msg.
+TOMINO_API tomino_error tomino_decode_FloatType(FloatType *msg, const uint8_t *buf, size_t len);
+
+// tomino_encode_JSONType encodes msg in amino's binary encoding into buf,
+// which has a capacity of cap bytes.
+// It returns the length of the encoding; if it is greater than cap, buf is too
+// small, and its contents are unspecified. If a length in msg is greater than
+// its maximum, it returns SIZE_MAX.
+TOMINO_API size_t tomino_encode_JSONType(const JSONType *msg, uint8_t *buf, size_t cap);
+
+// tomino_decode_JSONType decodes buf, of length len, from amino's binary
+// encoding into msg.
+TOMINO_API tomino_error tomino_decode_JSONType(JSONType *msg, const uint8_t *buf, size_t len);
+
+#endif // RESULT_H
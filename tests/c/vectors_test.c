@@ -0,0 +1,285 @@
+// Checks the C output in result.c against the test vectors in
+// golden/vectors.json and golden/invalid_vectors.json, which are verified
+// against amino by TestVectors and TestInvalidVectors.
+//
+// Run with (in the tests/c directory):
+//
+//	cc -std=c99 -Wall -Wextra -Werror -pedantic -DTOMINO_MAX_LEN=1024 -o vectors_test vectors_test.c result.c
+//	./vectors_test
+//
+// TOMINO_MAX_LEN is raised, as the vectors contain a bytes value of 1000
+// bytes.
+
+#include <math.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "result.h"
+
+#define MAX_VECTORS 64
+#define MAX_BINARY 4096
+
+typedef struct {
+	char name[64];
+	char type[64];
+	uint8_t binary[MAX_BINARY];
+	size_t len;
+} vector;
+
+static vector vectors[MAX_VECTORS];
+static size_t nvectors;
+static vector invalid_vectors[MAX_VECTORS];
+static size_t ninvalid_vectors;
+static int failures;
+
+#define CHECK(cond, ...) \
+	do { \
+		if (!(cond)) { \
+			fprintf(stderr, "%s:%d: check failed: %s: ", __FILE__, __LINE__, #cond); \
+			fprintf(stderr, __VA_ARGS__); \
+			fprintf(stderr, "\n"); \
+			failures++; \
+		} \
+	} while (0)
+
+// string_value returns the value of the JSON string member key in line,
+// writing it to dst, or returns 0 if line doesn't contain it.
+// vectors.json is generated by encoding/json with indentation, so each
+// member is on its own line, and the values never contain escapes.
+static int string_value(const char *line, const char *key, char *dst, size_t cap) {
+	char prefix[32];
+	snprintf(prefix, sizeof(prefix), "\"%s\": \"", key);
+	const char *start = strstr(line, prefix);
+	if (start == NULL) {
+		return 0;
+	}
+	start += strlen(prefix);
+	const char *end = strchr(start, '"');
+	if (end == NULL || (size_t)(end - start) >= cap) {
+		fprintf(stderr, "invalid %s in vectors.json\n", key);
+		exit(1);
+	}
+	memcpy(dst, start, (size_t)(end - start));
+	dst[end - start] = '\0';
+	return 1;
+}
+
+static size_t hex_decode(const char *s, uint8_t *dst) {
+	size_t n = strlen(s) / 2;
+	for (size_t i = 0; i < n; i++) {
+		unsigned int b;
+		sscanf(s + 2 * i, "%2x", &b);
+		dst[i] = (uint8_t)b;
+	}
+	return n;
+}
+
+// load_vectors loads the vectors in the file at path into dst, which has
+// MAX_VECTORS elements, and returns their number.
+static size_t load_vectors(const char *path, vector *dst) {
+	FILE *f = fopen(path, "r");
+	if (f == NULL) {
+		perror(path);
+		exit(1);
+	}
+	static char line[2 * MAX_BINARY + 64];
+	static char value[2 * MAX_BINARY + 1];
+	size_t n = 0;
+	while (fgets(line, sizeof(line), f) != NULL) {
+		if (n == MAX_VECTORS) {
+			fprintf(stderr, "too many vectors in %s\n", path);
+			exit(1);
+		}
+		if (string_value(line, "name", dst[n].name, sizeof(dst[0].name))) {
+			n++;
+		} else if (string_value(line, "type", dst[n - 1].type, sizeof(dst[0].type))) {
+			// stored in the vector.
+		} else if (string_value(line, "binary", value, sizeof(value))) {
+			vector *v = &dst[n - 1];
+			v->len = hex_decode(value, v->binary);
+		}
+	}
+	fclose(f);
+	return n;
+}
+
+static const vector *find_vector(const char *name) {
+	for (size_t i = 0; i < nvectors; i++) {
+		if (strcmp(vectors[i].name, name) == 0) {
+			return &vectors[i];
+		}
+	}
+	fprintf(stderr, "vector %s not found\n", name);
+	exit(1);
+}
+
+static void test_round_trip(void) {
+	static TestType msg;
+	static FloatType float_msg;
+	static uint8_t buf[MAX_BINARY];
+	for (size_t i = 0; i < nvectors; i++) {
+		const vector *v = &vectors[i];
+		// decoding and re-encoding the vector should produce the same bytes.
+		tomino_error err;
+		size_t n, size;
+		if (strcmp(v->type, "TestType") == 0) {
+			err = tomino_decode_TestType(&msg, v->binary, v->len);
+			n = tomino_encode_TestType(&msg, buf, sizeof(buf));
+			size = tomino_encode_TestType(&msg, NULL, 0);
+		} else if (strcmp(v->type, "FloatType") == 0) {
+			err = tomino_decode_FloatType(&float_msg, v->binary, v->len);
+			n = tomino_encode_FloatType(&float_msg, buf, sizeof(buf));
+			size = tomino_encode_FloatType(&float_msg, NULL, 0);
+		} else {
+			CHECK(0, "%s: unknown type %s", v->name, v->type);
+			continue;
+		}
+		CHECK(err == TOMINO_OK, "%s: error %d", v->name, err);
+		CHECK(n == v->len && memcmp(buf, v->binary, n) == 0, "%s: got %zu bytes", v->name, n);
+		// encoding without a buffer returns the size.
+		CHECK(size == v->len, "%s", v->name);
+	}
+}
+
+static void test_invalid_vectors(void) {
+	static TestType msg;
+	static JSONType json_msg;
+	for (size_t i = 0; i < ninvalid_vectors; i++) {
+		const vector *v = &invalid_vectors[i];
+		tomino_error err;
+		if (strcmp(v->type, "TestType") == 0) {
+			err = tomino_decode_TestType(&msg, v->binary, v->len);
+		} else if (strcmp(v->type, "JSONType") == 0) {
+			err = tomino_decode_JSONType(&json_msg, v->binary, v->len);
+		} else {
+			CHECK(0, "%s: unknown type %s", v->name, v->type);
+			continue;
+		}
+		CHECK(err != TOMINO_OK, "%s: decoded an invalid vector", v->name);
+	}
+}
+
+static void test_decoded_values(void) {
+	static TestType msg;
+	const vector *v = find_vector("bytes");
+	CHECK(tomino_decode_TestType(&msg, v->binary, v->len) == TOMINO_OK, "bytes");
+	CHECK(msg.bytes.len == 4 && memcmp(msg.bytes.data, "\x01\x02\x03\x04", 4) == 0, "bytes");
+
+	v = find_vector("ptr_-1337");
+	CHECK(tomino_decode_TestType(&msg, v->binary, v->len) == TOMINO_OK, "ptr_-1337");
+	CHECK(msg.has_int_ptr && msg.int_ptr == -1337, "%lld", (long long)msg.int_ptr);
+
+	v = find_vector("time_duration");
+	CHECK(tomino_decode_TestType(&msg, v->binary, v->len) == TOMINO_OK, "time_duration");
+	CHECK(msg.time.seconds == 900000 && msg.time.nanoseconds == 0, "time");
+	CHECK(msg.duration.seconds == -1337 && msg.duration.nanoseconds == 1, "duration");
+}
+
+static void test_invalid_utf8(void) {
+	// like amino, the bytes of strings are kept even if they are not UTF-8.
+	static JSONType msg;
+	static const uint8_t bin[] = {0x12, 0x01, 0xff};
+	uint8_t buf[64];
+	CHECK(tomino_decode_JSONType(&msg, bin, sizeof(bin)) == TOMINO_OK, "invalid UTF-8");
+	CHECK(msg.name.len == 1 && (uint8_t)msg.name.data[0] == 0xff, "invalid UTF-8");
+	// Name is the first field written.
+	CHECK(tomino_encode_JSONType(&msg, buf, sizeof(buf)) >= sizeof(bin) && memcmp(buf, bin, sizeof(bin)) == 0,
+		"invalid UTF-8 re-encoded");
+}
+
+static void test_floats(void) {
+	static FloatType msg;
+	const vector *v = find_vector("float_values");
+	CHECK(tomino_decode_FloatType(&msg, v->binary, v->len) == TOMINO_OK, "float_values");
+	CHECK(msg.float_ == 3.14159 && msg.float32 == -2.5f, "%g %g", msg.float_, (double)msg.float32);
+	CHECK(msg.has_float_ptr && msg.float_ptr == 1e300, "%g", msg.float_ptr);
+	CHECK(msg.floats.len == 3 && msg.floats.data[2] == -0.5f, "floats");
+	CHECK(isinf(msg.float_arr[0]) && msg.float_arr[0] > 0 && isinf(msg.float_arr[1]) && msg.float_arr[1] < 0, "float_arr");
+
+	v = find_vector("float_neg_zero");
+	CHECK(tomino_decode_FloatType(&msg, v->binary, v->len) == TOMINO_OK, "float_neg_zero");
+	CHECK(msg.float_ == 0 && signbit(msg.float_) && signbit(msg.float32), "negative zero");
+	v = find_vector("float_nan");
+	CHECK(tomino_decode_FloatType(&msg, v->binary, v->len) == TOMINO_OK, "float_nan");
+	CHECK(isnan(msg.float_) && isnan(msg.float32), "NaN");
+
+	// floats are written even when zero.
+	memset(&msg, 0, sizeof(msg));
+	v = find_vector("float_empty");
+	CHECK(tomino_encode_FloatType(&msg, NULL, 0) == v->len, "float_empty");
+}
+
+static void test_encode(void) {
+	static TestType msg;
+	memset(&msg, 0, sizeof(msg));
+	msg.time.seconds = 900000;
+	msg.has_byte_arr = true;
+	memcpy(msg.byte_arr, "\x01\x02\x03\x04", 4);
+	msg.has_int_ptr = true;
+	const uint8_t want[] = {0x0a, 0x04, 0x08, 0xa0, 0xf7, 0x36, 0x32, 0x04, 0x01, 0x02, 0x03, 0x04};
+
+	uint8_t buf[sizeof(want)];
+	CHECK(tomino_encode_TestType(&msg, buf, sizeof(buf)) == sizeof(want), "encoded size");
+	CHECK(memcmp(buf, want, sizeof(want)) == 0, "encoded bytes");
+	// a buffer which is too small still returns the full size.
+	CHECK(tomino_encode_TestType(&msg, buf, 5) == sizeof(want), "truncated size");
+
+	msg.bytes.len = TOMINO_MAX_TEST_TYPE_BYTES + 1;
+	CHECK(tomino_encode_TestType(&msg, buf, sizeof(buf)) == SIZE_MAX, "invalid length");
+}
+
+static void test_decode_errors(void) {
+	static const struct {
+		const char *input;
+		size_t len;
+		tomino_error want;
+	} cases[] = {
+		// truncated varint.
+		{"\x20", 1, TOMINO_ERR_EOF},
+		// ByteArr with a length other than 4.
+		{"\x32\x01\x00", 3, TOMINO_ERR_LENGTH},
+		// Byte with the fixed64 wire type.
+		{"\x21\x00\x00\x00\x00\x00\x00\x00\x00", 9, TOMINO_ERR_WIRE_TYPE},
+		// Byte overflowing 8 bits.
+		{"\x20\x80\x02", 3, TOMINO_ERR_OVERFLOW},
+		// length prefix longer than the input.
+		{"\x2a\x05\x01", 3, TOMINO_ERR_EOF},
+	};
+	static TestType msg;
+	for (size_t i = 0; i < sizeof(cases) / sizeof(cases[0]); i++) {
+		tomino_error err = tomino_decode_TestType(&msg, (const uint8_t *)cases[i].input, cases[i].len);
+		CHECK(err == cases[i].want, "case %zu: got %d, want %d", i, err, cases[i].want);
+	}
+
+	// more elements than the maximum length of Slice.
+	static uint8_t buf[2 * (TOMINO_MAX_TEST_TYPE_SLICE + 1)];
+	for (size_t i = 0; i < sizeof(buf); i += 2) {
+		buf[i] = 0x4a;
+		buf[i + 1] = 0x00;
+	}
+	CHECK(tomino_decode_TestType(&msg, buf, sizeof(buf)) == TOMINO_ERR_TOO_LONG, "slice too long");
+
+	// Bool as 2.
+	static JSONType json_msg;
+	CHECK(tomino_decode_JSONType(&json_msg, (const uint8_t *)"\x30\x02", 2) == TOMINO_ERR_BOOL, "invalid bool");
+}
+
+int main(void) {
+	nvectors = load_vectors("../golden/vectors.json", vectors);
+	ninvalid_vectors = load_vectors("../golden/invalid_vectors.json", invalid_vectors);
+	CHECK(nvectors > 0 && ninvalid_vectors > 0, "no vectors loaded");
+	test_round_trip();
+	test_invalid_vectors();
+	test_decoded_values();
+	test_invalid_utf8();
+	test_floats();
+	test_encode();
+	test_decode_errors();
+	if (failures > 0) {
+		fprintf(stderr, "FAIL: %d checks failed\n", failures);
+		return 1;
+	}
+	printf("PASS: %zu vectors\n", nvectors);
+	return 0;
+}
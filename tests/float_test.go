@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+	"github.com/thehowl/tomino/tests/golden/purego"
+)
+
+// NaNs with the "canonical" bits, which are preserved by all the targets.
+// Go's math.NaN has a non-zero payload, which is not guaranteed to survive
+// a round trip in JavaScript.
+var (
+	canonicalNaN64 = math.Float64frombits(0x7ff8_0000_0000_0000)
+	canonicalNaN32 = math.Float32frombits(0x7fc0_0000)
+)
+
+// floatCompatMessages returns the messages containing floating points which
+// are encoded both with amino and tomino, and checked to produce the same
+// output.
+func floatCompatMessages() map[string]tomtypes.FloatTypeMessage {
+	return map[string]tomtypes.FloatTypeMessage{
+		// floats are always written, even when zero.
+		"float_empty": {},
+		"float_ptr_0": {FloatPtr: ptrTo(0.0)},
+		"float_values": {
+			Float:     3.14159,
+			Float32:   -2.5,
+			FloatPtr:  ptrTo(1e300),
+			Floats:    []float32{1, 0, -0.5},
+			FloatArr:  [2]float64{math.Inf(1), math.Inf(-1)},
+			FloatPtrs: []*float64{ptrTo(1.5), ptrTo(0.0)},
+		},
+		"float_neg_zero": {
+			Float:     math.Copysign(0, -1),
+			Float32:   float32(math.Copysign(0, -1)),
+			FloatPtr:  ptrTo(math.Copysign(0, -1)),
+			Floats:    []float32{float32(math.Copysign(0, -1))},
+			FloatArr:  [2]float64{math.Copysign(0, -1), 0},
+			FloatPtrs: []*float64{ptrTo(math.Copysign(0, -1))},
+		},
+		"float_nan": {
+			Float:     canonicalNaN64,
+			Float32:   canonicalNaN32,
+			FloatPtr:  ptrTo(canonicalNaN64),
+			Floats:    []float32{canonicalNaN32},
+			FloatArr:  [2]float64{canonicalNaN64, 1},
+			FloatPtrs: []*float64{ptrTo(canonicalNaN64)},
+		},
+		"float_subnormal": {
+			Float:   math.SmallestNonzeroFloat64,
+			Float32: math.SmallestNonzeroFloat32,
+			Floats:  []float32{math.MaxFloat32, -math.SmallestNonzeroFloat32},
+		},
+	}
+}
+
+// TestFloatCompatibility checks that amino and tomino produce the same
+// encoding for floating points, and decode the same bits. The decoded
+// messages are compared by re-encoding them, as NaN != NaN.
+func TestFloatCompatibility(t *testing.T) {
+	tm := floatCompatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			aminoRes, err := amino.Marshal(v)
+			require.NoError(t, err)
+			tominoRes, err := v.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes))
+
+			var aminoDec, tominoDec tomtypes.FloatTypeMessage
+			require.NoError(t, amino.Unmarshal(aminoRes, &aminoDec))
+			require.NoError(t, tominoDec.UnmarshalBinary(aminoRes))
+
+			aminoReenc, err := aminoDec.MarshalBinary()
+			require.NoError(t, err)
+			tominoReenc, err := tominoDec.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(aminoReenc))
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoReenc))
+		})
+	}
+}
+
+// TestFloatBits checks that the bits of floating points are preserved when
+// encoding and decoding, including the sign of zero and the payload of NaNs,
+// both with and without -purego.
+func TestFloatBits(t *testing.T) {
+	const (
+		nan64 = 0x7ff8_0000_dead_beef
+		nan32 = 0xffc0_beef
+		neg0  = 0x8000_0000_0000_0000
+	)
+	v := tomtypes.FloatTypeMessage{
+		Float:     math.Float64frombits(nan64),
+		Float32:   math.Float32frombits(nan32),
+		FloatPtr:  ptrTo(math.Float64frombits(neg0)),
+		Floats:    []float32{math.Float32frombits(nan32)},
+		FloatArr:  [2]float64{math.Float64frombits(neg0), math.Float64frombits(nan64)},
+		FloatPtrs: []*float64{nil, ptrTo(math.Float64frombits(nan64))},
+	}
+	b, err := v.MarshalBinary()
+	require.NoError(t, err)
+	pb, err := purego.FloatTypeMessage(v).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(b), hex.EncodeToString(pb))
+
+	check := func(t *testing.T, got tomtypes.FloatTypeMessage) {
+		t.Helper()
+		assert.Equal(t, uint64(nan64), math.Float64bits(got.Float))
+		assert.Equal(t, uint32(nan32), math.Float32bits(got.Float32))
+		require.NotNil(t, got.FloatPtr)
+		assert.Equal(t, uint64(neg0), math.Float64bits(*got.FloatPtr))
+		require.Len(t, got.Floats, 1)
+		assert.Equal(t, uint32(nan32), math.Float32bits(got.Floats[0]))
+		assert.Equal(t, uint64(neg0), math.Float64bits(got.FloatArr[0]))
+		assert.Equal(t, uint64(nan64), math.Float64bits(got.FloatArr[1]))
+		require.Len(t, got.FloatPtrs, 2)
+		// nil elements are encoded as zero.
+		require.NotNil(t, got.FloatPtrs[0])
+		assert.Equal(t, uint64(0), math.Float64bits(*got.FloatPtrs[0]))
+		require.NotNil(t, got.FloatPtrs[1])
+		assert.Equal(t, uint64(nan64), math.Float64bits(*got.FloatPtrs[1]))
+	}
+
+	t.Run("default", func(t *testing.T) {
+		var dec tomtypes.FloatTypeMessage
+		require.NoError(t, dec.UnmarshalBinary(b))
+		check(t, dec)
+	})
+	t.Run("purego", func(t *testing.T) {
+		var dec purego.FloatTypeMessage
+		require.NoError(t, dec.UnmarshalBinary(b))
+		check(t, tomtypes.FloatTypeMessage(dec))
+	})
+}
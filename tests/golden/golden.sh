@@ -2,21 +2,79 @@
 # cd into script directory
 cd "$(dirname "$0")"
 
-go run github.com/thehowl/tomino/cmd/tomgen \
+go run github.com/thehowl/tomino/cmd/tomgen -registry \
     net/url.URL \
-    github.com/thehowl/tomino/tests/golden.TestType > result.go.1 || exit 1
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType \
+    github.com/thehowl/tomino/tests/golden.Cat \
+    github.com/thehowl/tomino/tests/golden.Dog \
+    github.com/thehowl/tomino/tests/golden.Fish \
+    github.com/thehowl/tomino/tests/golden.Zoo > result.go.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -purego -pkg purego \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType > purego/result.go.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -unknown -pkg unknown \
+    github.com/thehowl/tomino/tests/golden.OldTestType \
+    github.com/thehowl/tomino/tests/golden.OldSliceTestType > unknown/result.go.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target gno \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType \
+    github.com/thehowl/tomino/tests/golden.Cat \
+    github.com/thehowl/tomino/tests/golden.Dog \
+    github.com/thehowl/tomino/tests/golden.Fish \
+    github.com/thehowl/tomino/tests/golden.Zoo > result.gno.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target ts \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType > result.ts.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target python \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType > result.py.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target rust \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType > result.rs.1 || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target zig \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType > result.zig.1 || exit 1
+# the C target writes result.h next to result.c; they are in the tests/c
+# directory, as the go command refuses C files in a package without cgo.
+tmp="$(mktemp -d)" || exit 1
+go run github.com/thehowl/tomino/cmd/tomgen -target c -o "$tmp/result.c" \
+    net/url.URL \
+    github.com/thehowl/tomino/tests/golden.TestType \
+    github.com/thehowl/tomino/tests/golden.FloatType \
+    github.com/thehowl/tomino/tests/golden.JSONType || exit 1
+mv "$tmp/result.c" ../c/result.c.1 && mv "$tmp/result.h" ../c/result.h.1 && rmdir "$tmp" || exit 1
 
-diff --color -bsu result.go result.go.1
-sc="$?"
-if [ "$sc" != "0" ]; then
-    if [ "$1" = "fix" ]; then
-        mv result.go.1 result.go
-        printf '//go:build ignore\n\n// This exists to preview the formatted output.\n\n' > result.gofumpt.go
-        go run mvdan.cc/gofumpt result.go >> result.gofumpt.go
-    fi
-    exit $sc
-else
-    rm result.go.1
-    printf '//go:build ignore\n\n// This exists to preview the formatted output.\n\n' > result.gofumpt.go
-    go run mvdan.cc/gofumpt result.go >> result.gofumpt.go
+# the generated code should already be gofumpt-clean.
+unformatted="$(go run mvdan.cc/gofumpt -l result.go.1 purego/result.go.1 unknown/result.go.1)" || exit 1
+if [ -n "$unformatted" ]; then
+    echo "generated code is not gofumpt-formatted:"
+    go run mvdan.cc/gofumpt -d result.go.1 purego/result.go.1 unknown/result.go.1
+    exit 1
 fi
+
+sc=0
+for f in result.go purego/result.go unknown/result.go result.gno result.ts result.py result.rs result.zig ../c/result.h ../c/result.c; do
+    if diff --color -bsu "$f" "$f.1"; then
+        rm "$f.1"
+    else
+        sc=1
+        if [ "$1" = "fix" ]; then
+            mv "$f.1" "$f"
+        fi
+    fi
+done
+exit $sc
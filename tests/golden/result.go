@@ -2,23 +2,33 @@
 
 package tomtypes
 
-import "unsafe"
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
 
 // URLMessage is the tomino message for the type
 // net/url.URL
 type URLMessage struct {
-	Scheme string `json:"Scheme"`
-	Opaque string `json:"Opaque"`
-	User *struct {
-} `json:"User"`
-	Host string `json:"Host"`
-	Path string `json:"Path"`
-	RawPath string `json:"RawPath"`
-	OmitHost bool `json:"OmitHost"`
-	ForceQuery bool `json:"ForceQuery"`
-	RawQuery string `json:"RawQuery"`
-	Fragment string `json:"Fragment"`
-	RawFragment string `json:"RawFragment"`
+	Scheme      string    `json:"Scheme"`
+	Opaque      string    `json:"Opaque"`
+	User        *struct{} `json:"User"`
+	Host        string    `json:"Host"`
+	Path        string    `json:"Path"`
+	RawPath     string    `json:"RawPath"`
+	OmitHost    bool      `json:"OmitHost"`
+	ForceQuery  bool      `json:"ForceQuery"`
+	RawQuery    string    `json:"RawQuery"`
+	Fragment    string    `json:"Fragment"`
+	RawFragment string    `json:"RawFragment"`
 }
 
 // MarshalBinary encodes the data in the message using the generated tomino
@@ -33,211 +43,953 @@ func (msg URLMessage) MarshalBinary() ([]byte, error) {
 // AppendBinary encodes the data in the message using the generated tomino
 // marshaler, appending the encoded bytes to b and returning the result.
 func (msg URLMessage) AppendBinary(b []byte) ([]byte, error) {
-	 
 	// field number 1
 	switch {
 	case len(msg.Scheme) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Scheme) <= maxVarint1:
-		b = append(b, (1 << 3) | 2 /* 0x0a */, byte(len(msg.Scheme)))
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Scheme)))
 		b = append(b, msg.Scheme...)
 	case len(msg.Scheme) <= maxVarint2:
-		b = append(b, (1 << 3) | 2 /* 0x0a */, byte(len(msg.Scheme) | 0x80), byte(len(msg.Scheme) >> 7))
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Scheme)|0x80), byte(len(msg.Scheme)>>7))
 		b = append(b, msg.Scheme...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Scheme))
-		b = append(b, (1 << 3) | 2 /* 0x0a */)
+		b = growBytes(b, 1+10+len(msg.Scheme))
+		b = append(b, (1<<3)|2 /* 0x0a */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Scheme)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Scheme...)
-	} 
+	}
 	// field number 2
 	switch {
 	case len(msg.Opaque) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Opaque) <= maxVarint1:
-		b = append(b, (2 << 3) | 2 /* 0x12 */, byte(len(msg.Opaque)))
+		b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Opaque)))
 		b = append(b, msg.Opaque...)
 	case len(msg.Opaque) <= maxVarint2:
-		b = append(b, (2 << 3) | 2 /* 0x12 */, byte(len(msg.Opaque) | 0x80), byte(len(msg.Opaque) >> 7))
+		b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Opaque)|0x80), byte(len(msg.Opaque)>>7))
 		b = append(b, msg.Opaque...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Opaque))
-		b = append(b, (2 << 3) | 2 /* 0x12 */)
+		b = growBytes(b, 1+10+len(msg.Opaque))
+		b = append(b, (2<<3)|2 /* 0x12 */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Opaque)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Opaque...)
 	}
-	if msg.User != nil {
-		// use a new "msg" so we can encode the underlying field directly.
-		// with _ = msg, avoid "unused" warnings.
-		msg := struct { User struct {
-} }{ *msg.User }
-		_ = msg
-
-		
 	// field number 3
-	
-		
-			// (no fields, skip as there is no write_empty)
-		
-	
-	} 
+	// (always empty, skip as there is no write_empty)
 	// field number 4
 	switch {
 	case len(msg.Host) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Host) <= maxVarint1:
-		b = append(b, (4 << 3) | 2 /* 0x22 */, byte(len(msg.Host)))
+		b = append(b, (4<<3)|2 /* 0x22 */, byte(len(msg.Host)))
 		b = append(b, msg.Host...)
 	case len(msg.Host) <= maxVarint2:
-		b = append(b, (4 << 3) | 2 /* 0x22 */, byte(len(msg.Host) | 0x80), byte(len(msg.Host) >> 7))
+		b = append(b, (4<<3)|2 /* 0x22 */, byte(len(msg.Host)|0x80), byte(len(msg.Host)>>7))
 		b = append(b, msg.Host...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Host))
-		b = append(b, (4 << 3) | 2 /* 0x22 */)
+		b = growBytes(b, 1+10+len(msg.Host))
+		b = append(b, (4<<3)|2 /* 0x22 */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Host)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Host...)
-	} 
+	}
 	// field number 5
 	switch {
 	case len(msg.Path) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Path) <= maxVarint1:
-		b = append(b, (5 << 3) | 2 /* 0x2a */, byte(len(msg.Path)))
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Path)))
 		b = append(b, msg.Path...)
 	case len(msg.Path) <= maxVarint2:
-		b = append(b, (5 << 3) | 2 /* 0x2a */, byte(len(msg.Path) | 0x80), byte(len(msg.Path) >> 7))
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Path)|0x80), byte(len(msg.Path)>>7))
 		b = append(b, msg.Path...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Path))
-		b = append(b, (5 << 3) | 2 /* 0x2a */)
+		b = growBytes(b, 1+10+len(msg.Path))
+		b = append(b, (5<<3)|2 /* 0x2a */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Path)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Path...)
-	} 
+	}
 	// field number 6
 	switch {
 	case len(msg.RawPath) == 0:
-	
 		// nothing to write
-	
 	case len(msg.RawPath) <= maxVarint1:
-		b = append(b, (6 << 3) | 2 /* 0x32 */, byte(len(msg.RawPath)))
+		b = append(b, (6<<3)|2 /* 0x32 */, byte(len(msg.RawPath)))
 		b = append(b, msg.RawPath...)
 	case len(msg.RawPath) <= maxVarint2:
-		b = append(b, (6 << 3) | 2 /* 0x32 */, byte(len(msg.RawPath) | 0x80), byte(len(msg.RawPath) >> 7))
+		b = append(b, (6<<3)|2 /* 0x32 */, byte(len(msg.RawPath)|0x80), byte(len(msg.RawPath)>>7))
 		b = append(b, msg.RawPath...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.RawPath))
-		b = append(b, (6 << 3) | 2 /* 0x32 */)
+		b = growBytes(b, 1+10+len(msg.RawPath))
+		b = append(b, (6<<3)|2 /* 0x32 */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.RawPath)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.RawPath...)
 	}
-	 
-		if msg.OmitHost {
-			// field number 7
-			b = append(b, (7 << 3) | 0 /* 0x38 */, 1)
-		}
-	
-	 
-		if msg.ForceQuery {
-			// field number 8
-			b = append(b, (8 << 3) | 0 /* 0x40 */, 1)
-		}
-	 
+	if msg.OmitHost {
+		// field number 7
+		b = append(b, (7<<3)|0 /* 0x38 */, 1)
+	}
+	if msg.ForceQuery {
+		// field number 8
+		b = append(b, (8<<3)|0 /* 0x40 */, 1)
+	}
 	// field number 9
 	switch {
 	case len(msg.RawQuery) == 0:
-	
 		// nothing to write
-	
 	case len(msg.RawQuery) <= maxVarint1:
-		b = append(b, (9 << 3) | 2 /* 0x4a */, byte(len(msg.RawQuery)))
+		b = append(b, (9<<3)|2 /* 0x4a */, byte(len(msg.RawQuery)))
 		b = append(b, msg.RawQuery...)
 	case len(msg.RawQuery) <= maxVarint2:
-		b = append(b, (9 << 3) | 2 /* 0x4a */, byte(len(msg.RawQuery) | 0x80), byte(len(msg.RawQuery) >> 7))
+		b = append(b, (9<<3)|2 /* 0x4a */, byte(len(msg.RawQuery)|0x80), byte(len(msg.RawQuery)>>7))
 		b = append(b, msg.RawQuery...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.RawQuery))
-		b = append(b, (9 << 3) | 2 /* 0x4a */)
+		b = growBytes(b, 1+10+len(msg.RawQuery))
+		b = append(b, (9<<3)|2 /* 0x4a */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.RawQuery)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.RawQuery...)
-	} 
+	}
 	// field number 10
 	switch {
 	case len(msg.Fragment) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Fragment) <= maxVarint1:
-		b = append(b, (10 << 3) | 2 /* 0x52 */, byte(len(msg.Fragment)))
+		b = append(b, (10<<3)|2 /* 0x52 */, byte(len(msg.Fragment)))
 		b = append(b, msg.Fragment...)
 	case len(msg.Fragment) <= maxVarint2:
-		b = append(b, (10 << 3) | 2 /* 0x52 */, byte(len(msg.Fragment) | 0x80), byte(len(msg.Fragment) >> 7))
+		b = append(b, (10<<3)|2 /* 0x52 */, byte(len(msg.Fragment)|0x80), byte(len(msg.Fragment)>>7))
 		b = append(b, msg.Fragment...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Fragment))
-		b = append(b, (10 << 3) | 2 /* 0x52 */)
+		b = growBytes(b, 1+10+len(msg.Fragment))
+		b = append(b, (10<<3)|2 /* 0x52 */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Fragment)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Fragment...)
-	} 
+	}
 	// field number 11
 	switch {
 	case len(msg.RawFragment) == 0:
-	
 		// nothing to write
-	
 	case len(msg.RawFragment) <= maxVarint1:
-		b = append(b, (11 << 3) | 2 /* 0x5a */, byte(len(msg.RawFragment)))
+		b = append(b, (11<<3)|2 /* 0x5a */, byte(len(msg.RawFragment)))
 		b = append(b, msg.RawFragment...)
 	case len(msg.RawFragment) <= maxVarint2:
-		b = append(b, (11 << 3) | 2 /* 0x5a */, byte(len(msg.RawFragment) | 0x80), byte(len(msg.RawFragment) >> 7))
+		b = append(b, (11<<3)|2 /* 0x5a */, byte(len(msg.RawFragment)|0x80), byte(len(msg.RawFragment)>>7))
 		b = append(b, msg.RawFragment...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.RawFragment))
-		b = append(b, (11 << 3) | 2 /* 0x5a */)
+		b = growBytes(b, 1+10+len(msg.RawFragment))
+		b = append(b, (11<<3)|2 /* 0x5a */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.RawFragment)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.RawFragment...)
 	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *URLMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [URLMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *URLMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *URLMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = URLMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Scheme = aliasString(v)
+			} else {
+				msg.Scheme = string(v)
+			}
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Opaque = aliasString(v)
+			} else {
+				msg.Opaque = string(v)
+			}
+		case 3:
+			if msg.User == nil {
+				msg.User = new(struct{})
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			_, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		case 4:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Host = aliasString(v)
+			} else {
+				msg.Host = string(v)
+			}
+		case 5:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Path = aliasString(v)
+			} else {
+				msg.Path = string(v)
+			}
+		case 6:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawPath = aliasString(v)
+			} else {
+				msg.RawPath = string(v)
+			}
+		case 7:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.OmitHost = x
+		case 8:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.ForceQuery = x
+		case 9:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawQuery = aliasString(v)
+			} else {
+				msg.RawQuery = string(v)
+			}
+		case 10:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Fragment = aliasString(v)
+			} else {
+				msg.Fragment = string(v)
+			}
+		case 11:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawFragment = aliasString(v)
+			} else {
+				msg.RawFragment = string(v)
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [URLMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg URLMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg URLMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *URLMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [URLMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg URLMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *URLMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = URLMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *URLMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Scheme = aliasString(v)
+			} else {
+				msg.Scheme = string(v)
+			}
+		case 2:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Opaque = aliasString(v)
+			} else {
+				msg.Opaque = string(v)
+			}
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.User == nil {
+				msg.User = new(struct{})
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			_, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Host = aliasString(v)
+			} else {
+				msg.Host = string(v)
+			}
+		case 5:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Path = aliasString(v)
+			} else {
+				msg.Path = string(v)
+			}
+		case 6:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawPath = aliasString(v)
+			} else {
+				msg.RawPath = string(v)
+			}
+		case 7:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.OmitHost = x
+		case 8:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.ForceQuery = x
+		case 9:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawQuery = aliasString(v)
+			} else {
+				msg.RawQuery = string(v)
+			}
+		case 10:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Fragment = aliasString(v)
+			} else {
+				msg.Fragment = string(v)
+			}
+		case 11:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.RawFragment = aliasString(v)
+			} else {
+				msg.RawFragment = string(v)
+			}
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /url.URL, like amino.MarshalAny.
+func (msg URLMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg URLMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/url.URL", msg)
+}
+
+// MarshalAnySized encodes the message like [URLMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg URLMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg URLMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /url.URL.
+// The message is reset before decoding.
+func (msg *URLMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/url.URL" {
+		return unknownTypeError(url, "net/url.URL")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *URLMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [URLMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg URLMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg URLMessage) AppendJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, `"Scheme":`...)
+	b = appendJSONString(b, msg.Scheme, false)
+	b = append(b, `,"Opaque":`...)
+	b = appendJSONString(b, msg.Opaque, false)
+	b = append(b, `,"User":`...)
+	if msg.User == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, "{}"...)
+	}
+	b = append(b, `,"Host":`...)
+	b = appendJSONString(b, msg.Host, false)
+	b = append(b, `,"Path":`...)
+	b = appendJSONString(b, msg.Path, false)
+	b = append(b, `,"RawPath":`...)
+	b = appendJSONString(b, msg.RawPath, false)
+	b = append(b, `,"OmitHost":`...)
+	b = strconv.AppendBool(b, msg.OmitHost)
+	b = append(b, `,"ForceQuery":`...)
+	b = strconv.AppendBool(b, msg.ForceQuery)
+	b = append(b, `,"RawQuery":`...)
+	b = appendJSONString(b, msg.RawQuery, false)
+	b = append(b, `,"Fragment":`...)
+	b = appendJSONString(b, msg.Fragment, false)
+	b = append(b, `,"RawFragment":`...)
+	b = appendJSONString(b, msg.RawFragment, false)
+	b = append(b, '}')
+	return b, nil
+}
 
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [URLMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg URLMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg URLMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, `"ForceQuery":`...)
+	b = strconv.AppendBool(b, msg.ForceQuery)
+	b = append(b, `,"Fragment":`...)
+	b = appendJSONString(b, msg.Fragment, true)
+	b = append(b, `,"Host":`...)
+	b = appendJSONString(b, msg.Host, true)
+	b = append(b, `,"OmitHost":`...)
+	b = strconv.AppendBool(b, msg.OmitHost)
+	b = append(b, `,"Opaque":`...)
+	b = appendJSONString(b, msg.Opaque, true)
+	b = append(b, `,"Path":`...)
+	b = appendJSONString(b, msg.Path, true)
+	b = append(b, `,"RawFragment":`...)
+	b = appendJSONString(b, msg.RawFragment, true)
+	b = append(b, `,"RawPath":`...)
+	b = appendJSONString(b, msg.RawPath, true)
+	b = append(b, `,"RawQuery":`...)
+	b = appendJSONString(b, msg.RawQuery, true)
+	b = append(b, `,"Scheme":`...)
+	b = appendJSONString(b, msg.Scheme, true)
+	b = append(b, `,"User":`...)
+	if msg.User == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, "{}"...)
+	}
+	b = append(b, '}')
 	return b, nil
 }
 
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *URLMessage) UnmarshalJSON(b []byte) error {
+	*msg = URLMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Scheme":
+				msg.Scheme = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Scheme = string(v)
+				}
+			case "Opaque":
+				msg.Opaque = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Opaque = string(v)
+				}
+			case "User":
+				msg.User = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.User = new(struct{})
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "{}", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						key, n, err := consumeJSONKey(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						switch string(key) {
+						default:
+							n, err := skipJSONValue(b, 0)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+						}
+					}
+				}
+			case "Host":
+				msg.Host = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Host = string(v)
+				}
+			case "Path":
+				msg.Path = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Path = string(v)
+				}
+			case "RawPath":
+				msg.RawPath = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.RawPath = string(v)
+				}
+			case "OmitHost":
+				msg.OmitHost = false
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONBool(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.OmitHost = x
+				}
+			case "ForceQuery":
+				msg.ForceQuery = false
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONBool(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.ForceQuery = x
+				}
+			case "RawQuery":
+				msg.RawQuery = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.RawQuery = string(v)
+				}
+			case "Fragment":
+				msg.Fragment = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Fragment = string(v)
+				}
+			case "RawFragment":
+				msg.RawFragment = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.RawFragment = string(v)
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /url.URL, in the "@type" member, like amino.MarshalJSONAny.
+func (msg URLMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
 // TestTypeMessage is the tomino message for the type
 // github.com/thehowl/tomino/tests/golden.TestType
 type TestTypeMessage struct {
 	Time struct {
-	Seconds uint64 `json:"seconds"`
-	Nanoseconds uint32 `json:"nanoseconds"`
-} `json:"Time"`
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Time"`
 	Duration struct {
-	Seconds uint64 `json:"seconds"`
-	Nanoseconds uint32 `json:"nanoseconds"`
-} `json:"Duration"`
-	FixedUint uint64 `json:"FixedUint" binary:"fixed64"`
-	Byte uint8 `json:"Byte"`
-	Bytes []byte `json:"Bytes"`
-	ByteArr *[4]byte `json:"ByteArr"`
-	ZeroArr [0]byte `json:"ZeroArr"`
-	IntPtr *int `json:"IntPtr"`
-	Slice []struct {
-	A int `json:"A"`
-	B int `json:"B"`
-} `json:"Slice"`
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Duration"`
+	FixedUint uint64   `json:"FixedUint" binary:"fixed64"`
+	Byte      uint8    `json:"Byte"`
+	Bytes     []byte   `json:"Bytes"`
+	ByteArr   *[4]byte `json:"ByteArr"`
+	ZeroArr   [0]byte  `json:"ZeroArr"`
+	IntPtr    *int     `json:"IntPtr"`
+	Slice     []struct {
+		A int `json:"A"`
+		B int `json:"B"`
+	} `json:"Slice"`
 }
 
 // MarshalBinary encodes the data in the message using the generated tomino
@@ -252,332 +1004,8318 @@ func (msg TestTypeMessage) MarshalBinary() ([]byte, error) {
 // AppendBinary encodes the data in the message using the generated tomino
 // marshaler, appending the encoded bytes to b and returning the result.
 func (msg TestTypeMessage) AppendBinary(b []byte) ([]byte, error) {
-	
 	// field number 1
-	
-		{
-			startLen := len(b)
-			msg := msg.Time
-			
-	
-		if msg.Seconds != 0 {
-		// field number 1
-		b = append(b, (1 << 3) | 0 /* 0x08 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Seconds))]
+	{
+		startLen := len(b)
+		if msg.Time.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Seconds))]
 		}
-	
-	
-		if msg.Nanoseconds != 0 {
-		// field number 2
-		b = append(b, (2 << 3) | 0 /* 0x10 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Nanoseconds))]
+		if msg.Time.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Nanoseconds))]
 		}
-	
-
-			encodedSize := uint64(len(b) - startLen)
-
-			switch {
-			case encodedSize == 0:
-			
-				// empty -- nothing to do.
-			
-			case encodedSize <= maxVarint1:
-				const shift = 1 + 1
-				b = growBytes(b, shift)[:len(b)+shift]
-				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (1 << 3) | 2 /* 0x0a */, byte(encodedSize))
-			default:
-				shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
-				b = growBytes(b, shift)[:len(b)+shift]
-				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (1 << 3) | 2 /* 0x0a */)
-				putUvarint(b[startLen+1:startLen+shift], encodedSize)
-			}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
 		}
-	
+	}
 	// field number 2
-	
-		{
-			startLen := len(b)
-			msg := msg.Duration
-			
-	
-		if msg.Seconds != 0 {
-		// field number 1
-		b = append(b, (1 << 3) | 0 /* 0x08 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Seconds))]
+	{
+		startLen := len(b)
+		if msg.Duration.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Seconds))]
 		}
-	
-	
-		if msg.Nanoseconds != 0 {
-		// field number 2
-		b = append(b, (2 << 3) | 0 /* 0x10 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Nanoseconds))]
+		if msg.Duration.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Nanoseconds))]
 		}
-	
-
-			encodedSize := uint64(len(b) - startLen)
-
-			switch {
-			case encodedSize == 0:
-			
-				// empty -- nothing to do.
-			
-			case encodedSize <= maxVarint1:
-				const shift = 1 + 1
-				b = growBytes(b, shift)[:len(b)+shift]
-				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (2 << 3) | 2 /* 0x12 */, byte(encodedSize))
-			default:
-				shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
-				b = growBytes(b, shift)[:len(b)+shift]
-				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (2 << 3) | 2 /* 0x12 */)
-				putUvarint(b[startLen+1:startLen+shift], encodedSize)
-			}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
 		}
-	
-	
-		{
-			u64 := *(*uint64)(unsafe.Pointer(&msg.FixedUint)) 
-			if u64 != 0 {
+	}
+	{
+		u64 := uint64(msg.FixedUint)
+		if u64 != 0 {
 			// field number 3
-			b = append(b, (3 << 3) | 1 /* 0x19 */)
+			b = append(b, (3<<3)|1 /* 0x19 */)
 			b = growBytes(b, 8)[:len(b)+8]
 			putUint64(b[len(b)-8:], u64)
-			 } 
 		}
-	
-	
-		if msg.Byte != 0 {
+	}
+	if msg.Byte != 0 {
 		// field number 4
-		b = append(b, (4 << 3) | 0 /* 0x20 */)
+		b = append(b, (4<<3)|0 /* 0x20 */)
 		b = growBytes(b, 10)
 		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Byte))]
-		}
-	 
+	}
 	// field number 5
 	switch {
 	case len(msg.Bytes) == 0:
-	
 		// nothing to write
-	
 	case len(msg.Bytes) <= maxVarint1:
-		b = append(b, (5 << 3) | 2 /* 0x2a */, byte(len(msg.Bytes)))
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Bytes)))
 		b = append(b, msg.Bytes...)
 	case len(msg.Bytes) <= maxVarint2:
-		b = append(b, (5 << 3) | 2 /* 0x2a */, byte(len(msg.Bytes) | 0x80), byte(len(msg.Bytes) >> 7))
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Bytes)|0x80), byte(len(msg.Bytes)>>7))
 		b = append(b, msg.Bytes...)
 	default:
-		b = growBytes(b, 1 + 10 + len(msg.Bytes))
-		b = append(b, (5 << 3) | 2 /* 0x2a */)
+		b = growBytes(b, 1+10+len(msg.Bytes))
+		b = append(b, (5<<3)|2 /* 0x2a */)
 		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Bytes)))
 		b = b[:len(b)+uvlen]
 		b = append(b, msg.Bytes...)
 	}
 	if msg.ByteArr != nil {
-		// use a new "msg" so we can encode the underlying field directly.
-		// with _ = msg, avoid "unused" warnings.
-		msg := struct { ByteArr [4]byte }{ *msg.ByteArr }
-		_ = msg
-
-		 
-	// field number 6
-	
-		b = append(b,
-			// tag
-			(6 << 3) | 2 /* 0x32 */,
-			// size
-			4, 
-		)
-		b = append(b, msg.ByteArr[:]...)
-	
-	} 
+		// field number 6
+		b = append(b, (6<<3)|2 /* 0x32 */, 4) // tag, size
+		b = append(b, (*msg.ByteArr)[:]...)
+	}
 	// field number 7
-	
-		// skipped (zero-element array)
-	
+	// (always empty, skip as there is no write_empty)
 	if msg.IntPtr != nil {
-		// use a new "msg" so we can encode the underlying field directly.
-		// with _ = msg, avoid "unused" warnings.
-		msg := struct { IntPtr int }{ *msg.IntPtr }
-		_ = msg
-
-		
-	
-		if msg.IntPtr != 0 {
-		// field number 8
-		b = append(b, (8 << 3) | 0 /* 0x40 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.IntPtr))]
+		if *msg.IntPtr != 0 {
+			// field number 8
+			b = append(b, (8<<3)|0 /* 0x40 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(*msg.IntPtr))]
 		}
-	
 	}
-	// field number 9
-	 
-		for _, el := range msg.Slice {
-			msg := struct { Slice struct {
-	A int `json:"A"`
-	B int `json:"B"`
-} }{ el }
-			
-	// field number 9
-	
+	for _, el := range msg.Slice {
+		// field number 9
 		{
 			startLen := len(b)
-			msg := msg.Slice
-			
-	
-		if msg.A != 0 {
-		// field number 1
-		b = append(b, (1 << 3) | 0 /* 0x08 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.A))]
-		}
-	
-	
-		if msg.B != 0 {
-		// field number 2
-		b = append(b, (2 << 3) | 0 /* 0x10 */)
-		b = growBytes(b, 10)
-		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.B))]
-		}
-	
-
+			if el.A != 0 {
+				// field number 1
+				b = append(b, (1<<3)|0 /* 0x08 */)
+				b = growBytes(b, 10)
+				b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(el.A))]
+			}
+			if el.B != 0 {
+				// field number 2
+				b = append(b, (2<<3)|0 /* 0x10 */)
+				b = growBytes(b, 10)
+				b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(el.B))]
+			}
 			encodedSize := uint64(len(b) - startLen)
-
 			switch {
 			case encodedSize == 0:
-			
-				// empty -- nothing to do.
-			
+				// empty -- append tag and 0.
+				b = append(b, (9<<3)|2 /* 0x4a */, 0)
 			case encodedSize <= maxVarint1:
 				const shift = 1 + 1
 				b = growBytes(b, shift)[:len(b)+shift]
 				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (9 << 3) | 2 /* 0x4a */, byte(encodedSize))
+				_ = append(b[:startLen], (9<<3)|2 /* 0x4a */, byte(encodedSize))
 			default:
 				shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
 				b = growBytes(b, shift)[:len(b)+shift]
 				copy(b[startLen+shift:], b[startLen:len(b)-shift])
-				_ = append(b[:startLen], (9 << 3) | 2 /* 0x4a */)
+				_ = append(b[:startLen], (9<<3)|2 /* 0x4a */)
 				putUvarint(b[startLen+1:startLen+shift], encodedSize)
 			}
 		}
-	
-		}
-	
-
+	}
 	return b, nil
 }
 
-// ---
-// encoding helpers
-
-// Non-generic version of slices.Grow.
-func growBytes(s []byte, n int) []byte {
-	if n -= cap(s) - len(s); n > 0 {
-		s = append(s[:cap(s)], make([]byte, n)...)[:len(s)]
-	}
-	return s
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *TestTypeMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
 }
 
-const (
-	// These are common when encoding lengths, and have fast paths instead of
-	// calling putUvarint.
-	maxVarint1 = (1 << 7) - 1
-	maxVarint2 = (1 << 7) - 1
+// UnmarshalBinaryNoCopy is like [TestTypeMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *TestTypeMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
 
-	len8tab = "" +
-		"\x00\x01\x02\x02\x03\x03\x03\x03\x04\x04\x04\x04\x04\x04\x04\x04" +
-		"\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05" +
-		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
-		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
-		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
-		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
-		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
-		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
-		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08"
-)
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *TestTypeMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = TestTypeMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Time
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Duration
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 3:
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		case 5:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 6:
+			if msg.ByteArr == nil {
+				msg.ByteArr = new([4]byte)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 4 {
+				return errArrayLength
+			}
+			copy((*msg.ByteArr)[:], v)
+		case 7:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 0 {
+				return errArrayLength
+			}
+			copy(msg.ZeroArr[:], v)
+		case 8:
+			if msg.IntPtr == nil {
+				msg.IntPtr = new(int)
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			*msg.IntPtr = int(x)
+		case 9:
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Slice = append(msg.Slice, struct {
+				A int `json:"A"`
+				B int `json:"B"`
+			}{})
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Slice[len(msg.Slice)-1]
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.A = int(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.B = int(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [TestTypeMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg TestTypeMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg TestTypeMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *TestTypeMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [TestTypeMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg TestTypeMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *TestTypeMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = TestTypeMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *TestTypeMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Time
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Duration
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		case 5:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 6:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.ByteArr == nil {
+				msg.ByteArr = new([4]byte)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 4 {
+				return errArrayLength
+			}
+			copy((*msg.ByteArr)[:], v)
+		case 7:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 0 {
+				return errArrayLength
+			}
+			copy(msg.ZeroArr[:], v)
+		case 8:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.IntPtr == nil {
+				msg.IntPtr = new(int)
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			*msg.IntPtr = int(x)
+		case 9:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			msg.Slice = append(msg.Slice, struct {
+				A int `json:"A"`
+				B int `json:"B"`
+			}{})
+			{
+				m := &msg.Slice[len(msg.Slice)-1]
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.A = int(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.B = int(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.TestType, like amino.MarshalAny.
+func (msg TestTypeMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg TestTypeMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.TestType", msg)
+}
+
+// MarshalAnySized encodes the message like [TestTypeMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg TestTypeMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg TestTypeMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.TestType.
+// The message is reset before decoding.
+func (msg *TestTypeMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.TestType" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.TestType")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *TestTypeMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [TestTypeMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg TestTypeMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg TestTypeMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, `,"B":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].B), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [TestTypeMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg TestTypeMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg TestTypeMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, `,"B":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].B), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *TestTypeMessage) UnmarshalJSON(b []byte) error {
+	*msg = TestTypeMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Time":
+				msg.Time.Seconds = 0
+				msg.Time.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONTime(v)
+					if err != nil {
+						return err
+					}
+					msg.Time.Seconds, msg.Time.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "Duration":
+				msg.Duration.Seconds = 0
+				msg.Duration.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONDuration(v)
+					if err != nil {
+						return err
+					}
+					msg.Duration.Seconds, msg.Duration.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "FixedUint":
+				msg.FixedUint = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 64, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.FixedUint = x
+				}
+			case "Byte":
+				msg.Byte = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 8, false)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Byte = uint8(x)
+				}
+			case "Bytes":
+				msg.Bytes = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Bytes, err = decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+				}
+			case "ByteArr":
+				msg.ByteArr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.ByteArr = new([4]byte)
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					p, err := decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+					if len(p) != 4 {
+						return errArrayLength
+					}
+					copy((*msg.ByteArr)[:], p)
+				}
+			case "ZeroArr":
+				msg.ZeroArr = [0]byte{}
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					p, err := decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+					if len(p) != 0 {
+						return errArrayLength
+					}
+					copy(msg.ZeroArr[:], p)
+				}
+			case "IntPtr":
+				msg.IntPtr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.IntPtr = new(int)
+					x, n, err := consumeJSONInt(b, 0, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.IntPtr = int(x)
+				}
+			case "Slice":
+				msg.Slice = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Slice = append(msg.Slice, struct {
+							A int `json:"A"`
+							B int `json:"B"`
+						}{})
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							for first := true; ; first = false {
+								more, n, err := nextJSONElem(b, "{}", first)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								if !more {
+									break
+								}
+								key, n, err := consumeJSONKey(b)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								switch string(key) {
+								case "A":
+									msg.Slice[len(msg.Slice)-1].A = 0
+									if isJSONNull(b) {
+										b = b[4:]
+									} else {
+										x, n, err := consumeJSONInt(b, 0, true)
+										if err != nil {
+											return err
+										}
+										b = b[n:]
+										msg.Slice[len(msg.Slice)-1].A = int(x)
+									}
+								case "B":
+									msg.Slice[len(msg.Slice)-1].B = 0
+									if isJSONNull(b) {
+										b = b[4:]
+									} else {
+										x, n, err := consumeJSONInt(b, 0, true)
+										if err != nil {
+											return err
+										}
+										b = b[n:]
+										msg.Slice[len(msg.Slice)-1].B = int(x)
+									}
+								default:
+									n, err := skipJSONValue(b, 0)
+									if err != nil {
+										return err
+									}
+									b = b[n:]
+								}
+							}
+						}
+					}
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.TestType, in the "@type" member, like amino.MarshalJSONAny.
+func (msg TestTypeMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// FloatTypeMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.FloatType
+type FloatTypeMessage struct {
+	Float     float64    `json:"Float" amino:"unsafe"`
+	Float32   float32    `json:"Float32" amino:"unsafe"`
+	FloatPtr  *float64   `json:"FloatPtr" amino:"unsafe"`
+	Floats    []float32  `json:"Floats" amino:"unsafe"`
+	FloatArr  [2]float64 `json:"FloatArr" amino:"unsafe"`
+	FloatPtrs []*float64 `json:"FloatPtrs" amino:"unsafe"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [FloatTypeMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg FloatTypeMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg FloatTypeMessage) AppendBinary(b []byte) ([]byte, error) {
+	{
+		u64 := *(*uint64)(unsafe.Pointer(&msg.Float))
+		// field number 1
+		b = append(b, (1<<3)|1 /* 0x09 */)
+		b = growBytes(b, 8)[:len(b)+8]
+		putUint64(b[len(b)-8:], u64)
+	}
+	{
+		u32 := *(*uint32)(unsafe.Pointer(&msg.Float32))
+		// field number 2
+		b = append(b, (2<<3)|5 /* 0x15 */)
+		b = growBytes(b, 4)[:len(b)+4]
+		putUint32(b[len(b)-4:], u32)
+	}
+	if msg.FloatPtr != nil {
+		{
+			u64 := *(*uint64)(unsafe.Pointer(&*msg.FloatPtr))
+			// field number 3
+			b = append(b, (3<<3)|1 /* 0x19 */)
+			b = growBytes(b, 8)[:len(b)+8]
+			putUint64(b[len(b)-8:], u64)
+		}
+	}
+	// field number 4 (packed)
+	if len(msg.Floats) != 0 {
+		b = append(b, (4<<3)|2 /* 0x22 */)
+		startLen := len(b)
+		for _, el := range msg.Floats {
+			b = growBytes(b, 4)[:len(b)+4]
+			putUint32(b[len(b)-4:], *(*uint32)(unsafe.Pointer(&el)))
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	// field number 5 (packed)
+	{
+		b = append(b, (5<<3)|2 /* 0x2a */)
+		startLen := len(b)
+		for _, el := range &msg.FloatArr {
+			b = growBytes(b, 8)[:len(b)+8]
+			putUint64(b[len(b)-8:], *(*uint64)(unsafe.Pointer(&el)))
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	// field number 6 (packed)
+	if len(msg.FloatPtrs) != 0 {
+		b = append(b, (6<<3)|2 /* 0x32 */)
+		startLen := len(b)
+		for _, el := range msg.FloatPtrs {
+			if el == nil {
+				b = append(b, 0, 0, 0, 0, 0, 0, 0, 0)
+				continue
+			}
+			b = growBytes(b, 8)[:len(b)+8]
+			putUint64(b[len(b)-8:], *(*uint64)(unsafe.Pointer(&*el)))
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *FloatTypeMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [FloatTypeMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *FloatTypeMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *FloatTypeMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = FloatTypeMessage{}
+	var idx5 int
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Float = *(*float64)(unsafe.Pointer(&x))
+		case 2:
+			if wt != 5 {
+				return errWireType
+			}
+			x, n, err := consumeFixed32(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Float32 = *(*float32)(unsafe.Pointer(&x))
+		case 3:
+			if msg.FloatPtr == nil {
+				msg.FloatPtr = new(float64)
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			*msg.FloatPtr = *(*float64)(unsafe.Pointer(&x))
+		case 4:
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.Floats = append(msg.Floats, 0)
+					x, n, err := consumeFixed32(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Floats[len(msg.Floats)-1] = *(*float32)(unsafe.Pointer(&x))
+				}
+			case 5:
+				msg.Floats = append(msg.Floats, 0)
+				x, n, err := consumeFixed32(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				msg.Floats[len(msg.Floats)-1] = *(*float32)(unsafe.Pointer(&x))
+			default:
+				return errWireType
+			}
+		case 5:
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					if idx5 >= 2 {
+						return errArrayLength
+					}
+					x, n, err := consumeFixed64(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.FloatArr[idx5] = *(*float64)(unsafe.Pointer(&x))
+					idx5++
+				}
+			case 1:
+				if idx5 >= 2 {
+					return errArrayLength
+				}
+				x, n, err := consumeFixed64(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				msg.FloatArr[idx5] = *(*float64)(unsafe.Pointer(&x))
+				idx5++
+			default:
+				return errWireType
+			}
+		case 6:
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.FloatPtrs = append(msg.FloatPtrs, nil)
+					msg.FloatPtrs[len(msg.FloatPtrs)-1] = new(float64)
+					x, n, err := consumeFixed64(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.FloatPtrs[len(msg.FloatPtrs)-1] = *(*float64)(unsafe.Pointer(&x))
+				}
+			case 1:
+				msg.FloatPtrs = append(msg.FloatPtrs, nil)
+				msg.FloatPtrs[len(msg.FloatPtrs)-1] = new(float64)
+				x, n, err := consumeFixed64(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				*msg.FloatPtrs[len(msg.FloatPtrs)-1] = *(*float64)(unsafe.Pointer(&x))
+			default:
+				return errWireType
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [FloatTypeMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg FloatTypeMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg FloatTypeMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *FloatTypeMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [FloatTypeMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg FloatTypeMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *FloatTypeMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = FloatTypeMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *FloatTypeMessage) readStream(sr *streamReader) error {
+	const alias = false
+	var idx5 int
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Float = *(*float64)(unsafe.Pointer(&x))
+		case 2:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 5 {
+				return errWireType
+			}
+			x, n, err := consumeFixed32(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Float32 = *(*float32)(unsafe.Pointer(&x))
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.FloatPtr == nil {
+				msg.FloatPtr = new(float64)
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			*msg.FloatPtr = *(*float64)(unsafe.Pointer(&x))
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.Floats = append(msg.Floats, 0)
+					x, n, err := consumeFixed32(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Floats[len(msg.Floats)-1] = *(*float32)(unsafe.Pointer(&x))
+				}
+			case 5:
+				msg.Floats = append(msg.Floats, 0)
+				x, n, err := consumeFixed32(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				msg.Floats[len(msg.Floats)-1] = *(*float32)(unsafe.Pointer(&x))
+			default:
+				return errWireType
+			}
+		case 5:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					if idx5 >= 2 {
+						return errArrayLength
+					}
+					x, n, err := consumeFixed64(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.FloatArr[idx5] = *(*float64)(unsafe.Pointer(&x))
+					idx5++
+				}
+			case 1:
+				if idx5 >= 2 {
+					return errArrayLength
+				}
+				x, n, err := consumeFixed64(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				msg.FloatArr[idx5] = *(*float64)(unsafe.Pointer(&x))
+				idx5++
+			default:
+				return errWireType
+			}
+		case 6:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.FloatPtrs = append(msg.FloatPtrs, nil)
+					msg.FloatPtrs[len(msg.FloatPtrs)-1] = new(float64)
+					x, n, err := consumeFixed64(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.FloatPtrs[len(msg.FloatPtrs)-1] = *(*float64)(unsafe.Pointer(&x))
+				}
+			case 1:
+				msg.FloatPtrs = append(msg.FloatPtrs, nil)
+				msg.FloatPtrs[len(msg.FloatPtrs)-1] = new(float64)
+				x, n, err := consumeFixed64(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				*msg.FloatPtrs[len(msg.FloatPtrs)-1] = *(*float64)(unsafe.Pointer(&x))
+			default:
+				return errWireType
+			}
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.FloatType, like amino.MarshalAny.
+func (msg FloatTypeMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg FloatTypeMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.FloatType", msg)
+}
+
+// MarshalAnySized encodes the message like [FloatTypeMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg FloatTypeMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg FloatTypeMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.FloatType.
+// The message is reset before decoding.
+func (msg *FloatTypeMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.FloatType" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.FloatType")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *FloatTypeMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [FloatTypeMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg FloatTypeMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg FloatTypeMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Float":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float), 64)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Float32":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float32), 32)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"FloatPtr":`...)
+	if msg.FloatPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONFloat(b, float64(*msg.FloatPtr), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, `,"Floats":`...)
+	if msg.Floats == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Floats {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b, err = appendJSONFloat(b, float64(msg.Floats[i]), 32)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"FloatArr":`...)
+	b = append(b, '[')
+	for i := range msg.FloatArr {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b, err = appendJSONFloat(b, float64(msg.FloatArr[i]), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, ']')
+	b = append(b, `,"FloatPtrs":`...)
+	if msg.FloatPtrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.FloatPtrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.FloatPtrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b, err = appendJSONFloat(b, float64(*msg.FloatPtrs[i]), 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [FloatTypeMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg FloatTypeMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg FloatTypeMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Float":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float), 64)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Float32":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float32), 32)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"FloatArr":`...)
+	b = append(b, '[')
+	for i := range msg.FloatArr {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b, err = appendJSONFloat(b, float64(msg.FloatArr[i]), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, ']')
+	b = append(b, `,"FloatPtr":`...)
+	if msg.FloatPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONFloat(b, float64(*msg.FloatPtr), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, `,"FloatPtrs":`...)
+	if msg.FloatPtrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.FloatPtrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.FloatPtrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b, err = appendJSONFloat(b, float64(*msg.FloatPtrs[i]), 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"Floats":`...)
+	if msg.Floats == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Floats {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b, err = appendJSONFloat(b, float64(msg.Floats[i]), 32)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *FloatTypeMessage) UnmarshalJSON(b []byte) error {
+	*msg = FloatTypeMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Float":
+				msg.Float = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONFloat(b, 64)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Float = x
+				}
+			case "Float32":
+				msg.Float32 = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONFloat(b, 32)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Float32 = float32(x)
+				}
+			case "FloatPtr":
+				msg.FloatPtr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.FloatPtr = new(float64)
+					x, n, err := consumeJSONFloat(b, 64)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.FloatPtr = x
+				}
+			case "Floats":
+				msg.Floats = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Floats = append(msg.Floats, 0)
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							x, n, err := consumeJSONFloat(b, 32)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							msg.Floats[len(msg.Floats)-1] = float32(x)
+						}
+					}
+				}
+			case "FloatArr":
+				msg.FloatArr = [2]float64{}
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					i := 0
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						if i >= 2 {
+							return errArrayLength
+						}
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							x, n, err := consumeJSONFloat(b, 64)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							msg.FloatArr[i] = x
+						}
+						i++
+					}
+					if i != 2 {
+						return errArrayLength
+					}
+				}
+			case "FloatPtrs":
+				msg.FloatPtrs = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.FloatPtrs = append(msg.FloatPtrs, nil)
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							msg.FloatPtrs[len(msg.FloatPtrs)-1] = new(float64)
+							x, n, err := consumeJSONFloat(b, 64)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							*msg.FloatPtrs[len(msg.FloatPtrs)-1] = x
+						}
+					}
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.FloatType, in the "@type" member, like amino.MarshalJSONAny.
+func (msg FloatTypeMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// JSONTypeMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.JSONType
+type JSONTypeMessage struct {
+	Int8      int8      `json:"int8,omitempty"`
+	Name      string    `json:"name"`
+	Int32     int32     `json:"int32"`
+	Int64     int64     `json:"int64,omitempty"`
+	Uint      uint      `json:"Uint,omitempty"`
+	Bool      bool      `json:"bool,omitempty"`
+	Bytes     []byte    `json:"bytes,omitempty"`
+	Strings   []string  `json:"strings,omitempty"`
+	StringPtr *string   `json:"string_ptr,omitempty"`
+	SlicePtr  *[]int16  `json:"slice_ptr,omitempty"`
+	Ptrs      []*int32  `json:"ptrs"`
+	Arr       [2]uint16 `json:"arr,omitempty"`
+	Time      struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"time,omitempty"`
+	Duration struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"duration,omitempty"`
+	Struct struct {
+		A uint32 `json:"A"`
+		B string `json:"B"`
+	} `json:"struct,omitempty"`
+	Structs [1]struct {
+		C bool `json:"C"`
+	} `json:"structs,omitempty"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [JSONTypeMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg JSONTypeMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg JSONTypeMessage) AppendBinary(b []byte) ([]byte, error) {
+	if msg.Int8 != 0 {
+		// field number 1
+		b = append(b, (1<<3)|0 /* 0x08 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.Int8))]
+	}
+	// field number 2
+	switch {
+	case len(msg.Name) == 0:
+		// nothing to write
+	case len(msg.Name) <= maxVarint1:
+		b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Name)))
+		b = append(b, msg.Name...)
+	case len(msg.Name) <= maxVarint2:
+		b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Name)|0x80), byte(len(msg.Name)>>7))
+		b = append(b, msg.Name...)
+	default:
+		b = growBytes(b, 1+10+len(msg.Name))
+		b = append(b, (2<<3)|2 /* 0x12 */)
+		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Name)))
+		b = b[:len(b)+uvlen]
+		b = append(b, msg.Name...)
+	}
+	if msg.Int32 != 0 {
+		// field number 3
+		b = append(b, (3<<3)|0 /* 0x18 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.Int32))]
+	}
+	if msg.Int64 != 0 {
+		// field number 4
+		b = append(b, (4<<3)|0 /* 0x20 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.Int64))]
+	}
+	if msg.Uint != 0 {
+		// field number 5
+		b = append(b, (5<<3)|0 /* 0x28 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Uint))]
+	}
+	if msg.Bool {
+		// field number 6
+		b = append(b, (6<<3)|0 /* 0x30 */, 1)
+	}
+	// field number 7
+	switch {
+	case len(msg.Bytes) == 0:
+		// nothing to write
+	case len(msg.Bytes) <= maxVarint1:
+		b = append(b, (7<<3)|2 /* 0x3a */, byte(len(msg.Bytes)))
+		b = append(b, msg.Bytes...)
+	case len(msg.Bytes) <= maxVarint2:
+		b = append(b, (7<<3)|2 /* 0x3a */, byte(len(msg.Bytes)|0x80), byte(len(msg.Bytes)>>7))
+		b = append(b, msg.Bytes...)
+	default:
+		b = growBytes(b, 1+10+len(msg.Bytes))
+		b = append(b, (7<<3)|2 /* 0x3a */)
+		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Bytes)))
+		b = b[:len(b)+uvlen]
+		b = append(b, msg.Bytes...)
+	}
+	for _, el := range msg.Strings {
+		// field number 8
+		switch {
+		case len(el) == 0:
+			b = append(b, (8<<3)|2 /* 0x42 */, 0)
+		case len(el) <= maxVarint1:
+			b = append(b, (8<<3)|2 /* 0x42 */, byte(len(el)))
+			b = append(b, el...)
+		case len(el) <= maxVarint2:
+			b = append(b, (8<<3)|2 /* 0x42 */, byte(len(el)|0x80), byte(len(el)>>7))
+			b = append(b, el...)
+		default:
+			b = growBytes(b, 1+10+len(el))
+			b = append(b, (8<<3)|2 /* 0x42 */)
+			uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(el)))
+			b = b[:len(b)+uvlen]
+			b = append(b, el...)
+		}
+	}
+	if msg.StringPtr != nil {
+		// field number 9
+		switch {
+		case len(*msg.StringPtr) == 0:
+			// nothing to write
+		case len(*msg.StringPtr) <= maxVarint1:
+			b = append(b, (9<<3)|2 /* 0x4a */, byte(len(*msg.StringPtr)))
+			b = append(b, *msg.StringPtr...)
+		case len(*msg.StringPtr) <= maxVarint2:
+			b = append(b, (9<<3)|2 /* 0x4a */, byte(len(*msg.StringPtr)|0x80), byte(len(*msg.StringPtr)>>7))
+			b = append(b, *msg.StringPtr...)
+		default:
+			b = growBytes(b, 1+10+len(*msg.StringPtr))
+			b = append(b, (9<<3)|2 /* 0x4a */)
+			uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(*msg.StringPtr)))
+			b = b[:len(b)+uvlen]
+			b = append(b, *msg.StringPtr...)
+		}
+	}
+	if msg.SlicePtr != nil {
+		// field number 10 (packed)
+		if len(*msg.SlicePtr) != 0 {
+			b = append(b, (10<<3)|2 /* 0x52 */)
+			startLen := len(b)
+			for _, el := range *msg.SlicePtr {
+				b = growBytes(b, 10)
+				b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(el))]
+			}
+			b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+		}
+	}
+	// field number 11 (packed)
+	if len(msg.Ptrs) != 0 {
+		b = append(b, (11<<3)|2 /* 0x5a */)
+		startLen := len(b)
+		for _, el := range msg.Ptrs {
+			if el == nil {
+				b = append(b, 0)
+				continue
+			}
+			b = growBytes(b, 10)
+			b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(*el))]
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	// field number 12 (packed)
+	{
+		b = append(b, (12<<3)|2 /* 0x62 */)
+		startLen := len(b)
+		for _, el := range &msg.Arr {
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(el))]
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	// field number 13
+	{
+		startLen := len(b)
+		if msg.Time.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Seconds))]
+		}
+		if msg.Time.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (13<<3)|2 /* 0x6a */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (13<<3)|2 /* 0x6a */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	// field number 14
+	{
+		startLen := len(b)
+		if msg.Duration.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Seconds))]
+		}
+		if msg.Duration.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (14<<3)|2 /* 0x72 */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (14<<3)|2 /* 0x72 */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	// field number 15
+	{
+		startLen := len(b)
+		if msg.Struct.A != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Struct.A))]
+		}
+		// field number 2
+		switch {
+		case len(msg.Struct.B) == 0:
+			// nothing to write
+		case len(msg.Struct.B) <= maxVarint1:
+			b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Struct.B)))
+			b = append(b, msg.Struct.B...)
+		case len(msg.Struct.B) <= maxVarint2:
+			b = append(b, (2<<3)|2 /* 0x12 */, byte(len(msg.Struct.B)|0x80), byte(len(msg.Struct.B)>>7))
+			b = append(b, msg.Struct.B...)
+		default:
+			b = growBytes(b, 1+10+len(msg.Struct.B))
+			b = append(b, (2<<3)|2 /* 0x12 */)
+			uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Struct.B)))
+			b = b[:len(b)+uvlen]
+			b = append(b, msg.Struct.B...)
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (15<<3)|2 /* 0x7a */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (15<<3)|2 /* 0x7a */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	for _, el := range &msg.Structs {
+		// field number 16
+		{
+			startLen := len(b)
+			if el.C {
+				// field number 1
+				b = append(b, (1<<3)|0 /* 0x08 */, 1)
+			}
+			encodedSize := uint64(len(b) - startLen)
+			switch {
+			case encodedSize == 0:
+				// empty -- append tag and 0.
+				b = append(b, 0x82, 0x01, 0)
+			case encodedSize <= maxVarint1:
+				const shift = 2 + 1
+				b = growBytes(b, shift)[:len(b)+shift]
+				copy(b[startLen+shift:], b[startLen:len(b)-shift])
+				_ = append(b[:startLen], 0x82, 0x01, byte(encodedSize))
+			default:
+				shift := 2 + uvarintSize(encodedSize) // tag length + uvarint size
+				b = growBytes(b, shift)[:len(b)+shift]
+				copy(b[startLen+shift:], b[startLen:len(b)-shift])
+				_ = append(b[:startLen], 0x82, 0x01)
+				putUvarint(b[startLen+2:startLen+shift], encodedSize)
+			}
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *JSONTypeMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [JSONTypeMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *JSONTypeMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *JSONTypeMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = JSONTypeMessage{}
+	var idx12 int
+	var idx16 int
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int8(x)) != x {
+				return errOverflow
+			}
+			msg.Int8 = int8(x)
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		case 3:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int32(x)) != x {
+				return errOverflow
+			}
+			msg.Int32 = int32(x)
+		case 4:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Int64 = int64(x)
+		case 5:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint(x)) != x {
+				return errOverflow
+			}
+			msg.Uint = uint(x)
+		case 6:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Bool = x
+		case 7:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 8:
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Strings = append(msg.Strings, "")
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Strings[len(msg.Strings)-1] = aliasString(v)
+			} else {
+				msg.Strings[len(msg.Strings)-1] = string(v)
+			}
+		case 9:
+			if msg.StringPtr == nil {
+				msg.StringPtr = new(string)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				*msg.StringPtr = aliasString(v)
+			} else {
+				*msg.StringPtr = string(v)
+			}
+		case 10:
+			if msg.SlicePtr == nil {
+				msg.SlicePtr = new([]int16)
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					*msg.SlicePtr = append(*msg.SlicePtr, 0)
+					x, n, err := consumeVarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if int64(int16(x)) != x {
+						return errOverflow
+					}
+					(*msg.SlicePtr)[len((*msg.SlicePtr))-1] = int16(x)
+				}
+			case 0:
+				*msg.SlicePtr = append(*msg.SlicePtr, 0)
+				x, n, err := consumeVarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if int64(int16(x)) != x {
+					return errOverflow
+				}
+				(*msg.SlicePtr)[len((*msg.SlicePtr))-1] = int16(x)
+			default:
+				return errWireType
+			}
+		case 11:
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.Ptrs = append(msg.Ptrs, nil)
+					msg.Ptrs[len(msg.Ptrs)-1] = new(int32)
+					x, n, err := consumeVarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if int64(int32(x)) != x {
+						return errOverflow
+					}
+					*msg.Ptrs[len(msg.Ptrs)-1] = int32(x)
+				}
+			case 0:
+				msg.Ptrs = append(msg.Ptrs, nil)
+				msg.Ptrs[len(msg.Ptrs)-1] = new(int32)
+				x, n, err := consumeVarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if int64(int32(x)) != x {
+					return errOverflow
+				}
+				*msg.Ptrs[len(msg.Ptrs)-1] = int32(x)
+			default:
+				return errWireType
+			}
+		case 12:
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					if idx12 >= 2 {
+						return errArrayLength
+					}
+					x, n, err := consumeUvarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if uint64(uint16(x)) != x {
+						return errOverflow
+					}
+					msg.Arr[idx12] = uint16(x)
+					idx12++
+				}
+			case 0:
+				if idx12 >= 2 {
+					return errArrayLength
+				}
+				x, n, err := consumeUvarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if uint64(uint16(x)) != x {
+					return errOverflow
+				}
+				msg.Arr[idx12] = uint16(x)
+				idx12++
+			default:
+				return errWireType
+			}
+		case 13:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Time
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 14:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Duration
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 15:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Struct
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.A = uint32(x)
+					case 2:
+						if wt != 2 {
+							return errWireType
+						}
+						v, n, err := consumeBytes(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if alias {
+							m.B = aliasString(v)
+						} else {
+							m.B = string(v)
+						}
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 16:
+			if wt != 2 {
+				return errWireType
+			}
+			if idx16 >= 1 {
+				return errArrayLength
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Structs[idx16]
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeBool(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.C = x
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+			idx16++
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [JSONTypeMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg JSONTypeMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg JSONTypeMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *JSONTypeMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [JSONTypeMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg JSONTypeMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *JSONTypeMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = JSONTypeMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *JSONTypeMessage) readStream(sr *streamReader) error {
+	const alias = false
+	var idx12 int
+	var idx16 int
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int8(x)) != x {
+				return errOverflow
+			}
+			msg.Int8 = int8(x)
+		case 2:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int32(x)) != x {
+				return errOverflow
+			}
+			msg.Int32 = int32(x)
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Int64 = int64(x)
+		case 5:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint(x)) != x {
+				return errOverflow
+			}
+			msg.Uint = uint(x)
+		case 6:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeBool(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.Bool = x
+		case 7:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 8:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Strings = append(msg.Strings, "")
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Strings[len(msg.Strings)-1] = aliasString(v)
+			} else {
+				msg.Strings[len(msg.Strings)-1] = string(v)
+			}
+		case 9:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.StringPtr == nil {
+				msg.StringPtr = new(string)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				*msg.StringPtr = aliasString(v)
+			} else {
+				*msg.StringPtr = string(v)
+			}
+		case 10:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.SlicePtr == nil {
+				msg.SlicePtr = new([]int16)
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					*msg.SlicePtr = append(*msg.SlicePtr, 0)
+					x, n, err := consumeVarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if int64(int16(x)) != x {
+						return errOverflow
+					}
+					(*msg.SlicePtr)[len((*msg.SlicePtr))-1] = int16(x)
+				}
+			case 0:
+				*msg.SlicePtr = append(*msg.SlicePtr, 0)
+				x, n, err := consumeVarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if int64(int16(x)) != x {
+					return errOverflow
+				}
+				(*msg.SlicePtr)[len((*msg.SlicePtr))-1] = int16(x)
+			default:
+				return errWireType
+			}
+		case 11:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					msg.Ptrs = append(msg.Ptrs, nil)
+					msg.Ptrs[len(msg.Ptrs)-1] = new(int32)
+					x, n, err := consumeVarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if int64(int32(x)) != x {
+						return errOverflow
+					}
+					*msg.Ptrs[len(msg.Ptrs)-1] = int32(x)
+				}
+			case 0:
+				msg.Ptrs = append(msg.Ptrs, nil)
+				msg.Ptrs[len(msg.Ptrs)-1] = new(int32)
+				x, n, err := consumeVarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if int64(int32(x)) != x {
+					return errOverflow
+				}
+				*msg.Ptrs[len(msg.Ptrs)-1] = int32(x)
+			default:
+				return errWireType
+			}
+		case 12:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			switch wt {
+			case 2:
+				v, n, err := consumeBytes(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				for b := v; len(b) > 0; {
+					if idx12 >= 2 {
+						return errArrayLength
+					}
+					x, n, err := consumeUvarint(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					if uint64(uint16(x)) != x {
+						return errOverflow
+					}
+					msg.Arr[idx12] = uint16(x)
+					idx12++
+				}
+			case 0:
+				if idx12 >= 2 {
+					return errArrayLength
+				}
+				x, n, err := consumeUvarint(b)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+				if uint64(uint16(x)) != x {
+					return errOverflow
+				}
+				msg.Arr[idx12] = uint16(x)
+				idx12++
+			default:
+				return errWireType
+			}
+		case 13:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Time
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 14:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Duration
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 15:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Struct
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.A = uint32(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 2 {
+							return errWireType
+						}
+						v, n, err := consumeBytes(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if alias {
+							m.B = aliasString(v)
+						} else {
+							m.B = string(v)
+						}
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 16:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			if idx16 >= 1 {
+				return errArrayLength
+			}
+			{
+				m := &msg.Structs[idx16]
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeBool(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.C = x
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			idx16++
+			sr.pop()
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.JSONType, like amino.MarshalAny.
+func (msg JSONTypeMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg JSONTypeMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.JSONType", msg)
+}
+
+// MarshalAnySized encodes the message like [JSONTypeMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg JSONTypeMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg JSONTypeMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.JSONType.
+// The message is reset before decoding.
+func (msg *JSONTypeMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.JSONType" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.JSONType")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *JSONTypeMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [JSONTypeMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg JSONTypeMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg JSONTypeMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	if msg.Int8 != 0 {
+		b = append(b, `"int8":`...)
+		b = strconv.AppendInt(b, int64(msg.Int8), 10)
+	}
+	if b[len(b)-1] != '{' {
+		b = append(b, ',')
+	}
+	b = append(b, `"name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, `,"int32":`...)
+	b = strconv.AppendInt(b, int64(msg.Int32), 10)
+	if msg.Int64 != 0 {
+		b = append(b, `,"int64":`...)
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(msg.Int64), 10)
+		b = append(b, '"')
+	}
+	if msg.Uint != 0 {
+		b = append(b, `,"Uint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.Uint), 10)
+		b = append(b, '"')
+	}
+	if msg.Bool {
+		b = append(b, `,"bool":`...)
+		b = strconv.AppendBool(b, msg.Bool)
+	}
+	if len(msg.Bytes) != 0 {
+		b = append(b, `,"bytes":`...)
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	if len(msg.Strings) != 0 {
+		b = append(b, `,"strings":`...)
+		b = append(b, '[')
+		for i := range msg.Strings {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, msg.Strings[i], false)
+		}
+		b = append(b, ']')
+	}
+	if msg.StringPtr != nil && len(*msg.StringPtr) != 0 {
+		b = append(b, `,"string_ptr":`...)
+		b = appendJSONString(b, *msg.StringPtr, false)
+	}
+	if msg.SlicePtr != nil && len(*msg.SlicePtr) != 0 {
+		b = append(b, `,"slice_ptr":`...)
+		b = append(b, '[')
+		for i := range *msg.SlicePtr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendInt(b, int64((*msg.SlicePtr)[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"ptrs":`...)
+	if msg.Ptrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Ptrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Ptrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b = strconv.AppendInt(b, int64(*msg.Ptrs[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if msg.Arr != [2]uint16{} {
+		b = append(b, `,"arr":`...)
+		b = append(b, '[')
+		for i := range msg.Arr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(msg.Arr[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if int64(msg.Time.Seconds) != -62135596800 || msg.Time.Nanoseconds != 0 {
+		b = append(b, `,"time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if msg.Duration.Seconds != 0 || msg.Duration.Nanoseconds != 0 {
+		b = append(b, `,"duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	}
+	if msg.Struct.A != 0 || msg.Struct.B != "" {
+		b = append(b, `,"struct":`...)
+		b = append(b, '{')
+		b = append(b, `"A":`...)
+		b = strconv.AppendUint(b, uint64(msg.Struct.A), 10)
+		b = append(b, `,"B":`...)
+		b = appendJSONString(b, msg.Struct.B, false)
+		b = append(b, '}')
+	}
+	if func() bool {
+		for i := range msg.Structs {
+			if msg.Structs[i].C {
+				return true
+			}
+		}
+		return false
+	}() {
+		b = append(b, `,"structs":`...)
+		b = append(b, '[')
+		for i := range msg.Structs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"C":`...)
+			b = strconv.AppendBool(b, msg.Structs[i].C)
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [JSONTypeMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg JSONTypeMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg JSONTypeMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	if msg.Uint != 0 {
+		b = append(b, `"Uint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.Uint), 10)
+		b = append(b, '"')
+	}
+	if msg.Arr != [2]uint16{} {
+		if b[len(b)-1] != '{' {
+			b = append(b, ',')
+		}
+		b = append(b, `"arr":`...)
+		b = append(b, '[')
+		for i := range msg.Arr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(msg.Arr[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if msg.Bool {
+		if b[len(b)-1] != '{' {
+			b = append(b, ',')
+		}
+		b = append(b, `"bool":`...)
+		b = strconv.AppendBool(b, msg.Bool)
+	}
+	if len(msg.Bytes) != 0 {
+		if b[len(b)-1] != '{' {
+			b = append(b, ',')
+		}
+		b = append(b, `"bytes":`...)
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	if msg.Duration.Seconds != 0 || msg.Duration.Nanoseconds != 0 {
+		if b[len(b)-1] != '{' {
+			b = append(b, ',')
+		}
+		b = append(b, `"duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	}
+	if b[len(b)-1] != '{' {
+		b = append(b, ',')
+	}
+	b = append(b, `"int32":`...)
+	b = strconv.AppendInt(b, int64(msg.Int32), 10)
+	if msg.Int64 != 0 {
+		b = append(b, `,"int64":`...)
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(msg.Int64), 10)
+		b = append(b, '"')
+	}
+	if msg.Int8 != 0 {
+		b = append(b, `,"int8":`...)
+		b = strconv.AppendInt(b, int64(msg.Int8), 10)
+	}
+	b = append(b, `,"name":`...)
+	b = appendJSONString(b, msg.Name, true)
+	b = append(b, `,"ptrs":`...)
+	if msg.Ptrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Ptrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Ptrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b = strconv.AppendInt(b, int64(*msg.Ptrs[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if msg.SlicePtr != nil && len(*msg.SlicePtr) != 0 {
+		b = append(b, `,"slice_ptr":`...)
+		b = append(b, '[')
+		for i := range *msg.SlicePtr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendInt(b, int64((*msg.SlicePtr)[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if msg.StringPtr != nil && len(*msg.StringPtr) != 0 {
+		b = append(b, `,"string_ptr":`...)
+		b = appendJSONString(b, *msg.StringPtr, true)
+	}
+	if len(msg.Strings) != 0 {
+		b = append(b, `,"strings":`...)
+		b = append(b, '[')
+		for i := range msg.Strings {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, msg.Strings[i], true)
+		}
+		b = append(b, ']')
+	}
+	if msg.Struct.A != 0 || msg.Struct.B != "" {
+		b = append(b, `,"struct":`...)
+		b = append(b, '{')
+		b = append(b, `"A":`...)
+		b = strconv.AppendUint(b, uint64(msg.Struct.A), 10)
+		b = append(b, `,"B":`...)
+		b = appendJSONString(b, msg.Struct.B, true)
+		b = append(b, '}')
+	}
+	if func() bool {
+		for i := range msg.Structs {
+			if msg.Structs[i].C {
+				return true
+			}
+		}
+		return false
+	}() {
+		b = append(b, `,"structs":`...)
+		b = append(b, '[')
+		for i := range msg.Structs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"C":`...)
+			b = strconv.AppendBool(b, msg.Structs[i].C)
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	if int64(msg.Time.Seconds) != -62135596800 || msg.Time.Nanoseconds != 0 {
+		b = append(b, `,"time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *JSONTypeMessage) UnmarshalJSON(b []byte) error {
+	*msg = JSONTypeMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "int8":
+				msg.Int8 = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONInt(b, 8, false)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Int8 = int8(x)
+				}
+			case "name":
+				msg.Name = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Name = string(v)
+				}
+			case "int32":
+				msg.Int32 = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONInt(b, 32, false)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Int32 = int32(x)
+				}
+			case "int64":
+				msg.Int64 = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONInt(b, 64, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Int64 = x
+				}
+			case "Uint":
+				msg.Uint = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 0, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Uint = uint(x)
+				}
+			case "bool":
+				msg.Bool = false
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONBool(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Bool = x
+				}
+			case "bytes":
+				msg.Bytes = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Bytes, err = decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+				}
+			case "strings":
+				msg.Strings = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Strings = append(msg.Strings, "")
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							v, n, err := consumeJSONString(b)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							msg.Strings[len(msg.Strings)-1] = string(v)
+						}
+					}
+				}
+			case "string_ptr":
+				msg.StringPtr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.StringPtr = new(string)
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.StringPtr = string(v)
+				}
+			case "slice_ptr":
+				msg.SlicePtr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.SlicePtr = new([]int16)
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						*msg.SlicePtr = append(*msg.SlicePtr, 0)
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							x, n, err := consumeJSONInt(b, 16, false)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							(*msg.SlicePtr)[len((*msg.SlicePtr))-1] = int16(x)
+						}
+					}
+				}
+			case "ptrs":
+				msg.Ptrs = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Ptrs = append(msg.Ptrs, nil)
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							msg.Ptrs[len(msg.Ptrs)-1] = new(int32)
+							x, n, err := consumeJSONInt(b, 32, false)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							*msg.Ptrs[len(msg.Ptrs)-1] = int32(x)
+						}
+					}
+				}
+			case "arr":
+				msg.Arr = [2]uint16{}
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					i := 0
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						if i >= 2 {
+							return errArrayLength
+						}
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							x, n, err := consumeJSONUint(b, 16, false)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							msg.Arr[i] = uint16(x)
+						}
+						i++
+					}
+					if i != 2 {
+						return errArrayLength
+					}
+				}
+			case "time":
+				msg.Time.Seconds = 0
+				msg.Time.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONTime(v)
+					if err != nil {
+						return err
+					}
+					msg.Time.Seconds, msg.Time.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "duration":
+				msg.Duration.Seconds = 0
+				msg.Duration.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONDuration(v)
+					if err != nil {
+						return err
+					}
+					msg.Duration.Seconds, msg.Duration.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "struct":
+				msg.Struct.A = 0
+				msg.Struct.B = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "{}", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						key, n, err := consumeJSONKey(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						switch string(key) {
+						case "A":
+							msg.Struct.A = 0
+							if isJSONNull(b) {
+								b = b[4:]
+							} else {
+								x, n, err := consumeJSONUint(b, 32, false)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								msg.Struct.A = uint32(x)
+							}
+						case "B":
+							msg.Struct.B = ""
+							if isJSONNull(b) {
+								b = b[4:]
+							} else {
+								v, n, err := consumeJSONString(b)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								msg.Struct.B = string(v)
+							}
+						default:
+							n, err := skipJSONValue(b, 0)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+						}
+					}
+				}
+			case "structs":
+				msg.Structs = [1]struct {
+					C bool `json:"C"`
+				}{}
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					i := 0
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						if i >= 1 {
+							return errArrayLength
+						}
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							for first := true; ; first = false {
+								more, n, err := nextJSONElem(b, "{}", first)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								if !more {
+									break
+								}
+								key, n, err := consumeJSONKey(b)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								switch string(key) {
+								case "C":
+									msg.Structs[i].C = false
+									if isJSONNull(b) {
+										b = b[4:]
+									} else {
+										x, n, err := consumeJSONBool(b)
+										if err != nil {
+											return err
+										}
+										b = b[n:]
+										msg.Structs[i].C = x
+									}
+								default:
+									n, err := skipJSONValue(b, 0)
+									if err != nil {
+										return err
+									}
+									b = b[n:]
+								}
+							}
+						}
+						i++
+					}
+					if i != 1 {
+						return errArrayLength
+					}
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.JSONType, in the "@type" member, like amino.MarshalJSONAny.
+func (msg JSONTypeMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// CatMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.Cat
+type CatMessage struct {
+	Name  string `json:"Name"`
+	Lives int    `json:"Lives"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [CatMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg CatMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg CatMessage) AppendBinary(b []byte) ([]byte, error) {
+	// field number 1
+	switch {
+	case len(msg.Name) == 0:
+		// nothing to write
+	case len(msg.Name) <= maxVarint1:
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Name)))
+		b = append(b, msg.Name...)
+	case len(msg.Name) <= maxVarint2:
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Name)|0x80), byte(len(msg.Name)>>7))
+		b = append(b, msg.Name...)
+	default:
+		b = growBytes(b, 1+10+len(msg.Name))
+		b = append(b, (1<<3)|2 /* 0x0a */)
+		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Name)))
+		b = b[:len(b)+uvlen]
+		b = append(b, msg.Name...)
+	}
+	if msg.Lives != 0 {
+		// field number 2
+		b = append(b, (2<<3)|0 /* 0x10 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(msg.Lives))]
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *CatMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [CatMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *CatMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *CatMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = CatMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		case 2:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			msg.Lives = int(x)
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [CatMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg CatMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg CatMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *CatMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [CatMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg CatMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *CatMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = CatMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *CatMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		case 2:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			msg.Lives = int(x)
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.Cat, like amino.MarshalAny.
+func (msg CatMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg CatMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.Cat", msg)
+}
+
+// MarshalAnySized encodes the message like [CatMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg CatMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg CatMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.Cat.
+// The message is reset before decoding.
+func (msg *CatMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.Cat" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Cat")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *CatMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [CatMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg CatMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg CatMessage) AppendJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, `"Name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, `,"Lives":`...)
+	b = append(b, '"')
+	b = strconv.AppendInt(b, int64(msg.Lives), 10)
+	b = append(b, '"')
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [CatMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg CatMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg CatMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, `"Lives":`...)
+	b = append(b, '"')
+	b = strconv.AppendInt(b, int64(msg.Lives), 10)
+	b = append(b, '"')
+	b = append(b, `,"Name":`...)
+	b = appendJSONString(b, msg.Name, true)
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *CatMessage) UnmarshalJSON(b []byte) error {
+	*msg = CatMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Name":
+				msg.Name = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Name = string(v)
+				}
+			case "Lives":
+				msg.Lives = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONInt(b, 0, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Lives = int(x)
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.Cat, in the "@type" member, like amino.MarshalJSONAny.
+func (msg CatMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// DogMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.Dog
+type DogMessage struct {
+	Name string `json:"Name"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [DogMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg DogMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg DogMessage) AppendBinary(b []byte) ([]byte, error) {
+	// field number 1
+	switch {
+	case len(msg.Name) == 0:
+		// nothing to write
+	case len(msg.Name) <= maxVarint1:
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Name)))
+		b = append(b, msg.Name...)
+	case len(msg.Name) <= maxVarint2:
+		b = append(b, (1<<3)|2 /* 0x0a */, byte(len(msg.Name)|0x80), byte(len(msg.Name)>>7))
+		b = append(b, msg.Name...)
+	default:
+		b = growBytes(b, 1+10+len(msg.Name))
+		b = append(b, (1<<3)|2 /* 0x0a */)
+		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Name)))
+		b = b[:len(b)+uvlen]
+		b = append(b, msg.Name...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *DogMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [DogMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *DogMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *DogMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = DogMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [DogMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg DogMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg DogMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *DogMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [DogMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg DogMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *DogMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = DogMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *DogMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Name = aliasString(v)
+			} else {
+				msg.Name = string(v)
+			}
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.Dog, like amino.MarshalAny.
+func (msg DogMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg DogMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.Dog", msg)
+}
+
+// MarshalAnySized encodes the message like [DogMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg DogMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg DogMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.Dog.
+// The message is reset before decoding.
+func (msg *DogMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.Dog" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Dog")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *DogMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [DogMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg DogMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg DogMessage) AppendJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, `"Name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [DogMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg DogMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg DogMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	return msg.AppendJSON(b)
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *DogMessage) UnmarshalJSON(b []byte) error {
+	*msg = DogMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Name":
+				msg.Name = ""
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Name = string(v)
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.Dog, in the "@type" member, like amino.MarshalJSONAny.
+func (msg DogMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// FishMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.Fish
+type FishMessage struct{}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [FishMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg FishMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg FishMessage) AppendBinary(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *FishMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [FishMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *FishMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *FishMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = FishMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [FishMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg FishMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg FishMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *FishMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [FishMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg FishMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *FishMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = FishMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *FishMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.Fish, like amino.MarshalAny.
+func (msg FishMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg FishMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.Fish", msg)
+}
+
+// MarshalAnySized encodes the message like [FishMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg FishMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg FishMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.Fish.
+// The message is reset before decoding.
+func (msg *FishMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.Fish" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Fish")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *FishMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [FishMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg FishMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg FishMessage) AppendJSON(b []byte) ([]byte, error) {
+	b = append(b, "{}"...)
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [FishMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg FishMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg FishMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	return msg.AppendJSON(b)
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *FishMessage) UnmarshalJSON(b []byte) error {
+	*msg = FishMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.Fish, in the "@type" member, like amino.MarshalJSONAny.
+func (msg FishMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// ZooMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.Zoo
+type ZooMessage struct {
+	Star    interface{}   `json:"Star"`
+	Animals []interface{} `json:"Animals"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [ZooMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg ZooMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg ZooMessage) AppendBinary(b []byte) ([]byte, error) {
+	// field number 1
+	if msg.Star != nil {
+		b = append(b, (1<<3)|2 /* 0x0a */)
+		startLen := len(b)
+		var err error
+		if b, err = appendAnyAnimal(b, msg.Star); err != nil {
+			return nil, err
+		}
+		b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+	}
+	for _, el := range msg.Animals {
+		// field number 2
+		if el != nil {
+			b = append(b, (2<<3)|2 /* 0x12 */)
+			startLen := len(b)
+			var err error
+			if b, err = appendAnyAnimal(b, el); err != nil {
+				return nil, err
+			}
+			b = insertUvarint(b, startLen, uint64(len(b)-startLen))
+		} else {
+			// (nil, encode as 0-length)
+			b = append(b, (2<<3)|2 /* 0x12 */, 0)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *ZooMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [ZooMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *ZooMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *ZooMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = ZooMessage{}
+	for len(b) > 0 {
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) == 0 {
+				msg.Star = nil
+			} else if msg.Star, err = decodeAnyAnimal(v, alias); err != nil {
+				return err
+			}
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Animals = append(msg.Animals, nil)
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) == 0 {
+				msg.Animals[len(msg.Animals)-1] = nil
+			} else if msg.Animals[len(msg.Animals)-1], err = decodeAnyAnimal(v, alias); err != nil {
+				return err
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [ZooMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg ZooMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg ZooMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *ZooMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [ZooMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg ZooMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *ZooMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = ZooMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *ZooMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) == 0 {
+				msg.Star = nil
+			} else if msg.Star, err = decodeAnyAnimal(v, alias); err != nil {
+				return err
+			}
+		case 2:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Animals = append(msg.Animals, nil)
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) == 0 {
+				msg.Animals[len(msg.Animals)-1] = nil
+			} else if msg.Animals[len(msg.Animals)-1], err = decodeAnyAnimal(v, alias); err != nil {
+				return err
+			}
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.Zoo, like amino.MarshalAny.
+func (msg ZooMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg ZooMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.Zoo", msg)
+}
+
+// MarshalAnySized encodes the message like [ZooMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg ZooMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg ZooMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.Zoo.
+// The message is reset before decoding.
+func (msg *ZooMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.Zoo" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Zoo")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *ZooMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [ZooMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg ZooMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg ZooMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Star":`...)
+	if msg.Star == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONAnyAnimal(b, msg.Star, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, `,"Animals":`...)
+	if msg.Animals == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Animals {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Animals[i] == nil {
+				b = append(b, "null"...)
+			} else {
+				b, err = appendJSONAnyAnimal(b, msg.Animals[i], false)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [ZooMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg ZooMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg ZooMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Animals":`...)
+	if msg.Animals == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Animals {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Animals[i] == nil {
+				b = append(b, "null"...)
+			} else {
+				b, err = appendJSONAnyAnimal(b, msg.Animals[i], true)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"Star":`...)
+	if msg.Star == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONAnyAnimal(b, msg.Star, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *ZooMessage) UnmarshalJSON(b []byte) error {
+	*msg = ZooMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Star":
+				msg.Star = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := decodeJSONAnyAnimal(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Star = x
+				}
+			case "Animals":
+				msg.Animals = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Animals = append(msg.Animals, nil)
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							x, n, err := decodeJSONAnyAnimal(b)
+							if err != nil {
+								return err
+							}
+							b = b[n:]
+							msg.Animals[len(msg.Animals)-1] = x
+						}
+					}
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.Zoo, in the "@type" member, like amino.MarshalJSONAny.
+func (msg ZooMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// ---
+// encoding helpers
+
+// Non-generic version of slices.Grow.
+func growBytes(s []byte, n int) []byte {
+	if n -= cap(s) - len(s); n > 0 {
+		s = append(s[:cap(s)], make([]byte, n)...)[:len(s)]
+	}
+	return s
+}
+
+const (
+	// These are common when encoding lengths, and have fast paths instead of
+	// calling putUvarint.
+	maxVarint1 = (1 << 7) - 1
+	maxVarint2 = (1 << 14) - 1
+
+	len8tab = "" +
+		"\x00\x01\x02\x02\x03\x03\x03\x03\x04\x04\x04\x04\x04\x04\x04\x04" +
+		"\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05" +
+		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
+		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08"
+)
+
+// len64 returns the minimum number of bits required to represent x; the result is 0 for x == 0.
+// from math/bits.
+func len64(x uint64) (n int) {
+	if x >= 1<<32 {
+		x >>= 32
+		n = 32
+	}
+	if x >= 1<<16 {
+		x >>= 16
+		n += 16
+	}
+	if x >= 1<<8 {
+		x >>= 8
+		n += 8
+	}
+	return n + int(len8tab[x])
+}
+
+func uvarintSize(x uint64) int {
+	// +6 allows us to count any "remainder" as a full byte to be encoded.
+	return (len64(x) + 6) / 7
+}
+
+// putUvarint encodes a uint64 into buf and returns the number of bytes written.
+// If the buffer is too small, PutUvarint will panic.
+// Copied from package binary.
+func putUvarint(buf []byte, x uint64) int {
+	i := 0
+	for x >= 0x80 {
+		buf[i] = byte(x) | 0x80
+		x >>= 7
+		i++
+	}
+	buf[i] = byte(x)
+	return i + 1
+}
+
+// insertUvarint inserts the uvarint encoding of x into b at position pos,
+// shifting the following bytes.
+func insertUvarint(b []byte, pos int, x uint64) []byte {
+	n := uvarintSize(x)
+	if n == 0 {
+		n = 1
+	}
+	b = growBytes(b, n)[:len(b)+n]
+	copy(b[pos+n:], b[pos:len(b)-n])
+	putUvarint(b[pos:pos+n], x)
+	return b
+}
+
+// putVarint encodes an int64 into buf and returns the number of bytes written.
+// If the buffer is too small, PutVarint will panic.
+func putVarint(buf []byte, x int64) int {
+	ux := uint64(x) << 1
+	if x < 0 {
+		ux = ^ux
+	}
+	return putUvarint(buf, ux)
+}
+
+func putUint64(b []byte, v uint64) {
+	_ = b[7] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+	b[5] = byte(v >> 40)
+	b[6] = byte(v >> 48)
+	b[7] = byte(v >> 56)
+}
+
+func putUint32(b []byte, v uint32) {
+	_ = b[3] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+}
+
+// ---
+// decoding helpers
+
+var (
+	errUnexpectedEOF = errors.New("tomino: unexpected end of input")
+	errOverflow      = errors.New("tomino: integer overflows its type")
+	errInvalidBool   = errors.New("tomino: invalid bool")
+	errWireType      = errors.New("tomino: invalid wire type")
+	errArrayLength   = errors.New("tomino: invalid array length")
+	errTooLarge      = errors.New("tomino: message exceeds the maximum size")
+	errTrailingData  = errors.New("tomino: trailing data after the message")
+	errInvalidAny    = errors.New("tomino: invalid google.protobuf.Any")
+	errNilAny        = errors.New("tomino: nil pointer in interface")
+)
+
+// consumeUvarint decodes an uvarint from b, and returns it together with the
+// number of bytes read.
+func consumeUvarint(b []byte) (uint64, int, error) {
+	var x uint64
+	for i := 0; i < 10; i++ {
+		if i >= len(b) {
+			return 0, 0, errUnexpectedEOF
+		}
+		c := b[i]
+		if i == 9 && c > 1 {
+			break
+		}
+		x |= uint64(c&0x7f) << uint(7*i)
+		if c < 0x80 {
+			return x, i + 1, nil
+		}
+	}
+	return 0, 0, errOverflow
+}
+
+// consumeVarint decodes a zig-zag encoded varint from b.
+func consumeVarint(b []byte) (int64, int, error) {
+	ux, n, err := consumeUvarint(b)
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, n, err
+}
+
+// consumeTag decodes the tag of a record, returning its field number and
+// wire type.
+func consumeTag(b []byte) (uint64, int, int, error) {
+	x, n, err := consumeUvarint(b)
+	return x >> 3, int(x & 7), n, err
+}
+
+// consumeBool decodes a bool from b. Like in amino, it must be a single byte
+// of value 0 or 1.
+func consumeBool(b []byte) (bool, int, error) {
+	if len(b) == 0 {
+		return false, 0, errUnexpectedEOF
+	}
+	if b[0] > 1 {
+		return false, 0, errInvalidBool
+	}
+	return b[0] == 1, 1, nil
+}
+
+func consumeFixed64(b []byte) (uint64, int, error) {
+	if len(b) < 8 {
+		return 0, 0, errUnexpectedEOF
+	}
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56, 8, nil
+}
+
+func consumeFixed32(b []byte) (uint32, int, error) {
+	if len(b) < 4 {
+		return 0, 0, errUnexpectedEOF
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24, 4, nil
+}
+
+// consumeSized returns the message in b, prefixed by its length as a uvarint.
+// The length must cover the rest of b, and it must not be greater than
+// maxSize, unless maxSize is 0.
+func consumeSized(b []byte, maxSize int) ([]byte, error) {
+	l, n, err := consumeUvarint(b)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case maxSize > 0 && l > uint64(maxSize):
+		return nil, errTooLarge
+	case l > uint64(len(b)-n):
+		return nil, errUnexpectedEOF
+	case l < uint64(len(b)-n):
+		return nil, errTrailingData
+	}
+	return b[n:], nil
+}
+
+// maxStreamSize is the maximum size of the messages read by ReadMessageFrom.
+const maxStreamSize = 64 << 20
+
+// streamReader reads a message from a stream for ReadMessageFrom, without
+// reading past its end. The embedded messages are decoded as they are read;
+// only the other values are read whole, so that a length prefix not followed
+// by the data doesn't cause a large allocation.
+type streamReader struct {
+	r io.Reader
+	// n is the number of bytes consumed from r.
+	n int64
+	// limits are the values of n where the message and the embedded messages
+	// being read end, from the outermost to the innermost.
+	limits []int64
+	// buf[pos:end] are the bytes read from r and not consumed yet.
+	buf      [512]byte
+	pos, end int
+	// val holds the tag and the value of the last record read.
+	val []byte
+}
+
+// readByte consumes a byte of the innermost message.
+func (s *streamReader) readByte() (byte, error) {
+	if len(s.limits) > 0 && s.n >= s.limits[len(s.limits)-1] {
+		return 0, errUnexpectedEOF
+	}
+	if s.pos == s.end {
+		if err := s.fill(); err != nil {
+			return 0, err
+		}
+	}
+	c := s.buf[s.pos]
+	s.pos++
+	s.n++
+	return c, nil
+}
+
+// fill reads more bytes into buf, once they have all been consumed. Until the
+// length of the message is known, the bytes are read one at a time.
+func (s *streamReader) fill() error {
+	want := int64(1)
+	if len(s.limits) > 0 {
+		want = s.limits[0] - s.n
+		if want > int64(len(s.buf)) {
+			want = int64(len(s.buf))
+		}
+	}
+	m, err := io.ReadAtLeast(s.r, s.buf[:want], 1)
+	s.pos, s.end = 0, m
+	if err == io.EOF && s.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// uvarint consumes a uvarint, appending its bytes to val.
+func (s *streamReader) uvarint() (uint64, error) {
+	start := len(s.val)
+	for i := 0; i < 10; i++ {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		s.val = append(s.val, c)
+		if c < 0x80 {
+			break
+		}
+	}
+	x, _, err := consumeUvarint(s.val[start:])
+	return x, err
+}
+
+// tag consumes the tag of a record, returning its field number and wire type.
+func (s *streamReader) tag() (uint64, int, error) {
+	s.val = s.val[:0]
+	x, err := s.uvarint()
+	return x >> 3, int(x & 7), err
+}
+
+// record consumes the value of a record of wire type wt, after its tag, and
+// returns it. The value is only valid until the next call to tag.
+func (s *streamReader) record(wt int) ([]byte, error) {
+	start := len(s.val)
+	var l uint64
+	switch wt {
+	case 0:
+		_, err := s.uvarint()
+		return s.val[start:], err
+	case 1:
+		l = 8
+	case 2:
+		var err error
+		if l, err = s.uvarint(); err != nil {
+			return nil, err
+		}
+	case 5:
+		l = 4
+	default:
+		return nil, errWireType
+	}
+	if l > uint64(s.limits[len(s.limits)-1]-s.n) {
+		return nil, errUnexpectedEOF
+	}
+	for l > 0 {
+		if s.pos == s.end {
+			if err := s.fill(); err != nil {
+				return nil, err
+			}
+		}
+		k := s.end - s.pos
+		if uint64(k) > l {
+			k = int(l)
+		}
+		s.val = append(s.val, s.buf[s.pos:s.pos+k]...)
+		s.pos += k
+		s.n += int64(k)
+		l -= uint64(k)
+	}
+	return s.val[start:], nil
+}
+
+// push consumes the length prefix of a message, which is then read until pop
+// is called. The outermost message must not be larger than maxStreamSize.
+func (s *streamReader) push() error {
+	s.val = s.val[:0]
+	l, err := s.uvarint()
+	if err != nil {
+		return err
+	}
+	if len(s.limits) == 0 {
+		if l > maxStreamSize {
+			return errTooLarge
+		}
+	} else if l > uint64(s.limits[len(s.limits)-1]-s.n) {
+		return errUnexpectedEOF
+	}
+	s.limits = append(s.limits, s.n+int64(l))
+	return nil
+}
+
+// pop ends the innermost message, which must have been read entirely.
+func (s *streamReader) pop() {
+	s.limits = s.limits[:len(s.limits)-1]
+}
+
+// more returns whether the innermost message has more bytes to read.
+func (s *streamReader) more() bool {
+	return s.n < s.limits[len(s.limits)-1]
+}
+
+// aliasBytes returns v as a decoded byte slice, without copying it.
+// Like when copying, empty slices are decoded as nil.
+func aliasBytes(v []byte) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return v[:len(v):len(v)]
+}
+
+// aliasString returns v as a string, without copying it.
+func aliasString(v []byte) string {
+	return unsafe.String(unsafe.SliceData(v), len(v))
+}
+
+// consumeBytes decodes a length-prefixed value from b. The returned slice
+// aliases b.
+func consumeBytes(b []byte) ([]byte, int, error) {
+	l, n, err := consumeUvarint(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if l > uint64(len(b)-n) {
+		return nil, 0, errUnexpectedEOF
+	}
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+// skipField returns the size of the value of an unknown field.
+func skipField(b []byte, wt int) (int, error) {
+	switch wt {
+	case 0:
+		_, n, err := consumeUvarint(b)
+		return n, err
+	case 1:
+		_, n, err := consumeFixed64(b)
+		return n, err
+	case 2:
+		_, n, err := consumeBytes(b)
+		return n, err
+	case 5:
+		_, n, err := consumeFixed32(b)
+		return n, err
+	}
+	return 0, errWireType
+}
+
+// ---
+// google.protobuf.Any
+
+// appendAny appends to b the google.protobuf.Any holding the message m,
+// identified by url. Like in amino, the value is omitted if it is empty.
+func appendAny(b []byte, url string, m interface{ AppendBinary([]byte) ([]byte, error) }) ([]byte, error) {
+	b = append(b, (1<<3)|2 /* 0x0a */)
+	b = insertUvarint(b, len(b), uint64(len(url)))
+	b = append(b, url...)
+	b = append(b, (2<<3)|2 /* 0x12 */)
+	start := len(b)
+	b, err := m.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == start {
+		return b[:start-1], nil
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// consumeAny decodes the google.protobuf.Any in b, returning its type URL and
+// its value. Like in amino, the type URL must come first, followed by the
+// value if it is not empty, and there can be no other fields.
+func consumeAny(b []byte) ([]byte, []byte, error) {
+	if len(b) == 0 || b[0] != (1<<3)|2 {
+		return nil, nil, errInvalidAny
+	}
+	url, n, err := consumeBytes(b[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	b = b[1+n:]
+	if len(b) == 0 {
+		return url, nil, nil
+	}
+	if b[0] != (2<<3)|2 {
+		return nil, nil, errInvalidAny
+	}
+	v, n, err := consumeBytes(b[1:])
+	switch {
+	case err != nil:
+		return nil, nil, err
+	case 1+n != len(b):
+		return nil, nil, errTrailingData
+	}
+	return url, v, nil
+}
+
+// unknownTypeError is returned when decoding an interface holding a type URL
+// which is not one of its implementations.
+func unknownTypeError(url []byte, iface string) error {
+	return fmt.Errorf("tomino: unknown type URL %q for %s", url, iface)
+}
+
+// notImplementationError is returned when encoding an interface holding a
+// value which is not one of its implementations.
+func notImplementationError(v interface{}, iface string) error {
+	return fmt.Errorf("tomino: %T is not an implementation of %s", v, iface)
+}
+
+// appendAnyAnimal appends v to b as a google.protobuf.Any. v must be one
+// of the implementations of the interface
+// github.com/thehowl/tomino/tests/golden.Animal
+func appendAnyAnimal(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case CatMessage:
+		return appendAny(b, "/tomtypes.Cat", v)
+	case *CatMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return appendAny(b, "/tomtypes.Cat", v)
+	case DogMessage:
+		return appendAny(b, "/tomtypes.Dog", v)
+	case *DogMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return appendAny(b, "/tomtypes.Dog", v)
+	case FishMessage:
+		return appendAny(b, "/tomtypes.Fish", v)
+	case *FishMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return appendAny(b, "/tomtypes.Fish", v)
+	}
+	return nil, notImplementationError(v, "github.com/thehowl/tomino/tests/golden.Animal")
+}
+
+// decodeAnyAnimal decodes the google.protobuf.Any in b, holding one of
+// the implementations of the interface
+// github.com/thehowl/tomino/tests/golden.Animal
+// The messages whose types only implement the interface with a pointer
+// receiver are decoded as pointers.
+func decodeAnyAnimal(b []byte, alias bool) (interface{}, error) {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return nil, err
+	}
+	switch string(url) {
+	case "/tomtypes.Cat":
+		var m CatMessage
+		if err := m.unmarshalBinary(v, alias); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Dog":
+		m := new(DogMessage)
+		if err := m.unmarshalBinary(v, alias); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Fish":
+		var m FishMessage
+		if err := m.unmarshalBinary(v, alias); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return nil, unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Animal")
+}
+
+// ---
+// JSON encoding helpers
+
+var (
+	errJSONFloat = errors.New("tomino: cannot encode NaN or infinity in JSON")
+	errJSONTime  = errors.New("tomino: time out of range")
+)
+
+const (
+	jsonHex = "0123456789abcdef"
+
+	// The range of times supported by amino: years 1 through 9999.
+	minJSONTime = -62135596800
+	maxJSONTime = 253402300800
+)
+
+// appendJSONString appends s as a JSON string, escaped like encoding/json
+// does: invalid UTF-8 is replaced with U+FFFD, and the HTML characters <, >
+// and &, as well as U+2028 and U+2029, are escaped.
+// U+FFFD is written as \ufffd, unless canonical is set: then it is written
+// as is, like encoding/json does when sorting the output of amino.
+func appendJSONString(b []byte, s string, canonical bool) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', jsonHex[c>>4], jsonHex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1 && canonical:
+			b = append(b, s[start:i]...)
+			b = append(b, "\uFFFD"...)
+		case r == utf8.RuneError && size == 1:
+			b = append(b, s[start:i]...)
+			b = append(b, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', jsonHex[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// appendJSONBytes appends p as a base64-encoded JSON string.
+func appendJSONBytes(b, p []byte) []byte {
+	n := base64.StdEncoding.EncodedLen(len(p))
+	b = append(b, '"')
+	b = growBytes(b, n)[:len(b)+n]
+	base64.StdEncoding.Encode(b[len(b)-n:], p)
+	return append(b, '"')
+}
+
+// appendJSONFloat appends f, which has the given bit size, formatted like
+// encoding/json does.
+func appendJSONFloat(b []byte, f float64, bits int) ([]byte, error) {
+	// f-f is NaN for NaN and infinities.
+	if f-f != 0 {
+		return nil, errJSONFloat
+	}
+	abs := f
+	if abs < 0 {
+		abs = -abs
+	}
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9.
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b, nil
+}
+
+// appendJSONNanos appends the fraction of a second of ns nanoseconds, with 0,
+// 3, 6 or 9 digits, as amino does for times and durations.
+func appendJSONNanos(b []byte, ns uint32) []byte {
+	if ns == 0 {
+		return b
+	}
+	digits := 9
+	for digits > 3 && ns%1000 == 0 {
+		ns /= 1000
+		digits -= 3
+	}
+	b = append(b, '.')
+	b = growBytes(b, digits)[:len(b)+digits]
+	for i := len(b) - 1; digits > 0; i, digits = i-1, digits-1 {
+		b[i] = byte('0' + ns%10)
+		ns /= 10
+	}
+	return b
+}
+
+// appendJSONTime appends the time at s seconds and ns nanoseconds since the
+// Unix epoch as an RFC 3339 string in UTC, like "2006-01-02T15:04:05.999Z".
+func appendJSONTime(b []byte, s int64, ns int32) ([]byte, error) {
+	if s < minJSONTime || s >= maxJSONTime || ns < 0 || ns >= 1e9 {
+		return nil, errJSONTime
+	}
+	b = append(b, '"')
+	b = append(b, time.Unix(s, int64(ns)).UTC().Format("2006-01-02T15:04:05")...)
+	b = appendJSONNanos(b, uint32(ns))
+	return append(b, 'Z', '"'), nil
+}
+
+// appendJSONDuration appends the duration of s seconds and ns nanoseconds as
+// a string of seconds, like "-1.5s".
+func appendJSONDuration(b []byte, s int64, ns int32) []byte {
+	d := s*1e9 + int64(ns)
+	u := uint64(d)
+	b = append(b, '"')
+	if d < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	b = strconv.AppendUint(b, u/1e9, 10)
+	b = appendJSONNanos(b, uint32(u%1e9))
+	return append(b, 's', '"')
+}
+
+// ---
+// JSON decoding helpers
+
+var (
+	errJSONSyntax = errors.New("tomino: invalid JSON")
+	errJSONValue  = errors.New("tomino: invalid JSON value for the type")
+)
+
+// maxJSONDepth is the maximum nesting of skipped JSON values, as in
+// encoding/json.
+const maxJSONDepth = 10000
+
+// jsonSpace returns the number of whitespace characters at the start of b.
+func jsonSpace(b []byte) int {
+	n := 0
+	for n < len(b) && (b[n] == ' ' || b[n] == '\t' || b[n] == '\n' || b[n] == '\r') {
+		n++
+	}
+	return n
+}
+
+// isJSONNull returns whether b starts with null.
+func isJSONNull(b []byte) bool {
+	return len(b) >= 4 && string(b[:4]) == "null"
+}
+
+// nextJSONElem consumes what precedes the next element of an object or array,
+// delimited by the two characters in delims: the opening character for the
+// first element, or a comma for the following ones, and the whitespace
+// around it. If there are no more elements, it consumes the closing character
+// and returns false.
+func nextJSONElem(b []byte, delims string, first bool) (bool, int, error) {
+	n := 0
+	if first {
+		switch {
+		case len(b) == 0:
+			return false, 0, errUnexpectedEOF
+		case b[0] != delims[0]:
+			return false, 0, errJSONValue
+		}
+		n = 1
+	}
+	n += jsonSpace(b[n:])
+	switch {
+	case n == len(b):
+		return false, 0, errUnexpectedEOF
+	case b[n] == delims[1]:
+		return false, n + 1, nil
+	case first:
+		return true, n, nil
+	case b[n] != ',':
+		return false, 0, errJSONSyntax
+	}
+	n++
+	return true, n + jsonSpace(b[n:]), nil
+}
+
+// consumeJSONKey decodes the key of an object member from b, consuming the
+// colon following it and the whitespace around it.
+func consumeJSONKey(b []byte) ([]byte, int, error) {
+	key, n, err := consumeJSONString(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	n += jsonSpace(b[n:])
+	if n == len(b) || b[n] != ':' {
+		return nil, 0, errJSONSyntax
+	}
+	n++
+	return key, n + jsonSpace(b[n:]), nil
+}
+
+// consumeJSONString decodes a JSON string from b. The returned slice aliases
+// b if the string contains no escape sequences and is ASCII.
+func consumeJSONString(b []byte) ([]byte, int, error) {
+	switch {
+	case len(b) == 0:
+		return nil, 0, errUnexpectedEOF
+	case b[0] != '"':
+		return nil, 0, errJSONValue
+	}
+	for i := 1; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == '"':
+			return b[1:i], i + 1, nil
+		case c == '\\' || c < 0x20 || c >= utf8.RuneSelf:
+			return unescapeJSONString(b, i)
+		}
+	}
+	return nil, 0, errUnexpectedEOF
+}
+
+// unescapeJSONString decodes the JSON string at the start of b, where b[i] is
+// the first character which needs to be unescaped or validated.
+// Like encoding/json, it replaces invalid UTF-8 and surrogates with U+FFFD.
+func unescapeJSONString(b []byte, i int) ([]byte, int, error) {
+	s := append([]byte(nil), b[1:i]...)
+	for i < len(b) {
+		switch c := b[i]; {
+		case c == '"':
+			return s, i + 1, nil
+		case c < 0x20:
+			return nil, 0, errJSONSyntax
+		case c == '\\':
+			if i+1 == len(b) {
+				return nil, 0, errUnexpectedEOF
+			}
+			c = b[i+1]
+			i += 2
+			switch c {
+			case '"', '\\', '/':
+				s = append(s, c)
+			case 'b':
+				s = append(s, '\b')
+			case 'f':
+				s = append(s, '\f')
+			case 'n':
+				s = append(s, '\n')
+			case 'r':
+				s = append(s, '\r')
+			case 't':
+				s = append(s, '\t')
+			case 'u':
+				r := jsonHexRune(b[i:])
+				if r < 0 {
+					return nil, 0, errJSONSyntax
+				}
+				i += 4
+				if 0xd800 <= r && r < 0xe000 {
+					// combine the surrogate with the following one, if valid.
+					r2 := rune(-1)
+					if r < 0xdc00 && i+1 < len(b) && b[i] == '\\' && b[i+1] == 'u' {
+						r2 = jsonHexRune(b[i+2:])
+					}
+					if 0xdc00 <= r2 && r2 < 0xe000 {
+						r = (r-0xd800)<<10 | (r2 - 0xdc00) + 0x10000
+						i += 6
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				var buf [utf8.UTFMax]byte
+				s = append(s, buf[:utf8.EncodeRune(buf[:], r)]...)
+			default:
+				return nil, 0, errJSONSyntax
+			}
+		case c < utf8.RuneSelf:
+			s = append(s, c)
+			i++
+		default:
+			r, size := utf8.DecodeRune(b[i:])
+			if r == utf8.RuneError && size == 1 {
+				s = append(s, "\uFFFD"...)
+			} else {
+				s = append(s, b[i:i+size]...)
+			}
+			i += size
+		}
+	}
+	return nil, 0, errUnexpectedEOF
+}
+
+// jsonHexRune decodes the 4 hexadecimal digits at the start of b, returning
+// -1 if they are not valid.
+func jsonHexRune(b []byte) rune {
+	if len(b) < 4 {
+		return -1
+	}
+	var r rune
+	for _, c := range b[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return -1
+		}
+		r = r<<4 | rune(c)
+	}
+	return r
+}
+
+// consumeJSONBool decodes a JSON boolean from b.
+func consumeJSONBool(b []byte) (bool, int, error) {
+	switch {
+	case len(b) >= 4 && string(b[:4]) == "true":
+		return true, 4, nil
+	case len(b) >= 5 && string(b[:5]) == "false":
+		return false, 5, nil
+	}
+	return false, 0, errJSONValue
+}
+
+// jsonNumber returns the length of the JSON number at the start of b, or 0 if
+// there is none. If integer is set, the fraction and exponent are not read.
+func jsonNumber(b []byte, integer bool) int {
+	n := 0
+	if n < len(b) && b[n] == '-' {
+		n++
+	}
+	switch {
+	case n < len(b) && b[n] == '0':
+		n++
+	case n < len(b) && '1' <= b[n] && b[n] <= '9':
+		n += jsonDigits(b[n:])
+	default:
+		return 0
+	}
+	if integer {
+		return n
+	}
+	if n < len(b) && b[n] == '.' {
+		d := jsonDigits(b[n+1:])
+		if d == 0 {
+			return 0
+		}
+		n += 1 + d
+	}
+	if n < len(b) && (b[n] == 'e' || b[n] == 'E') {
+		n++
+		if n < len(b) && (b[n] == '+' || b[n] == '-') {
+			n++
+		}
+		d := jsonDigits(b[n:])
+		if d == 0 {
+			return 0
+		}
+		n += d
+	}
+	return n
+}
+
+// jsonDigits returns the number of decimal digits at the start of b.
+func jsonDigits(b []byte) int {
+	n := 0
+	for n < len(b) && '0' <= b[n] && b[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// jsonInteger returns the JSON integer at the start of b. If quoted is set,
+// it must be in a string, as amino does for 64-bit integers.
+func jsonInteger(b []byte, quoted bool) ([]byte, int, error) {
+	start := 0
+	if quoted {
+		if len(b) == 0 || b[0] != '"' {
+			return nil, 0, errJSONValue
+		}
+		start = 1
+	}
+	n := start + jsonNumber(b[start:], true)
+	if n == start {
+		return nil, 0, errJSONValue
+	}
+	s := b[start:n]
+	if quoted {
+		if n == len(b) || b[n] != '"' {
+			return nil, 0, errJSONValue
+		}
+		n++
+	}
+	return s, n, nil
+}
+
+// consumeJSONInt decodes a signed integer of the given bit size from b.
+func consumeJSONInt(b []byte, bitSize int, quoted bool) (int64, int, error) {
+	s, n, err := jsonInteger(b, quoted)
+	if err != nil {
+		return 0, 0, err
+	}
+	x, err := strconv.ParseInt(string(s), 10, bitSize)
+	if err != nil {
+		return 0, 0, errOverflow
+	}
+	return x, n, nil
+}
+
+// consumeJSONUint decodes an unsigned integer of the given bit size from b.
+func consumeJSONUint(b []byte, bitSize int, quoted bool) (uint64, int, error) {
+	s, n, err := jsonInteger(b, quoted)
+	if err != nil {
+		return 0, 0, err
+	}
+	x, err := strconv.ParseUint(string(s), 10, bitSize)
+	if err != nil {
+		return 0, 0, errOverflow
+	}
+	return x, n, nil
+}
+
+// consumeJSONFloat decodes a floating point of the given bit size from b.
+func consumeJSONFloat(b []byte, bitSize int) (float64, int, error) {
+	n := jsonNumber(b, false)
+	if n == 0 {
+		return 0, 0, errJSONValue
+	}
+	x, err := strconv.ParseFloat(string(b[:n]), bitSize)
+	if err != nil {
+		return 0, 0, errJSONValue
+	}
+	return x, n, nil
+}
+
+// decodeJSONBase64 decodes the base64-encoded bytes in the JSON string v.
+// Empty strings are decoded as nil.
+func decodeJSONBase64(v []byte) ([]byte, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	p := make([]byte, base64.StdEncoding.DecodedLen(len(v)))
+	n, err := base64.StdEncoding.Decode(p, v)
+	if err != nil {
+		return nil, errJSONValue
+	}
+	return p[:n], nil
+}
+
+// parseJSONTime parses the time in v, an RFC 3339 string in UTC, returning
+// its seconds and nanoseconds since the Unix epoch.
+func parseJSONTime(v []byte) (int64, int32, error) {
+	if len(v) == 0 || v[len(v)-1] != 'Z' {
+		return 0, 0, errJSONValue
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(v))
+	if err != nil {
+		return 0, 0, errJSONValue
+	}
+	return t.Unix(), int32(t.Nanosecond()), nil
+}
+
+// parseJSONDuration parses the duration in v, a string of seconds with up to
+// 9 fractional digits, like "-1.5s", returning its seconds and nanoseconds.
+func parseJSONDuration(v []byte) (int64, int32, error) {
+	if len(v) < 2 || v[len(v)-1] != 's' {
+		return 0, 0, errJSONValue
+	}
+	v = v[:len(v)-1]
+	neg := v[0] == '-'
+	if neg {
+		v = v[1:]
+	}
+	d := jsonDigits(v)
+	if d == 0 {
+		return 0, 0, errJSONValue
+	}
+	var s, ns uint64
+	for _, c := range v[:d] {
+		s = s*10 + uint64(c-'0')
+		if s > (1<<63)/1000000000 {
+			return 0, 0, errOverflow
+		}
+	}
+	v = v[d:]
+	if len(v) > 0 {
+		if v[0] != '.' || len(v) == 1 || len(v) > 10 || jsonDigits(v[1:]) != len(v)-1 {
+			return 0, 0, errJSONValue
+		}
+		for i := 1; i < 10; i++ {
+			ns *= 10
+			if i < len(v) {
+				ns += uint64(v[i] - '0')
+			}
+		}
+	}
+	u := s*1e9 + ns
+	if u > 1<<63 || u == 1<<63 && !neg {
+		return 0, 0, errOverflow
+	}
+	x := int64(u)
+	if neg {
+		x = -x
+	}
+	return x / 1e9, int32(x % 1e9), nil
+}
+
+// skipJSONValue returns the size of the JSON value at the start of b, which
+// is nested in depth objects or arrays.
+func skipJSONValue(b []byte, depth int) (int, error) {
+	if len(b) == 0 {
+		return 0, errUnexpectedEOF
+	}
+	switch b[0] {
+	case '"':
+		_, n, err := consumeJSONString(b)
+		return n, err
+	case '{', '[':
+		if depth >= maxJSONDepth {
+			return 0, errJSONSyntax
+		}
+		delims := "[]"
+		if b[0] == '{' {
+			delims = "{}"
+		}
+		n := 0
+		for first := true; ; first = false {
+			more, m, err := nextJSONElem(b[n:], delims, first)
+			if err != nil {
+				return 0, err
+			}
+			n += m
+			if !more {
+				return n, nil
+			}
+			if delims[0] == '{' {
+				if _, m, err = consumeJSONKey(b[n:]); err != nil {
+					return 0, err
+				}
+				n += m
+			}
+			if m, err = skipJSONValue(b[n:], depth+1); err != nil {
+				return 0, err
+			}
+			n += m
+		}
+	case 't':
+		if len(b) >= 4 && string(b[:4]) == "true" {
+			return 4, nil
+		}
+	case 'f':
+		if len(b) >= 5 && string(b[:5]) == "false" {
+			return 5, nil
+		}
+	case 'n':
+		if isJSONNull(b) {
+			return 4, nil
+		}
+	default:
+		if n := jsonNumber(b, false); n > 0 {
+			return n, nil
+		}
+	}
+	return 0, errJSONSyntax
+}
+
+// ---
+// JSON encoding of interfaces
+
+var errJSONTypeURL = errors.New("tomino: missing @type in the JSON value of an interface")
+
+// consumeJSONTypeURL returns the "@type" member of the JSON object at the
+// start of b, which is the value of an interface, and the size of the object.
+func consumeJSONTypeURL(b []byte) ([]byte, int, error) {
+	var url []byte
+	n := 0
+	for first := true; ; first = false {
+		more, m, err := nextJSONElem(b[n:], "{}", first)
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+		if !more {
+			break
+		}
+		key, m, err := consumeJSONKey(b[n:])
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+		if string(key) == "@type" && url == nil {
+			url, m, err = consumeJSONString(b[n:])
+		} else {
+			m, err = skipJSONValue(b[n:], 1)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+	}
+	if url == nil {
+		return nil, 0, errJSONTypeURL
+	}
+	return url, n, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg URLMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/url.URL"`...)
+		b = append(b, `,"ForceQuery":`...)
+		b = strconv.AppendBool(b, msg.ForceQuery)
+		b = append(b, `,"Fragment":`...)
+		b = appendJSONString(b, msg.Fragment, true)
+		b = append(b, `,"Host":`...)
+		b = appendJSONString(b, msg.Host, true)
+		b = append(b, `,"OmitHost":`...)
+		b = strconv.AppendBool(b, msg.OmitHost)
+		b = append(b, `,"Opaque":`...)
+		b = appendJSONString(b, msg.Opaque, true)
+		b = append(b, `,"Path":`...)
+		b = appendJSONString(b, msg.Path, true)
+		b = append(b, `,"RawFragment":`...)
+		b = appendJSONString(b, msg.RawFragment, true)
+		b = append(b, `,"RawPath":`...)
+		b = appendJSONString(b, msg.RawPath, true)
+		b = append(b, `,"RawQuery":`...)
+		b = appendJSONString(b, msg.RawQuery, true)
+		b = append(b, `,"Scheme":`...)
+		b = appendJSONString(b, msg.Scheme, true)
+		b = append(b, `,"User":`...)
+		if msg.User == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, "{}"...)
+		}
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/url.URL"`...)
+	b = append(b, `,"Scheme":`...)
+	b = appendJSONString(b, msg.Scheme, false)
+	b = append(b, `,"Opaque":`...)
+	b = appendJSONString(b, msg.Opaque, false)
+	b = append(b, `,"User":`...)
+	if msg.User == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, "{}"...)
+	}
+	b = append(b, `,"Host":`...)
+	b = appendJSONString(b, msg.Host, false)
+	b = append(b, `,"Path":`...)
+	b = appendJSONString(b, msg.Path, false)
+	b = append(b, `,"RawPath":`...)
+	b = appendJSONString(b, msg.RawPath, false)
+	b = append(b, `,"OmitHost":`...)
+	b = strconv.AppendBool(b, msg.OmitHost)
+	b = append(b, `,"ForceQuery":`...)
+	b = strconv.AppendBool(b, msg.ForceQuery)
+	b = append(b, `,"RawQuery":`...)
+	b = appendJSONString(b, msg.RawQuery, false)
+	b = append(b, `,"Fragment":`...)
+	b = appendJSONString(b, msg.Fragment, false)
+	b = append(b, `,"RawFragment":`...)
+	b = appendJSONString(b, msg.RawFragment, false)
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg TestTypeMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.TestType"`...)
+		b = append(b, `,"Byte":`...)
+		b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+		b = append(b, `,"ByteArr":`...)
+		if msg.ByteArr == nil {
+			b = append(b, "null"...)
+		} else {
+			b = appendJSONBytes(b, (*msg.ByteArr)[:])
+		}
+		b = append(b, `,"Bytes":`...)
+		if msg.Bytes == nil {
+			b = append(b, "null"...)
+		} else {
+			b = appendJSONBytes(b, msg.Bytes)
+		}
+		b = append(b, `,"Duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+		b = append(b, `,"FixedUint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+		b = append(b, '"')
+		b = append(b, `,"IntPtr":`...)
+		if msg.IntPtr == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+			b = append(b, '"')
+		}
+		b = append(b, `,"Slice":`...)
+		if msg.Slice == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.Slice {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = append(b, '{')
+				b = append(b, `"A":`...)
+				b = append(b, '"')
+				b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+				b = append(b, '"')
+				b = append(b, `,"B":`...)
+				b = append(b, '"')
+				b = strconv.AppendInt(b, int64(msg.Slice[i].B), 10)
+				b = append(b, '"')
+				b = append(b, '}')
+			}
+			b = append(b, ']')
+		}
+		b = append(b, `,"Time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, `,"ZeroArr":`...)
+		b = appendJSONBytes(b, msg.ZeroArr[:])
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.TestType"`...)
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, `,"B":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].B), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg FloatTypeMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.FloatType"`...)
+		b = append(b, `,"Float":`...)
+		b, err = appendJSONFloat(b, float64(msg.Float), 64)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, `,"Float32":`...)
+		b, err = appendJSONFloat(b, float64(msg.Float32), 32)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, `,"FloatArr":`...)
+		b = append(b, '[')
+		for i := range msg.FloatArr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b, err = appendJSONFloat(b, float64(msg.FloatArr[i]), 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+		b = append(b, `,"FloatPtr":`...)
+		if msg.FloatPtr == nil {
+			b = append(b, "null"...)
+		} else {
+			b, err = appendJSONFloat(b, float64(*msg.FloatPtr), 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, `,"FloatPtrs":`...)
+		if msg.FloatPtrs == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.FloatPtrs {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if msg.FloatPtrs[i] == nil {
+					b = append(b, "null"...)
+					continue
+				}
+				b, err = appendJSONFloat(b, float64(*msg.FloatPtrs[i]), 64)
+				if err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, `,"Floats":`...)
+		if msg.Floats == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.Floats {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b, err = appendJSONFloat(b, float64(msg.Floats[i]), 32)
+				if err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.FloatType"`...)
+	b = append(b, `,"Float":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float), 64)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Float32":`...)
+	b, err = appendJSONFloat(b, float64(msg.Float32), 32)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"FloatPtr":`...)
+	if msg.FloatPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONFloat(b, float64(*msg.FloatPtr), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, `,"Floats":`...)
+	if msg.Floats == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Floats {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b, err = appendJSONFloat(b, float64(msg.Floats[i]), 32)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"FloatArr":`...)
+	b = append(b, '[')
+	for i := range msg.FloatArr {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b, err = appendJSONFloat(b, float64(msg.FloatArr[i]), 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, ']')
+	b = append(b, `,"FloatPtrs":`...)
+	if msg.FloatPtrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.FloatPtrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.FloatPtrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b, err = appendJSONFloat(b, float64(*msg.FloatPtrs[i]), 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg JSONTypeMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.JSONType"`...)
+		if msg.Uint != 0 {
+			b = append(b, `,"Uint":`...)
+			b = append(b, '"')
+			b = strconv.AppendUint(b, uint64(msg.Uint), 10)
+			b = append(b, '"')
+		}
+		if msg.Arr != [2]uint16{} {
+			b = append(b, `,"arr":`...)
+			b = append(b, '[')
+			for i := range msg.Arr {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = strconv.AppendUint(b, uint64(msg.Arr[i]), 10)
+			}
+			b = append(b, ']')
+		}
+		if msg.Bool {
+			b = append(b, `,"bool":`...)
+			b = strconv.AppendBool(b, msg.Bool)
+		}
+		if len(msg.Bytes) != 0 {
+			b = append(b, `,"bytes":`...)
+			b = appendJSONBytes(b, msg.Bytes)
+		}
+		if msg.Duration.Seconds != 0 || msg.Duration.Nanoseconds != 0 {
+			b = append(b, `,"duration":`...)
+			b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+		}
+		b = append(b, `,"int32":`...)
+		b = strconv.AppendInt(b, int64(msg.Int32), 10)
+		if msg.Int64 != 0 {
+			b = append(b, `,"int64":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Int64), 10)
+			b = append(b, '"')
+		}
+		if msg.Int8 != 0 {
+			b = append(b, `,"int8":`...)
+			b = strconv.AppendInt(b, int64(msg.Int8), 10)
+		}
+		b = append(b, `,"name":`...)
+		b = appendJSONString(b, msg.Name, true)
+		b = append(b, `,"ptrs":`...)
+		if msg.Ptrs == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.Ptrs {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if msg.Ptrs[i] == nil {
+					b = append(b, "null"...)
+					continue
+				}
+				b = strconv.AppendInt(b, int64(*msg.Ptrs[i]), 10)
+			}
+			b = append(b, ']')
+		}
+		if msg.SlicePtr != nil && len(*msg.SlicePtr) != 0 {
+			b = append(b, `,"slice_ptr":`...)
+			b = append(b, '[')
+			for i := range *msg.SlicePtr {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = strconv.AppendInt(b, int64((*msg.SlicePtr)[i]), 10)
+			}
+			b = append(b, ']')
+		}
+		if msg.StringPtr != nil && len(*msg.StringPtr) != 0 {
+			b = append(b, `,"string_ptr":`...)
+			b = appendJSONString(b, *msg.StringPtr, true)
+		}
+		if len(msg.Strings) != 0 {
+			b = append(b, `,"strings":`...)
+			b = append(b, '[')
+			for i := range msg.Strings {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = appendJSONString(b, msg.Strings[i], true)
+			}
+			b = append(b, ']')
+		}
+		if msg.Struct.A != 0 || msg.Struct.B != "" {
+			b = append(b, `,"struct":`...)
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = strconv.AppendUint(b, uint64(msg.Struct.A), 10)
+			b = append(b, `,"B":`...)
+			b = appendJSONString(b, msg.Struct.B, true)
+			b = append(b, '}')
+		}
+		if func() bool {
+			for i := range msg.Structs {
+				if msg.Structs[i].C {
+					return true
+				}
+			}
+			return false
+		}() {
+			b = append(b, `,"structs":`...)
+			b = append(b, '[')
+			for i := range msg.Structs {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = append(b, '{')
+				b = append(b, `"C":`...)
+				b = strconv.AppendBool(b, msg.Structs[i].C)
+				b = append(b, '}')
+			}
+			b = append(b, ']')
+		}
+		if int64(msg.Time.Seconds) != -62135596800 || msg.Time.Nanoseconds != 0 {
+			b = append(b, `,"time":`...)
+			b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.JSONType"`...)
+	if msg.Int8 != 0 {
+		b = append(b, `,"int8":`...)
+		b = strconv.AppendInt(b, int64(msg.Int8), 10)
+	}
+	b = append(b, `,"name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, `,"int32":`...)
+	b = strconv.AppendInt(b, int64(msg.Int32), 10)
+	if msg.Int64 != 0 {
+		b = append(b, `,"int64":`...)
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(msg.Int64), 10)
+		b = append(b, '"')
+	}
+	if msg.Uint != 0 {
+		b = append(b, `,"Uint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.Uint), 10)
+		b = append(b, '"')
+	}
+	if msg.Bool {
+		b = append(b, `,"bool":`...)
+		b = strconv.AppendBool(b, msg.Bool)
+	}
+	if len(msg.Bytes) != 0 {
+		b = append(b, `,"bytes":`...)
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	if len(msg.Strings) != 0 {
+		b = append(b, `,"strings":`...)
+		b = append(b, '[')
+		for i := range msg.Strings {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, msg.Strings[i], false)
+		}
+		b = append(b, ']')
+	}
+	if msg.StringPtr != nil && len(*msg.StringPtr) != 0 {
+		b = append(b, `,"string_ptr":`...)
+		b = appendJSONString(b, *msg.StringPtr, false)
+	}
+	if msg.SlicePtr != nil && len(*msg.SlicePtr) != 0 {
+		b = append(b, `,"slice_ptr":`...)
+		b = append(b, '[')
+		for i := range *msg.SlicePtr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendInt(b, int64((*msg.SlicePtr)[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"ptrs":`...)
+	if msg.Ptrs == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Ptrs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Ptrs[i] == nil {
+				b = append(b, "null"...)
+				continue
+			}
+			b = strconv.AppendInt(b, int64(*msg.Ptrs[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if msg.Arr != [2]uint16{} {
+		b = append(b, `,"arr":`...)
+		b = append(b, '[')
+		for i := range msg.Arr {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(msg.Arr[i]), 10)
+		}
+		b = append(b, ']')
+	}
+	if int64(msg.Time.Seconds) != -62135596800 || msg.Time.Nanoseconds != 0 {
+		b = append(b, `,"time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if msg.Duration.Seconds != 0 || msg.Duration.Nanoseconds != 0 {
+		b = append(b, `,"duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	}
+	if msg.Struct.A != 0 || msg.Struct.B != "" {
+		b = append(b, `,"struct":`...)
+		b = append(b, '{')
+		b = append(b, `"A":`...)
+		b = strconv.AppendUint(b, uint64(msg.Struct.A), 10)
+		b = append(b, `,"B":`...)
+		b = appendJSONString(b, msg.Struct.B, false)
+		b = append(b, '}')
+	}
+	if func() bool {
+		for i := range msg.Structs {
+			if msg.Structs[i].C {
+				return true
+			}
+		}
+		return false
+	}() {
+		b = append(b, `,"structs":`...)
+		b = append(b, '[')
+		for i := range msg.Structs {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"C":`...)
+			b = strconv.AppendBool(b, msg.Structs[i].C)
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
 
-// len64 returns the minimum number of bits required to represent x; the result is 0 for x == 0.
-// from math/bits.
-func len64(x uint64) (n int) {
-	if x >= 1<<32 {
-		x >>= 32
-		n = 32
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg CatMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.Cat"`...)
+		b = append(b, `,"Lives":`...)
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(msg.Lives), 10)
+		b = append(b, '"')
+		b = append(b, `,"Name":`...)
+		b = appendJSONString(b, msg.Name, true)
+		b = append(b, '}')
+		return b, nil
 	}
-	if x >= 1<<16 {
-		x >>= 16
-		n += 16
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.Cat"`...)
+	b = append(b, `,"Name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, `,"Lives":`...)
+	b = append(b, '"')
+	b = strconv.AppendInt(b, int64(msg.Lives), 10)
+	b = append(b, '"')
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg DogMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.Dog"`...)
+		b = append(b, `,"Name":`...)
+		b = appendJSONString(b, msg.Name, true)
+		b = append(b, '}')
+		return b, nil
 	}
-	if x >= 1<<8 {
-		x >>= 8
-		n += 8
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.Dog"`...)
+	b = append(b, `,"Name":`...)
+	b = appendJSONString(b, msg.Name, false)
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg FishMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.Fish"`...)
+		b = append(b, '}')
+		return b, nil
 	}
-	return n + int(len8tab[x])
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.Fish"`...)
+	b = append(b, '}')
+	return b, nil
 }
 
-func uvarintSize(x uint64) int {
-	// +6 allows us to count any "remainder" as a full byte to be encoded.
-	return (len64(x)+6) / 7
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg ZooMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.Zoo"`...)
+		b = append(b, `,"Animals":`...)
+		if msg.Animals == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.Animals {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				if msg.Animals[i] == nil {
+					b = append(b, "null"...)
+				} else {
+					b, err = appendJSONAnyAnimal(b, msg.Animals[i], true)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+			b = append(b, ']')
+		}
+		b = append(b, `,"Star":`...)
+		if msg.Star == nil {
+			b = append(b, "null"...)
+		} else {
+			b, err = appendJSONAnyAnimal(b, msg.Star, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.Zoo"`...)
+	b = append(b, `,"Star":`...)
+	if msg.Star == nil {
+		b = append(b, "null"...)
+	} else {
+		b, err = appendJSONAnyAnimal(b, msg.Star, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, `,"Animals":`...)
+	if msg.Animals == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Animals {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if msg.Animals[i] == nil {
+				b = append(b, "null"...)
+			} else {
+				b, err = appendJSONAnyAnimal(b, msg.Animals[i], false)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
 }
 
-// putUvarint encodes a uint64 into buf and returns the number of bytes written.
-// If the buffer is too small, PutUvarint will panic.
-// Copied from package binary.
-func putUvarint(buf []byte, x uint64) int {
-	i := 0
-	for x >= 0x80 {
-		buf[i] = byte(x) | 0x80
-		x >>= 7
-		i++
+// appendJSONAnyAnimal appends v to b in amino's JSON encoding, like
+// appendAnyAnimal. If canonical is set, the keys of the objects are
+// sorted.
+func appendJSONAnyAnimal(b []byte, v interface{}, canonical bool) ([]byte, error) {
+	switch v := v.(type) {
+	case CatMessage:
+		return v.appendJSONAny(b, canonical)
+	case *CatMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return v.appendJSONAny(b, canonical)
+	case DogMessage:
+		return v.appendJSONAny(b, canonical)
+	case *DogMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return v.appendJSONAny(b, canonical)
+	case FishMessage:
+		return v.appendJSONAny(b, canonical)
+	case *FishMessage:
+		if v == nil {
+			return nil, errNilAny
+		}
+		return v.appendJSONAny(b, canonical)
 	}
-	buf[i] = byte(x)
-	return i + 1
+	return nil, notImplementationError(v, "github.com/thehowl/tomino/tests/golden.Animal")
 }
 
-// putVarint encodes an int64 into buf and returns the number of bytes written.
-// If the buffer is too small, PutVarint will panic.
-func putVarint(buf []byte, x int64) int {
-	ux := uint64(x) << 1
-	if x < 0 {
-		ux = ^ux
+// decodeJSONAnyAnimal decodes the value of the interface from the JSON
+// object at the start of b, like decodeAnyAnimal, returning it together
+// with the size of the object.
+func decodeJSONAnyAnimal(b []byte) (interface{}, int, error) {
+	url, n, err := consumeJSONTypeURL(b)
+	if err != nil {
+		return nil, 0, err
 	}
-	return putUvarint(buf, ux)
+	switch string(url) {
+	case "/tomtypes.Cat":
+		var m CatMessage
+		if err := m.UnmarshalJSON(b[:n]); err != nil {
+			return nil, 0, err
+		}
+		return m, n, nil
+	case "/tomtypes.Dog":
+		m := new(DogMessage)
+		if err := m.UnmarshalJSON(b[:n]); err != nil {
+			return nil, 0, err
+		}
+		return m, n, nil
+	case "/tomtypes.Fish":
+		var m FishMessage
+		if err := m.UnmarshalJSON(b[:n]); err != nil {
+			return nil, 0, err
+		}
+		return m, n, nil
+	}
+	return nil, 0, unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.Animal")
 }
 
-func putUint64(b []byte, v uint64) {
-	_ = b[7] // early bounds check to guarantee safety of writes below
-	b[0] = byte(v)
-	b[1] = byte(v >> 8)
-	b[2] = byte(v >> 16)
-	b[3] = byte(v >> 24)
-	b[4] = byte(v >> 32)
-	b[5] = byte(v >> 40)
-	b[6] = byte(v >> 48)
-	b[7] = byte(v >> 56)
+// ---
+// registry
+
+var errNilPointer = errors.New("tomino: nil pointer")
+
+// unregisteredError returns the error for a value whose type is not one of the
+// registered messages.
+func unregisteredError(o any) error {
+	return fmt.Errorf("tomino: unregistered type %T", o)
 }
 
-func putUint32(b []byte, v uint32) {
-	_ = b[3] // early bounds check to guarantee safety of writes below
-	b[0] = byte(v)
-	b[1] = byte(v >> 8)
-	b[2] = byte(v >> 16)
-	b[3] = byte(v >> 24)
+// binaryMessage is implemented by the registered messages.
+type binaryMessage interface {
+	MarshalBinary() ([]byte, error)
+	MarshalBinarySized() ([]byte, error)
+}
+
+// binaryPointer is implemented by pointers to the registered messages.
+type binaryPointer interface {
+	UnmarshalBinary(b []byte) error
+	UnmarshalBinarySized(b []byte, maxSize int) error
+}
+
+// lookupBinary returns o as a binaryMessage, if it is a registered message or
+// a non-nil pointer to one.
+func lookupBinary(o any) (binaryMessage, error) {
+	switch o := o.(type) {
+	case URLMessage:
+		return o, nil
+	case *URLMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case TestTypeMessage:
+		return o, nil
+	case *TestTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FloatTypeMessage:
+		return o, nil
+	case *FloatTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case JSONTypeMessage:
+		return o, nil
+	case *JSONTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case CatMessage:
+		return o, nil
+	case *CatMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case DogMessage:
+		return o, nil
+	case *DogMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FishMessage:
+		return o, nil
+	case *FishMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case ZooMessage:
+		return o, nil
+	case *ZooMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	}
+	return nil, unregisteredError(o)
+}
+
+// lookupBinaryPointer returns ptr as a binaryPointer, if it is a non-nil
+// pointer to a registered message.
+func lookupBinaryPointer(ptr any) (binaryPointer, error) {
+	switch ptr := ptr.(type) {
+	case *URLMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *TestTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FloatTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *JSONTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *CatMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *DogMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FishMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *ZooMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	}
+	return nil, unregisteredError(ptr)
+}
+
+// Marshal encodes o, a registered message or a pointer to one, like
+// amino.Marshal.
+func Marshal(o any) ([]byte, error) {
+	m, err := lookupBinary(o)
+	if err != nil {
+		return nil, err
+	}
+	return m.MarshalBinary()
+}
+
+// MustMarshal is like [Marshal], but panics on error.
+func MustMarshal(o any) []byte {
+	b, err := Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MarshalSized encodes o prefixed by its length as a uvarint, like
+// amino.MarshalSized.
+func MarshalSized(o any) ([]byte, error) {
+	m, err := lookupBinary(o)
+	if err != nil {
+		return nil, err
+	}
+	return m.MarshalBinarySized()
 }
 
-// avoid unused import errors.
-var _ = unsafe.Pointer((*int)(nil))
+// MustMarshalSized is like [MarshalSized], but panics on error.
+func MustMarshalSized(o any) []byte {
+	b, err := MarshalSized(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Unmarshal decodes b into ptr, a pointer to a registered message, like
+// amino.Unmarshal.
+func Unmarshal(b []byte, ptr any) error {
+	p, err := lookupBinaryPointer(ptr)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalBinary(b)
+}
+
+// MustUnmarshal is like [Unmarshal], but panics on error.
+func MustUnmarshal(b []byte, ptr any) {
+	if err := Unmarshal(b, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// UnmarshalSized decodes b, prefixed by its length as a uvarint, into ptr,
+// like amino.UnmarshalSized.
+func UnmarshalSized(b []byte, ptr any) error {
+	p, err := lookupBinaryPointer(ptr)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalBinarySized(b, 0)
+}
+
+// MustUnmarshalSized is like [UnmarshalSized], but panics on error.
+func MustUnmarshalSized(b []byte, ptr any) {
+	if err := UnmarshalSized(b, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// anyMessage is implemented by the registered messages with a type URL.
+type anyMessage interface {
+	MarshalAny() ([]byte, error)
+	MarshalAnySized() ([]byte, error)
+}
+
+// anyPointer is implemented by pointers to the registered messages with a
+// type URL.
+type anyPointer interface {
+	UnmarshalAny(b []byte) error
+}
+
+// lookupAny returns o as an anyMessage, if it is a registered message with a
+// type URL or a non-nil pointer to one.
+func lookupAny(o any) (anyMessage, error) {
+	switch o := o.(type) {
+	case URLMessage:
+		return o, nil
+	case *URLMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case TestTypeMessage:
+		return o, nil
+	case *TestTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FloatTypeMessage:
+		return o, nil
+	case *FloatTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case JSONTypeMessage:
+		return o, nil
+	case *JSONTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case CatMessage:
+		return o, nil
+	case *CatMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case DogMessage:
+		return o, nil
+	case *DogMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FishMessage:
+		return o, nil
+	case *FishMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case ZooMessage:
+		return o, nil
+	case *ZooMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	}
+	return nil, unregisteredError(o)
+}
+
+// lookupAnyPointer returns ptr as an anyPointer, if it is a non-nil pointer
+// to a registered message with a type URL.
+func lookupAnyPointer(ptr any) (anyPointer, error) {
+	switch ptr := ptr.(type) {
+	case *URLMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *TestTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FloatTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *JSONTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *CatMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *DogMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FishMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *ZooMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	}
+	return nil, unregisteredError(ptr)
+}
+
+// decodeRegisteredAny decodes the google.protobuf.Any in b as the registered
+// message with its type URL.
+func decodeRegisteredAny(b []byte) (any, error) {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return nil, err
+	}
+	switch string(url) {
+	case "/url.URL":
+		var m URLMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.TestType":
+		var m TestTypeMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.FloatType":
+		var m FloatTypeMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.JSONType":
+		var m JSONTypeMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Cat":
+		var m CatMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Dog":
+		var m DogMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Fish":
+		var m FishMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case "/tomtypes.Zoo":
+		var m ZooMessage
+		if err := m.UnmarshalBinary(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("tomino: unregistered type URL %q", url)
+}
+
+// MarshalAny encodes o, a registered message or a pointer to one, wrapped in
+// a google.protobuf.Any with its type URL, like amino.MarshalAny.
+func MarshalAny(o any) ([]byte, error) {
+	m, err := lookupAny(o)
+	if err != nil {
+		return nil, err
+	}
+	return m.MarshalAny()
+}
+
+// MustMarshalAny is like [MarshalAny], but panics on error.
+func MustMarshalAny(o any) []byte {
+	b, err := MarshalAny(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MarshalAnySized encodes o like [MarshalAny], prefixed by its length as a
+// uvarint, like amino.MarshalAnySized.
+func MarshalAnySized(o any) ([]byte, error) {
+	m, err := lookupAny(o)
+	if err != nil {
+		return nil, err
+	}
+	return m.MarshalAnySized()
+}
+
+// MustMarshalAnySized is like [MarshalAnySized], but panics on error.
+func MustMarshalAnySized(o any) []byte {
+	b, err := MarshalAnySized(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
 
+// UnmarshalAny decodes the google.protobuf.Any in b into ptr, like
+// amino.UnmarshalAny. ptr is either a pointer to a registered message, which
+// must have the type URL of the Any, or a pointer to an interface{}, which
+// is set to the registered message with the type URL of the Any.
+func UnmarshalAny(b []byte, ptr any) error {
+	if ip, ok := ptr.(*any); ok {
+		if ip == nil {
+			return errNilPointer
+		}
+		v, err := decodeRegisteredAny(b)
+		if err != nil {
+			return err
+		}
+		*ip = v
+		return nil
+	}
+	p, err := lookupAnyPointer(ptr)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalAny(b)
+}
+
+// MustUnmarshalAny is like [UnmarshalAny], but panics on error.
+func MustUnmarshalAny(b []byte, ptr any) {
+	if err := UnmarshalAny(b, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// jsonMessage is implemented by the registered messages with JSON encoders.
+type jsonMessage interface {
+	MarshalJSON() ([]byte, error)
+}
+
+// jsonPointer is implemented by pointers to the registered messages with JSON
+// decoders.
+type jsonPointer interface {
+	UnmarshalJSON(b []byte) error
+}
+
+// lookupJSON returns o as a jsonMessage, if it is a registered message with
+// JSON encoders or a non-nil pointer to one.
+func lookupJSON(o any) (jsonMessage, error) {
+	switch o := o.(type) {
+	case URLMessage:
+		return o, nil
+	case *URLMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case TestTypeMessage:
+		return o, nil
+	case *TestTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FloatTypeMessage:
+		return o, nil
+	case *FloatTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case JSONTypeMessage:
+		return o, nil
+	case *JSONTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case CatMessage:
+		return o, nil
+	case *CatMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case DogMessage:
+		return o, nil
+	case *DogMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case FishMessage:
+		return o, nil
+	case *FishMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	case ZooMessage:
+		return o, nil
+	case *ZooMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o, nil
+	}
+	return nil, unregisteredError(o)
+}
+
+// lookupJSONPointer returns ptr as a jsonPointer, if it is a non-nil pointer
+// to a registered message with JSON decoders.
+func lookupJSONPointer(ptr any) (jsonPointer, error) {
+	switch ptr := ptr.(type) {
+	case *URLMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *TestTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FloatTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *JSONTypeMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *CatMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *DogMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *FishMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	case *ZooMessage:
+		if ptr == nil {
+			return nil, errNilPointer
+		}
+		return ptr, nil
+	}
+	return nil, unregisteredError(ptr)
+}
+
+// MarshalJSON encodes o in amino's JSON encoding, like amino.MarshalJSON.
+func MarshalJSON(o any) ([]byte, error) {
+	m, err := lookupJSON(o)
+	if err != nil {
+		return nil, err
+	}
+	return m.MarshalJSON()
+}
+
+// MustMarshalJSON is like [MarshalJSON], but panics on error.
+func MustMarshalJSON(o any) []byte {
+	b, err := MarshalJSON(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MarshalJSONIndent is like [MarshalJSON], but indents the output like
+// amino.MarshalJSONIndent.
+func MarshalJSONIndent(o any, prefix, indent string) ([]byte, error) {
+	b, err := MarshalJSON(o)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, prefix, indent); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// UnmarshalJSON decodes b, in amino's JSON encoding, into ptr, like
+// amino.UnmarshalJSON.
+func UnmarshalJSON(b []byte, ptr any) error {
+	p, err := lookupJSONPointer(ptr)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalJSON(b)
+}
+
+// MustUnmarshalJSON is like [UnmarshalJSON], but panics on error.
+func MustUnmarshalJSON(b []byte, ptr any) {
+	if err := UnmarshalJSON(b, ptr); err != nil {
+		panic(err)
+	}
+}
+
+// MarshalJSONAny encodes o in amino's JSON encoding, with its type URL in the
+// "@type" member, like amino.MarshalJSONAny.
+func MarshalJSONAny(o any) ([]byte, error) {
+	switch o := o.(type) {
+	case URLMessage:
+		return o.MarshalJSONAny()
+	case *URLMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case TestTypeMessage:
+		return o.MarshalJSONAny()
+	case *TestTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case FloatTypeMessage:
+		return o.MarshalJSONAny()
+	case *FloatTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case JSONTypeMessage:
+		return o.MarshalJSONAny()
+	case *JSONTypeMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case CatMessage:
+		return o.MarshalJSONAny()
+	case *CatMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case DogMessage:
+		return o.MarshalJSONAny()
+	case *DogMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case FishMessage:
+		return o.MarshalJSONAny()
+	case *FishMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	case ZooMessage:
+		return o.MarshalJSONAny()
+	case *ZooMessage:
+		if o == nil {
+			return nil, errNilPointer
+		}
+		return o.MarshalJSONAny()
+	}
+	return nil, unregisteredError(o)
+}
+
+// MustMarshalJSONAny is like [MarshalJSONAny], but panics on error.
+func MustMarshalJSONAny(o any) []byte {
+	b, err := MarshalJSONAny(o)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,959 @@
+# Code generated by tomgen (tomino). DO NOT EDIT.
+
+from __future__ import annotations
+
+import dataclasses
+import datetime
+import struct
+from typing import List, Optional
+
+_EPOCH = datetime.datetime(1970, 1, 1, tzinfo=datetime.timezone.utc)
+
+
+@dataclasses.dataclass
+class Userinfo:
+    """Userinfo is the tomino message for the type net/url.Userinfo."""
+
+
+@dataclasses.dataclass
+class URL:
+    """URL is the tomino message for the type net/url.URL."""
+    scheme: str = ""
+    opaque: str = ""
+    user: Optional[Userinfo] = None
+    host: str = ""
+    path: str = ""
+    raw_path: str = ""
+    omit_host: bool = False
+    force_query: bool = False
+    raw_query: str = ""
+    fragment: str = ""
+    raw_fragment: str = ""
+
+
+@dataclasses.dataclass
+class TestTypeSlice:
+    a: int = 0
+    b: int = 0
+
+
+@dataclasses.dataclass
+class TestType:
+    """TestType is the tomino message for the type github.com/thehowl/tomino/tests/golden.TestType."""
+    time: datetime.datetime = _EPOCH
+    duration: datetime.timedelta = datetime.timedelta()
+    fixed_uint: int = 0
+    byte: int = 0
+    bytes_: bytes = b""
+    byte_arr: Optional[bytes] = None
+    zero_arr: bytes = b""
+    int_ptr: Optional[int] = None
+    slice: List[TestTypeSlice] = dataclasses.field(default_factory=list)
+
+
+@dataclasses.dataclass
+class FloatType:
+    """FloatType is the tomino message for the type github.com/thehowl/tomino/tests/golden.FloatType."""
+    float_: float = 0.0
+    float32: float = 0.0
+    float_ptr: Optional[float] = None
+    floats: List[float] = dataclasses.field(default_factory=list)
+    float_arr: List[float] = dataclasses.field(default_factory=lambda: [0.0] * 2)
+    float_ptrs: List[Optional[float]] = dataclasses.field(default_factory=list)
+
+
+@dataclasses.dataclass
+class JSONTypeStruct:
+    a: int = 0
+    b: str = ""
+
+
+@dataclasses.dataclass
+class JSONTypeStructs:
+    c: bool = False
+
+
+@dataclasses.dataclass
+class JSONType:
+    """JSONType is the tomino message for the type github.com/thehowl/tomino/tests/golden.JSONType."""
+    int8: int = 0
+    name: str = ""
+    int32: int = 0
+    int64: int = 0
+    uint: int = 0
+    bool_: bool = False
+    bytes_: bytes = b""
+    strings: List[str] = dataclasses.field(default_factory=list)
+    string_ptr: Optional[str] = None
+    slice_ptr: Optional[List[int]] = None
+    ptrs: List[Optional[int]] = dataclasses.field(default_factory=list)
+    arr: List[int] = dataclasses.field(default_factory=lambda: [0] * 2)
+    time: datetime.datetime = _EPOCH
+    duration: datetime.timedelta = datetime.timedelta()
+    struct: JSONTypeStruct = dataclasses.field(default_factory=JSONTypeStruct)
+    structs: List[JSONTypeStructs] = dataclasses.field(default_factory=lambda: [JSONTypeStructs() for _ in range(1)])
+
+
+def encode_url(msg: URL) -> bytes:
+    """Encodes msg in amino's binary encoding."""
+    w = _Writer()
+    _write_url(w, msg)
+    return bytes(w.buf)
+
+
+def decode_url(b: bytes) -> URL:
+    """Decodes a URL from amino's binary encoding."""
+    r = _Reader(b)
+    return _read_url(r, len(b))
+
+
+def encode_test_type(msg: TestType) -> bytes:
+    """Encodes msg in amino's binary encoding."""
+    w = _Writer()
+    _write_test_type(w, msg)
+    return bytes(w.buf)
+
+
+def decode_test_type(b: bytes) -> TestType:
+    """Decodes a TestType from amino's binary encoding."""
+    r = _Reader(b)
+    return _read_test_type(r, len(b))
+
+
+def encode_float_type(msg: FloatType) -> bytes:
+    """Encodes msg in amino's binary encoding."""
+    w = _Writer()
+    _write_float_type(w, msg)
+    return bytes(w.buf)
+
+
+def decode_float_type(b: bytes) -> FloatType:
+    """Decodes a FloatType from amino's binary encoding."""
+    r = _Reader(b)
+    return _read_float_type(r, len(b))
+
+
+def encode_json_type(msg: JSONType) -> bytes:
+    """Encodes msg in amino's binary encoding."""
+    w = _Writer()
+    _write_json_type(w, msg)
+    return bytes(w.buf)
+
+
+def decode_json_type(b: bytes) -> JSONType:
+    """Decodes a JSONType from amino's binary encoding."""
+    r = _Reader(b)
+    return _read_json_type(r, len(b))
+
+
+def _read_userinfo(r: _Reader, end: int) -> Userinfo:
+    msg = Userinfo()
+    while r.pos < end:
+        r.skip(r.tag()[1])
+    r.done(end)
+    return msg
+
+
+def _write_url(w: _Writer, msg: URL) -> None:
+    # field number 1
+    if msg.scheme:
+        w.raw(b"\x0a")
+        w.string(msg.scheme)
+    # field number 2
+    if msg.opaque:
+        w.raw(b"\x12")
+        w.string(msg.opaque)
+    # field number 3
+    # (always empty, skip as there is no write_empty)
+    # field number 4
+    if msg.host:
+        w.raw(b"\x22")
+        w.string(msg.host)
+    # field number 5
+    if msg.path:
+        w.raw(b"\x2a")
+        w.string(msg.path)
+    # field number 6
+    if msg.raw_path:
+        w.raw(b"\x32")
+        w.string(msg.raw_path)
+    # field number 7
+    if msg.omit_host:
+        w.raw(b"\x38")
+        w.bool(msg.omit_host)
+    # field number 8
+    if msg.force_query:
+        w.raw(b"\x40")
+        w.bool(msg.force_query)
+    # field number 9
+    if msg.raw_query:
+        w.raw(b"\x4a")
+        w.string(msg.raw_query)
+    # field number 10
+    if msg.fragment:
+        w.raw(b"\x52")
+        w.string(msg.fragment)
+    # field number 11
+    if msg.raw_fragment:
+        w.raw(b"\x5a")
+        w.string(msg.raw_fragment)
+
+
+def _read_url(r: _Reader, end: int) -> URL:
+    msg = URL()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 2)
+            msg.scheme = r.string()
+        elif num == 2:
+            r.expect(wt, 2)
+            msg.opaque = r.string()
+        elif num == 3:
+            r.expect(wt, 2)
+            msg.user = _read_userinfo(r, r.end())
+        elif num == 4:
+            r.expect(wt, 2)
+            msg.host = r.string()
+        elif num == 5:
+            r.expect(wt, 2)
+            msg.path = r.string()
+        elif num == 6:
+            r.expect(wt, 2)
+            msg.raw_path = r.string()
+        elif num == 7:
+            r.expect(wt, 0)
+            msg.omit_host = r.bool()
+        elif num == 8:
+            r.expect(wt, 0)
+            msg.force_query = r.bool()
+        elif num == 9:
+            r.expect(wt, 2)
+            msg.raw_query = r.string()
+        elif num == 10:
+            r.expect(wt, 2)
+            msg.fragment = r.string()
+        elif num == 11:
+            r.expect(wt, 2)
+            msg.raw_fragment = r.string()
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_time(w: _Writer, value: datetime.datetime) -> None:
+    msg = _Timestamp.from_time(value)
+    # field number 1
+    if msg.seconds:
+        w.raw(b"\x08")
+        w.uint(msg.seconds, 64)
+    # field number 2
+    if msg.nanoseconds:
+        w.raw(b"\x10")
+        w.uint(msg.nanoseconds, 32)
+
+
+def _read_time(r: _Reader, end: int) -> datetime.datetime:
+    msg = _Timestamp()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.seconds = r.uint(64)
+        elif num == 2:
+            r.expect(wt, 0)
+            msg.nanoseconds = r.uint(32)
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg.time()
+
+
+def _write_duration(w: _Writer, value: datetime.timedelta) -> None:
+    msg = _Timestamp.from_duration(value)
+    # field number 1
+    if msg.seconds:
+        w.raw(b"\x08")
+        w.uint(msg.seconds, 64)
+    # field number 2
+    if msg.nanoseconds:
+        w.raw(b"\x10")
+        w.uint(msg.nanoseconds, 32)
+
+
+def _read_duration(r: _Reader, end: int) -> datetime.timedelta:
+    msg = _Timestamp()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.seconds = r.uint(64)
+        elif num == 2:
+            r.expect(wt, 0)
+            msg.nanoseconds = r.uint(32)
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg.duration()
+
+
+def _write_test_type_slice(w: _Writer, msg: TestTypeSlice) -> None:
+    # field number 1
+    if msg.a:
+        w.raw(b"\x08")
+        w.int(msg.a, 64)
+    # field number 2
+    if msg.b:
+        w.raw(b"\x10")
+        w.int(msg.b, 64)
+
+
+def _read_test_type_slice(r: _Reader, end: int) -> TestTypeSlice:
+    msg = TestTypeSlice()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.a = r.int(64)
+        elif num == 2:
+            r.expect(wt, 0)
+            msg.b = r.int(64)
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_test_type(w: _Writer, msg: TestType) -> None:
+    # field number 1
+    start = w.begin(b"\x0a")
+    _write_time(w, msg.time)
+    w.end(start, 1, True)
+    # field number 2
+    start = w.begin(b"\x12")
+    _write_duration(w, msg.duration)
+    w.end(start, 1, True)
+    # field number 3
+    if msg.fixed_uint:
+        w.raw(b"\x19")
+        w.fixed(_FIXED64, msg.fixed_uint)
+    # field number 4
+    if msg.byte:
+        w.raw(b"\x20")
+        w.uint(msg.byte, 8)
+    # field number 5
+    if msg.bytes_:
+        w.raw(b"\x2a")
+        w.bytes(msg.bytes_)
+    # field number 6
+    if msg.byte_arr is not None:
+        w.raw(b"\x32")
+        w.byte_array(msg.byte_arr, 4)
+    # field number 7
+    # (always empty, skip as there is no write_empty)
+    # field number 8
+    if msg.int_ptr is not None:
+        if msg.int_ptr:
+            w.raw(b"\x40")
+            w.int(msg.int_ptr, 64)
+    # field number 9
+    for el in msg.slice:
+        start = w.begin(b"\x4a")
+        _write_test_type_slice(w, el)
+        w.end(start, 1, False)
+
+
+def _read_test_type(r: _Reader, end: int) -> TestType:
+    msg = TestType()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 2)
+            msg.time = _read_time(r, r.end())
+        elif num == 2:
+            r.expect(wt, 2)
+            msg.duration = _read_duration(r, r.end())
+        elif num == 3:
+            r.expect(wt, 1)
+            msg.fixed_uint = r.fixed(_FIXED64)
+        elif num == 4:
+            r.expect(wt, 0)
+            msg.byte = r.uint(8)
+        elif num == 5:
+            r.expect(wt, 2)
+            msg.bytes_ = r.bytes()
+        elif num == 6:
+            r.expect(wt, 2)
+            msg.byte_arr = r.byte_array(4)
+        elif num == 7:
+            r.expect(wt, 2)
+            msg.zero_arr = r.byte_array(0)
+        elif num == 8:
+            r.expect(wt, 0)
+            msg.int_ptr = r.int(64)
+        elif num == 9:
+            r.expect(wt, 2)
+            msg.slice.append(_read_test_type_slice(r, r.end()))
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_float_type(w: _Writer, msg: FloatType) -> None:
+    # field number 1
+    w.raw(b"\x09")
+    w.fixed(_DOUBLE, msg.float_)
+    # field number 2
+    w.raw(b"\x15")
+    w.fixed(_FLOAT, msg.float32)
+    # field number 3
+    if msg.float_ptr is not None:
+        w.raw(b"\x19")
+        w.fixed(_DOUBLE, msg.float_ptr)
+    # field number 4
+    if msg.floats:
+        start = w.begin(b"\x22")
+        for el in msg.floats:
+            w.fixed(_FLOAT, el)
+        w.end(start, 1, False)
+    # field number 5
+    _check_length(msg.float_arr, 2)
+    start = w.begin(b"\x2a")
+    for el in msg.float_arr:
+        w.fixed(_DOUBLE, el)
+    w.end(start, 1, False)
+    # field number 6
+    if msg.float_ptrs:
+        start = w.begin(b"\x32")
+        for el in msg.float_ptrs:
+            w.fixed(_DOUBLE, el if el is not None else 0.0)
+        w.end(start, 1, False)
+
+
+def _read_float_type(r: _Reader, end: int) -> FloatType:
+    msg = FloatType()
+    i5 = 0
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 1)
+            msg.float_ = r.fixed(_DOUBLE)
+        elif num == 2:
+            r.expect(wt, 5)
+            msg.float32 = r.fixed(_FLOAT)
+        elif num == 3:
+            r.expect(wt, 1)
+            msg.float_ptr = r.fixed(_DOUBLE)
+        elif num == 4:
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.floats.append(r.fixed(_FLOAT))
+                r.done(stop)
+            else:
+                r.expect(wt, 5)
+                msg.floats.append(r.fixed(_FLOAT))
+        elif num == 5:
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.float_arr[r.index(i5, 2)] = r.fixed(_DOUBLE)
+                    i5 += 1
+                r.done(stop)
+            else:
+                r.expect(wt, 1)
+                msg.float_arr[r.index(i5, 2)] = r.fixed(_DOUBLE)
+                i5 += 1
+        elif num == 6:
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.float_ptrs.append(r.fixed(_DOUBLE))
+                r.done(stop)
+            else:
+                r.expect(wt, 1)
+                msg.float_ptrs.append(r.fixed(_DOUBLE))
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_json_type_struct(w: _Writer, msg: JSONTypeStruct) -> None:
+    # field number 1
+    if msg.a:
+        w.raw(b"\x08")
+        w.uint(msg.a, 32)
+    # field number 2
+    if msg.b:
+        w.raw(b"\x12")
+        w.string(msg.b)
+
+
+def _read_json_type_struct(r: _Reader, end: int) -> JSONTypeStruct:
+    msg = JSONTypeStruct()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.a = r.uint(32)
+        elif num == 2:
+            r.expect(wt, 2)
+            msg.b = r.string()
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_json_type_structs(w: _Writer, msg: JSONTypeStructs) -> None:
+    # field number 1
+    if msg.c:
+        w.raw(b"\x08")
+        w.bool(msg.c)
+
+
+def _read_json_type_structs(r: _Reader, end: int) -> JSONTypeStructs:
+    msg = JSONTypeStructs()
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.c = r.bool()
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+def _write_json_type(w: _Writer, msg: JSONType) -> None:
+    # field number 1
+    if msg.int8:
+        w.raw(b"\x08")
+        w.int(msg.int8, 8)
+    # field number 2
+    if msg.name:
+        w.raw(b"\x12")
+        w.string(msg.name)
+    # field number 3
+    if msg.int32:
+        w.raw(b"\x18")
+        w.int(msg.int32, 32)
+    # field number 4
+    if msg.int64:
+        w.raw(b"\x20")
+        w.int(msg.int64, 64)
+    # field number 5
+    if msg.uint:
+        w.raw(b"\x28")
+        w.uint(msg.uint, 64)
+    # field number 6
+    if msg.bool_:
+        w.raw(b"\x30")
+        w.bool(msg.bool_)
+    # field number 7
+    if msg.bytes_:
+        w.raw(b"\x3a")
+        w.bytes(msg.bytes_)
+    # field number 8
+    for el in msg.strings:
+        w.raw(b"\x42")
+        w.string(el)
+    # field number 9
+    if msg.string_ptr is not None:
+        if msg.string_ptr:
+            w.raw(b"\x4a")
+            w.string(msg.string_ptr)
+    # field number 10
+    if msg.slice_ptr is not None:
+        if msg.slice_ptr:
+            start = w.begin(b"\x52")
+            for el in msg.slice_ptr:
+                w.int(el, 16)
+            w.end(start, 1, False)
+    # field number 11
+    if msg.ptrs:
+        start = w.begin(b"\x5a")
+        for el in msg.ptrs:
+            w.int(el if el is not None else 0, 32)
+        w.end(start, 1, False)
+    # field number 12
+    _check_length(msg.arr, 2)
+    start = w.begin(b"\x62")
+    for el in msg.arr:
+        w.uint(el, 16)
+    w.end(start, 1, False)
+    # field number 13
+    start = w.begin(b"\x6a")
+    _write_time(w, msg.time)
+    w.end(start, 1, True)
+    # field number 14
+    start = w.begin(b"\x72")
+    _write_duration(w, msg.duration)
+    w.end(start, 1, True)
+    # field number 15
+    start = w.begin(b"\x7a")
+    _write_json_type_struct(w, msg.struct)
+    w.end(start, 1, True)
+    # field number 16
+    _check_length(msg.structs, 1)
+    for el in msg.structs:
+        start = w.begin(b"\x82\x01")
+        _write_json_type_structs(w, el)
+        w.end(start, 2, False)
+
+
+def _read_json_type(r: _Reader, end: int) -> JSONType:
+    msg = JSONType()
+    i12 = 0
+    i16 = 0
+    while r.pos < end:
+        num, wt = r.tag()
+        if num == 1:
+            r.expect(wt, 0)
+            msg.int8 = r.int(8)
+        elif num == 2:
+            r.expect(wt, 2)
+            msg.name = r.string()
+        elif num == 3:
+            r.expect(wt, 0)
+            msg.int32 = r.int(32)
+        elif num == 4:
+            r.expect(wt, 0)
+            msg.int64 = r.int(64)
+        elif num == 5:
+            r.expect(wt, 0)
+            msg.uint = r.uint(64)
+        elif num == 6:
+            r.expect(wt, 0)
+            msg.bool_ = r.bool()
+        elif num == 7:
+            r.expect(wt, 2)
+            msg.bytes_ = r.bytes()
+        elif num == 8:
+            r.expect(wt, 2)
+            msg.strings.append(r.string())
+        elif num == 9:
+            r.expect(wt, 2)
+            msg.string_ptr = r.string()
+        elif num == 10:
+            if msg.slice_ptr is None:
+                msg.slice_ptr = []
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.slice_ptr.append(r.int(16))
+                r.done(stop)
+            else:
+                r.expect(wt, 0)
+                msg.slice_ptr.append(r.int(16))
+        elif num == 11:
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.ptrs.append(r.int(32))
+                r.done(stop)
+            else:
+                r.expect(wt, 0)
+                msg.ptrs.append(r.int(32))
+        elif num == 12:
+            if wt == 2:
+                stop = r.end()
+                while r.pos < stop:
+                    msg.arr[r.index(i12, 2)] = r.uint(16)
+                    i12 += 1
+                r.done(stop)
+            else:
+                r.expect(wt, 0)
+                msg.arr[r.index(i12, 2)] = r.uint(16)
+                i12 += 1
+        elif num == 13:
+            r.expect(wt, 2)
+            msg.time = _read_time(r, r.end())
+        elif num == 14:
+            r.expect(wt, 2)
+            msg.duration = _read_duration(r, r.end())
+        elif num == 15:
+            r.expect(wt, 2)
+            msg.struct = _read_json_type_struct(r, r.end())
+        elif num == 16:
+            r.expect(wt, 2)
+            msg.structs[r.index(i16, 1)] = _read_json_type_structs(r, r.end())
+            i16 += 1
+        else:
+            r.skip(wt)
+    r.done(end)
+    return msg
+
+
+# ---
+# encoding runtime
+
+
+class DecodeError(ValueError):
+    """DecodeError is raised by the decoders on invalid input."""
+
+
+_FIXED64 = struct.Struct("<Q")
+_SFIXED64 = struct.Struct("<q")
+_FIXED32 = struct.Struct("<I")
+_SFIXED32 = struct.Struct("<i")
+_DOUBLE = struct.Struct("<d")
+_FLOAT = struct.Struct("<f")
+
+
+def _uvarint(x: int) -> bytes:
+    b = bytearray()
+    while x > 0x7F:
+        b.append((x & 0x7F) | 0x80)
+        x >>= 7
+    b.append(x)
+    return bytes(b)
+
+
+def _check_length(x, n: int) -> None:
+    if len(x) != n:
+        raise ValueError(f"tomino: expected array of length {n}, got {len(x)}")
+
+
+def _div_trunc(x: int, y: int) -> int:
+    # integer division rounding towards zero, like in Go.
+    q = abs(x) // y
+    return -q if x < 0 else q
+
+
+class _Timestamp:
+    """_Timestamp is the encoding of amino's well-known time types.
+
+    The seconds and nanoseconds are signed, but are encoded as unsigned
+    integers of 64 and 32 bits. Python's datetime types have a precision of
+    microseconds, so the nanoseconds are truncated when decoding.
+    """
+
+    __slots__ = ("seconds", "nanoseconds")
+
+    def __init__(self, seconds: int = 0, nanoseconds: int = 0) -> None:
+        self.seconds = seconds
+        self.nanoseconds = nanoseconds
+
+    @classmethod
+    def from_time(cls, t: datetime.datetime) -> _Timestamp:
+        if t.tzinfo is None:
+            # naive datetimes are assumed to be in UTC.
+            t = t.replace(tzinfo=datetime.timezone.utc)
+        d = t - _EPOCH
+        return cls.from_parts(d.days * 86400 + d.seconds, d.microseconds * 1000)
+
+    @classmethod
+    def from_duration(cls, d: datetime.timedelta) -> _Timestamp:
+        ns = d // datetime.timedelta(microseconds=1) * 1000
+        s = _div_trunc(ns, 1_000_000_000)
+        return cls.from_parts(s, ns - s * 1_000_000_000)
+
+    @classmethod
+    def from_parts(cls, s: int, ns: int) -> _Timestamp:
+        return cls(s & 0xFFFFFFFFFFFFFFFF, ns & 0xFFFFFFFF)
+
+    def parts(self) -> tuple:
+        s, ns = self.seconds, self.nanoseconds
+        if s >= 1 << 63:
+            s -= 1 << 64
+        if ns >= 1 << 31:
+            ns -= 1 << 32
+        return s, ns
+
+    def time(self) -> datetime.datetime:
+        s, ns = self.parts()
+        if not 0 <= ns < 1_000_000_000:
+            raise DecodeError(f"tomino: invalid nanoseconds {ns} in time")
+        try:
+            return _EPOCH + datetime.timedelta(seconds=s, microseconds=ns // 1000)
+        except OverflowError:
+            raise DecodeError(f"tomino: time out of range ({s} seconds)") from None
+
+    def duration(self) -> datetime.timedelta:
+        s, ns = self.parts()
+        try:
+            return datetime.timedelta(microseconds=_div_trunc(s * 1_000_000_000 + ns, 1000))
+        except OverflowError:
+            raise DecodeError(f"tomino: duration out of range ({s} seconds)") from None
+
+
+class _Writer:
+    def __init__(self) -> None:
+        self.buf = bytearray()
+
+    def raw(self, b: bytes) -> None:
+        self.buf += b
+
+    def uvarint(self, x: int) -> None:
+        self.buf += _uvarint(x)
+
+    def uint(self, x: int, bits: int) -> None:
+        if not 0 <= x < 1 << bits:
+            raise ValueError(f"tomino: {x} overflows a {bits}-bit unsigned integer")
+        self.uvarint(x)
+
+    def int(self, x: int, bits: int) -> None:
+        if not -(1 << (bits - 1)) <= x < 1 << (bits - 1):
+            raise ValueError(f"tomino: {x} overflows a {bits}-bit integer")
+        # zig-zag encoding.
+        self.uvarint((x << 1) ^ (x >> 63))
+
+    def bool(self, x: bool) -> None:
+        self.buf.append(1 if x else 0)
+
+    def fixed(self, s: struct.Struct, x) -> None:
+        self.buf += s.pack(x)
+
+    def bytes(self, x: bytes) -> None:
+        self.uvarint(len(x))
+        self.buf += x
+
+    def string(self, x: str) -> None:
+        try:
+            b = x.encode("utf-8")
+        except UnicodeEncodeError:
+            raise ValueError("tomino: string with unpaired surrogates can't be encoded as UTF-8") from None
+        self.bytes(b)
+
+    def byte_array(self, x: bytes, n: int) -> None:
+        _check_length(x, n)
+        self.bytes(x)
+
+    def begin(self, tag: bytes) -> int:
+        """Writes the tag of a length-prefixed value, and returns its position,
+        to be passed to end once the value has been written."""
+        start = len(self.buf)
+        self.buf += tag
+        return start
+
+    def end(self, start: int, tag_len: int, omit_empty: bool) -> None:
+        """Inserts the length of the value started with begin.
+        If the value is empty and omit_empty is set, the tag is removed
+        instead."""
+        value_start = start + tag_len
+        size = len(self.buf) - value_start
+        if size == 0 and omit_empty:
+            del self.buf[start:]
+            return
+        self.buf[value_start:value_start] = _uvarint(size)
+
+
+class _Reader:
+    def __init__(self, buf: bytes) -> None:
+        self.buf = memoryview(buf).cast("B")
+        self.pos = 0
+
+    def need(self, n: int) -> None:
+        if self.pos + n > len(self.buf):
+            raise DecodeError("tomino: unexpected end of input")
+
+    def uvarint(self) -> int:
+        x = 0
+        for shift in range(0, 70, 7):
+            self.need(1)
+            b = self.buf[self.pos]
+            self.pos += 1
+            x |= (b & 0x7F) << shift
+            if b < 0x80:
+                if x >> 64:
+                    break
+                return x
+        raise DecodeError("tomino: varint overflows a 64-bit integer")
+
+    def tag(self) -> tuple:
+        x = self.uvarint()
+        return x >> 3, x & 7
+
+    def expect(self, wt: int, want: int) -> None:
+        if wt != want:
+            raise DecodeError(f"tomino: invalid wire type {wt}, expected {want}")
+
+    def skip(self, wt: int) -> None:
+        if wt == 0:
+            self.uvarint()
+        elif wt == 1:
+            self.need(8)
+            self.pos += 8
+        elif wt == 2:
+            self.pos = self.end()
+        elif wt == 5:
+            self.need(4)
+            self.pos += 4
+        else:
+            raise DecodeError(f"tomino: invalid wire type {wt}")
+
+    def end(self) -> int:
+        """Reads the length prefix of a value, and returns the position where
+        the value ends."""
+        n = self.uvarint()
+        self.need(n)
+        return self.pos + n
+
+    def done(self, end: int) -> None:
+        if self.pos != end:
+            raise DecodeError("tomino: value overflows its length prefix")
+
+    def nil(self) -> bool:
+        """Reports whether the next value is empty, consuming its length
+        prefix if so. Nil elements of repeated fields are encoded as empty
+        values."""
+        self.need(1)
+        if self.buf[self.pos] != 0:
+            return False
+        self.pos += 1
+        return True
+
+    def index(self, i: int, n: int) -> int:
+        if i >= n:
+            raise DecodeError(f"tomino: too many elements for array of length {n}")
+        return i
+
+    def uint(self, bits: int) -> int:
+        x = self.uvarint()
+        if x >> bits:
+            raise DecodeError(f"tomino: varint overflows a {bits}-bit unsigned integer")
+        return x
+
+    def int(self, bits: int) -> int:
+        x = self.uvarint()
+        x = (x >> 1) ^ -(x & 1)
+        if not -(1 << (bits - 1)) <= x < 1 << (bits - 1):
+            raise DecodeError(f"tomino: varint overflows a {bits}-bit integer")
+        return x
+
+    def bool(self) -> bool:
+        # like in amino, bools are a single byte of value 0 or 1.
+        self.need(1)
+        b = self.buf[self.pos]
+        self.pos += 1
+        if b > 1:
+            raise DecodeError("tomino: invalid bool")
+        return b == 1
+
+    def fixed(self, s: struct.Struct):
+        self.need(s.size)
+        (x,) = s.unpack_from(self.buf, self.pos)
+        self.pos += s.size
+        return x
+
+    def bytes(self) -> bytes:
+        end = self.end()
+        b = bytes(self.buf[self.pos : end])
+        self.pos = end
+        return b
+
+    def string(self) -> str:
+        try:
+            return self.bytes().decode("utf-8")
+        except UnicodeDecodeError:
+            raise DecodeError("tomino: invalid UTF-8 string") from None
+
+    def byte_array(self, n: int) -> bytes:
+        b = self.bytes()
+        if len(b) != n:
+            raise DecodeError(f"tomino: expected array of length {n}, got {len(b)}")
+        return b
@@ -0,0 +1,1136 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+//
+// This module supports no_std, depending only on the alloc crate: the crate
+// including it must declare `extern crate alloc;`.
+
+#[allow(unused_imports)]
+use alloc::{string::String, vec::Vec};
+
+/// DecodeError is the error returned by the decoders.
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub enum DecodeError {
+    /// The input ends in the middle of a value.
+    UnexpectedEof,
+    /// A varint, or a decoded integer, overflows its type.
+    Overflow,
+    /// A field has an unexpected wire type.
+    InvalidWireType,
+    /// An array has the wrong number of elements.
+    InvalidLength,
+    /// A string is not valid UTF-8.
+    InvalidUtf8,
+    /// A bool is encoded as a byte other than 0 or 1.
+    InvalidBool,
+}
+
+impl core::fmt::Display for DecodeError {
+    fn fmt(&self, f: &mut core::fmt::Formatter<'_>) -> core::fmt::Result {
+        f.write_str(match self {
+                DecodeError::UnexpectedEof => "tomino: unexpected end of input",
+                DecodeError::Overflow => "tomino: integer overflow",
+                DecodeError::InvalidWireType => "tomino: invalid wire type",
+                DecodeError::InvalidLength => "tomino: invalid array length",
+                DecodeError::InvalidUtf8 => "tomino: invalid UTF-8 string",
+                DecodeError::InvalidBool => "tomino: invalid bool",
+        })
+    }
+}
+
+/// Timestamp represents amino's well-known types, time.Time and
+/// time.Duration, as seconds and nanoseconds (since the Unix epoch, for
+    /// time.Time).
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq)]
+pub struct Timestamp {
+    pub seconds: i64,
+    pub nanos: i32,
+}
+
+impl Timestamp {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // amino encodes both fields as unsigned varints.
+        if self.seconds != 0 {
+            buf.extend_from_slice(&[0x08]);
+            tomino::put_uvarint(buf, self.seconds as u64);
+        }
+        if self.nanos != 0 {
+            buf.extend_from_slice(&[0x10]);
+            tomino::put_uvarint(buf, self.nanos as u32 as u64);
+        }
+    }
+
+    /// Decodes a Timestamp from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 0)?;
+                    msg.seconds = r.uvarint()? as i64;
+                }
+                2 => {
+                    tomino::expect(wt, 0)?;
+                    msg.nanos = r.uint::<u32>()? as i32;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+/// Userinfo is the tomino message for the type
+/// net/url.Userinfo
+#[derive(Clone, Debug, PartialEq)]
+pub struct Userinfo {
+}
+
+impl Default for Userinfo {
+    fn default() -> Self {
+        Self {
+        }
+    }
+}
+
+impl Userinfo {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, _buf: &mut Vec<u8>) {}
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a Userinfo from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        while !r.done() {
+            let (_, wt) = r.tag()?;
+            r.skip(wt)?;
+        }
+        Ok(Self::default())
+    }
+}
+
+/// URL is the tomino message for the type
+/// net/url.URL
+#[derive(Clone, Debug, PartialEq)]
+pub struct URL {
+    pub scheme: String,
+    pub opaque: String,
+    pub user: Option<Userinfo>,
+    pub host: String,
+    pub path: String,
+    pub raw_path: String,
+    pub omit_host: bool,
+    pub force_query: bool,
+    pub raw_query: String,
+    pub fragment: String,
+    pub raw_fragment: String,
+}
+
+impl Default for URL {
+    fn default() -> Self {
+        Self {
+            scheme: Default::default(),
+            opaque: Default::default(),
+            user: None,
+            host: Default::default(),
+            path: Default::default(),
+            raw_path: Default::default(),
+            omit_host: Default::default(),
+            force_query: Default::default(),
+            raw_query: Default::default(),
+            fragment: Default::default(),
+            raw_fragment: Default::default(),
+        }
+    }
+}
+
+impl URL {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        if !self.scheme.is_empty() {
+            buf.extend_from_slice(&[0x0a]);
+            tomino::put_bytes(buf, self.scheme.as_bytes());
+        }
+        // field number 2
+        if !self.opaque.is_empty() {
+            buf.extend_from_slice(&[0x12]);
+            tomino::put_bytes(buf, self.opaque.as_bytes());
+        }
+        // field number 3
+        // (always empty, skip as there is no write_empty)
+        // field number 4
+        if !self.host.is_empty() {
+            buf.extend_from_slice(&[0x22]);
+            tomino::put_bytes(buf, self.host.as_bytes());
+        }
+        // field number 5
+        if !self.path.is_empty() {
+            buf.extend_from_slice(&[0x2a]);
+            tomino::put_bytes(buf, self.path.as_bytes());
+        }
+        // field number 6
+        if !self.raw_path.is_empty() {
+            buf.extend_from_slice(&[0x32]);
+            tomino::put_bytes(buf, self.raw_path.as_bytes());
+        }
+        // field number 7
+        if self.omit_host {
+            buf.extend_from_slice(&[0x38]);
+            buf.push(self.omit_host as u8);
+        }
+        // field number 8
+        if self.force_query {
+            buf.extend_from_slice(&[0x40]);
+            buf.push(self.force_query as u8);
+        }
+        // field number 9
+        if !self.raw_query.is_empty() {
+            buf.extend_from_slice(&[0x4a]);
+            tomino::put_bytes(buf, self.raw_query.as_bytes());
+        }
+        // field number 10
+        if !self.fragment.is_empty() {
+            buf.extend_from_slice(&[0x52]);
+            tomino::put_bytes(buf, self.fragment.as_bytes());
+        }
+        // field number 11
+        if !self.raw_fragment.is_empty() {
+            buf.extend_from_slice(&[0x5a]);
+            tomino::put_bytes(buf, self.raw_fragment.as_bytes());
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a URL from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 2)?;
+                    msg.scheme = tomino::string(r.bytes()?)?;
+                }
+                2 => {
+                    tomino::expect(wt, 2)?;
+                    msg.opaque = tomino::string(r.bytes()?)?;
+                }
+                3 => {
+                    tomino::expect(wt, 2)?;
+                    msg.user = Some(Userinfo::decode(r.bytes()?)?);
+                }
+                4 => {
+                    tomino::expect(wt, 2)?;
+                    msg.host = tomino::string(r.bytes()?)?;
+                }
+                5 => {
+                    tomino::expect(wt, 2)?;
+                    msg.path = tomino::string(r.bytes()?)?;
+                }
+                6 => {
+                    tomino::expect(wt, 2)?;
+                    msg.raw_path = tomino::string(r.bytes()?)?;
+                }
+                7 => {
+                    tomino::expect(wt, 0)?;
+                    msg.omit_host = r.bool()?;
+                }
+                8 => {
+                    tomino::expect(wt, 0)?;
+                    msg.force_query = r.bool()?;
+                }
+                9 => {
+                    tomino::expect(wt, 2)?;
+                    msg.raw_query = tomino::string(r.bytes()?)?;
+                }
+                10 => {
+                    tomino::expect(wt, 2)?;
+                    msg.fragment = tomino::string(r.bytes()?)?;
+                }
+                11 => {
+                    tomino::expect(wt, 2)?;
+                    msg.raw_fragment = tomino::string(r.bytes()?)?;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+#[derive(Clone, Debug, PartialEq)]
+pub struct TestTypeSlice {
+    pub a: i64,
+    pub b: i64,
+}
+
+impl Default for TestTypeSlice {
+    fn default() -> Self {
+        Self {
+            a: Default::default(),
+            b: Default::default(),
+        }
+    }
+}
+
+impl TestTypeSlice {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        if self.a != 0 {
+            buf.extend_from_slice(&[0x08]);
+            tomino::put_uvarint(buf, tomino::zigzag(self.a as i64));
+        }
+        // field number 2
+        if self.b != 0 {
+            buf.extend_from_slice(&[0x10]);
+            tomino::put_uvarint(buf, tomino::zigzag(self.b as i64));
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a TestTypeSlice from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 0)?;
+                    msg.a = r.int::<i64>()?;
+                }
+                2 => {
+                    tomino::expect(wt, 0)?;
+                    msg.b = r.int::<i64>()?;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+/// TestType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.TestType
+#[derive(Clone, Debug, PartialEq)]
+pub struct TestType {
+    pub time: Timestamp,
+    pub duration: Timestamp,
+    pub fixed_uint: u64,
+    pub byte: u8,
+    pub bytes: Vec<u8>,
+    pub byte_arr: Option<[u8; 4]>,
+    pub zero_arr: [u8; 0],
+    pub int_ptr: Option<i64>,
+    pub slice: Vec<TestTypeSlice>,
+}
+
+impl Default for TestType {
+    fn default() -> Self {
+        Self {
+            time: Default::default(),
+            duration: Default::default(),
+            fixed_uint: Default::default(),
+            byte: Default::default(),
+            bytes: Default::default(),
+            byte_arr: None,
+            zero_arr: [0; 0],
+            int_ptr: None,
+            slice: Default::default(),
+        }
+    }
+}
+
+impl TestType {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        {
+            let start = tomino::begin(buf, &[0x0a]);
+            self.time.encode(buf);
+            tomino::end(buf, start, 1, true);
+        }
+        // field number 2
+        {
+            let start = tomino::begin(buf, &[0x12]);
+            self.duration.encode(buf);
+            tomino::end(buf, start, 1, true);
+        }
+        // field number 3
+        if self.fixed_uint != 0 {
+            buf.extend_from_slice(&[0x19]);
+            buf.extend_from_slice(&self.fixed_uint.to_le_bytes());
+        }
+        // field number 4
+        if self.byte != 0 {
+            buf.extend_from_slice(&[0x20]);
+            tomino::put_uvarint(buf, self.byte as u64);
+        }
+        // field number 5
+        if !self.bytes.is_empty() {
+            buf.extend_from_slice(&[0x2a]);
+            tomino::put_bytes(buf, &self.bytes);
+        }
+        // field number 6
+        if let Some(v) = &self.byte_arr {
+            buf.extend_from_slice(&[0x32]);
+            tomino::put_bytes(buf, v);
+        }
+        // field number 7
+        // (always empty, skip as there is no write_empty)
+        // field number 8
+        if let Some(v) = &self.int_ptr {
+            if *v != 0 {
+                buf.extend_from_slice(&[0x40]);
+                tomino::put_uvarint(buf, tomino::zigzag(*v as i64));
+            }
+        }
+        // field number 9
+        for el in self.slice.iter() {
+            let start = tomino::begin(buf, &[0x4a]);
+            el.encode(buf);
+            tomino::end(buf, start, 1, false);
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a TestType from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 2)?;
+                    msg.time = Timestamp::decode(r.bytes()?)?;
+                }
+                2 => {
+                    tomino::expect(wt, 2)?;
+                    msg.duration = Timestamp::decode(r.bytes()?)?;
+                }
+                3 => {
+                    tomino::expect(wt, 1)?;
+                    msg.fixed_uint = u64::from_le_bytes(r.fixed::<8>()?);
+                }
+                4 => {
+                    tomino::expect(wt, 0)?;
+                    msg.byte = r.uint::<u8>()?;
+                }
+                5 => {
+                    tomino::expect(wt, 2)?;
+                    msg.bytes = r.bytes()?.to_vec();
+                }
+                6 => {
+                    tomino::expect(wt, 2)?;
+                    msg.byte_arr = Some(tomino::byte_array::<4>(r.bytes()?)?);
+                }
+                7 => {
+                    tomino::expect(wt, 2)?;
+                    msg.zero_arr = tomino::byte_array::<0>(r.bytes()?)?;
+                }
+                8 => {
+                    tomino::expect(wt, 0)?;
+                    msg.int_ptr = Some(r.int::<i64>()?);
+                }
+                9 => {
+                    tomino::expect(wt, 2)?;
+                    msg.slice.push(TestTypeSlice::decode(r.bytes()?)?);
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+/// FloatType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.FloatType
+#[derive(Clone, Debug, PartialEq)]
+pub struct FloatType {
+    pub float: f64,
+    pub float32: f32,
+    pub float_ptr: Option<f64>,
+    pub floats: Vec<f32>,
+    pub float_arr: [f64; 2],
+    pub float_ptrs: Vec<Option<f64>>,
+}
+
+impl Default for FloatType {
+    fn default() -> Self {
+        Self {
+            float: Default::default(),
+            float32: Default::default(),
+            float_ptr: None,
+            floats: Default::default(),
+            float_arr: core::array::from_fn(|_| Default::default()),
+            float_ptrs: Default::default(),
+        }
+    }
+}
+
+impl FloatType {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        buf.extend_from_slice(&[0x09]);
+        buf.extend_from_slice(&self.float.to_le_bytes());
+        // field number 2
+        buf.extend_from_slice(&[0x15]);
+        buf.extend_from_slice(&self.float32.to_le_bytes());
+        // field number 3
+        if let Some(v) = &self.float_ptr {
+            buf.extend_from_slice(&[0x19]);
+            buf.extend_from_slice(&v.to_le_bytes());
+        }
+        // field number 4
+        if !self.floats.is_empty() {
+            let start = tomino::begin(buf, &[0x22]);
+            for &el in self.floats.iter() {
+                buf.extend_from_slice(&el.to_le_bytes());
+            }
+            tomino::end(buf, start, 1, false);
+        }
+        // field number 5
+        {
+            let start = tomino::begin(buf, &[0x2a]);
+            for &el in self.float_arr.iter() {
+                buf.extend_from_slice(&el.to_le_bytes());
+            }
+            tomino::end(buf, start, 1, false);
+        }
+        // field number 6
+        if !self.float_ptrs.is_empty() {
+            let start = tomino::begin(buf, &[0x32]);
+            for &el in self.float_ptrs.iter() {
+                buf.extend_from_slice(&el.unwrap_or(0.0).to_le_bytes());
+            }
+            tomino::end(buf, start, 1, false);
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a FloatType from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        let mut i_5 = 0;
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 1)?;
+                    msg.float = f64::from_le_bytes(r.fixed::<8>()?);
+                }
+                2 => {
+                    tomino::expect(wt, 5)?;
+                    msg.float32 = f32::from_le_bytes(r.fixed::<4>()?);
+                }
+                3 => {
+                    tomino::expect(wt, 1)?;
+                    msg.float_ptr = Some(f64::from_le_bytes(r.fixed::<8>()?));
+                }
+                4 => {
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            msg.floats.push(f32::from_le_bytes(sub.fixed::<4>()?));
+                        }
+                    } else {
+                        tomino::expect(wt, 5)?;
+                        msg.floats.push(f32::from_le_bytes(r.fixed::<4>()?));
+                    }
+                }
+                5 => {
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            msg.float_arr[tomino::index(&mut i_5, 2)?] = f64::from_le_bytes(sub.fixed::<8>()?);
+                        }
+                    } else {
+                        tomino::expect(wt, 1)?;
+                        msg.float_arr[tomino::index(&mut i_5, 2)?] = f64::from_le_bytes(r.fixed::<8>()?);
+                    }
+                }
+                6 => {
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            msg.float_ptrs.push(Some(f64::from_le_bytes(sub.fixed::<8>()?)));
+                        }
+                    } else {
+                        tomino::expect(wt, 1)?;
+                        msg.float_ptrs.push(Some(f64::from_le_bytes(r.fixed::<8>()?)));
+                    }
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+#[derive(Clone, Debug, PartialEq)]
+pub struct JSONTypeStruct {
+    pub a: u32,
+    pub b: String,
+}
+
+impl Default for JSONTypeStruct {
+    fn default() -> Self {
+        Self {
+            a: Default::default(),
+            b: Default::default(),
+        }
+    }
+}
+
+impl JSONTypeStruct {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        if self.a != 0 {
+            buf.extend_from_slice(&[0x08]);
+            tomino::put_uvarint(buf, self.a as u64);
+        }
+        // field number 2
+        if !self.b.is_empty() {
+            buf.extend_from_slice(&[0x12]);
+            tomino::put_bytes(buf, self.b.as_bytes());
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a JSONTypeStruct from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 0)?;
+                    msg.a = r.uint::<u32>()?;
+                }
+                2 => {
+                    tomino::expect(wt, 2)?;
+                    msg.b = tomino::string(r.bytes()?)?;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+#[derive(Clone, Debug, PartialEq)]
+pub struct JSONTypeStructs {
+    pub c: bool,
+}
+
+impl Default for JSONTypeStructs {
+    fn default() -> Self {
+        Self {
+            c: Default::default(),
+        }
+    }
+}
+
+impl JSONTypeStructs {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        if self.c {
+            buf.extend_from_slice(&[0x08]);
+            buf.push(self.c as u8);
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a JSONTypeStructs from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 0)?;
+                    msg.c = r.bool()?;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+/// JSONType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.JSONType
+#[derive(Clone, Debug, PartialEq)]
+pub struct JSONType {
+    pub int8: i8,
+    pub name: String,
+    pub int32: i32,
+    pub int64: i64,
+    pub uint: u64,
+    pub bool: bool,
+    pub bytes: Vec<u8>,
+    pub strings: Vec<String>,
+    pub string_ptr: Option<String>,
+    pub slice_ptr: Option<Vec<i16>>,
+    pub ptrs: Vec<Option<i32>>,
+    pub arr: [u16; 2],
+    pub time: Timestamp,
+    pub duration: Timestamp,
+    pub r#struct: JSONTypeStruct,
+    pub structs: [JSONTypeStructs; 1],
+}
+
+impl Default for JSONType {
+    fn default() -> Self {
+        Self {
+            int8: Default::default(),
+            name: Default::default(),
+            int32: Default::default(),
+            int64: Default::default(),
+            uint: Default::default(),
+            bool: Default::default(),
+            bytes: Default::default(),
+            strings: Default::default(),
+            string_ptr: None,
+            slice_ptr: None,
+            ptrs: Default::default(),
+            arr: core::array::from_fn(|_| Default::default()),
+            time: Default::default(),
+            duration: Default::default(),
+            r#struct: Default::default(),
+            structs: core::array::from_fn(|_| Default::default()),
+        }
+    }
+}
+
+impl JSONType {
+    /// Appends the amino binary encoding of self to buf.
+    pub fn encode(&self, buf: &mut Vec<u8>) {
+        // field number 1
+        if self.int8 != 0 {
+            buf.extend_from_slice(&[0x08]);
+            tomino::put_uvarint(buf, tomino::zigzag(self.int8 as i64));
+        }
+        // field number 2
+        if !self.name.is_empty() {
+            buf.extend_from_slice(&[0x12]);
+            tomino::put_bytes(buf, self.name.as_bytes());
+        }
+        // field number 3
+        if self.int32 != 0 {
+            buf.extend_from_slice(&[0x18]);
+            tomino::put_uvarint(buf, tomino::zigzag(self.int32 as i64));
+        }
+        // field number 4
+        if self.int64 != 0 {
+            buf.extend_from_slice(&[0x20]);
+            tomino::put_uvarint(buf, tomino::zigzag(self.int64 as i64));
+        }
+        // field number 5
+        if self.uint != 0 {
+            buf.extend_from_slice(&[0x28]);
+            tomino::put_uvarint(buf, self.uint as u64);
+        }
+        // field number 6
+        if self.bool {
+            buf.extend_from_slice(&[0x30]);
+            buf.push(self.bool as u8);
+        }
+        // field number 7
+        if !self.bytes.is_empty() {
+            buf.extend_from_slice(&[0x3a]);
+            tomino::put_bytes(buf, &self.bytes);
+        }
+        // field number 8
+        for el in self.strings.iter() {
+            buf.extend_from_slice(&[0x42]);
+            tomino::put_bytes(buf, el.as_bytes());
+        }
+        // field number 9
+        if let Some(v) = &self.string_ptr {
+            if !v.is_empty() {
+                buf.extend_from_slice(&[0x4a]);
+                tomino::put_bytes(buf, v.as_bytes());
+            }
+        }
+        // field number 10
+        if let Some(v) = &self.slice_ptr {
+            if !v.is_empty() {
+                let start = tomino::begin(buf, &[0x52]);
+                for &el in v.iter() {
+                    tomino::put_uvarint(buf, tomino::zigzag(el as i64));
+                }
+                tomino::end(buf, start, 1, false);
+            }
+        }
+        // field number 11
+        if !self.ptrs.is_empty() {
+            let start = tomino::begin(buf, &[0x5a]);
+            for &el in self.ptrs.iter() {
+                tomino::put_uvarint(buf, tomino::zigzag(el.unwrap_or(0) as i64));
+            }
+            tomino::end(buf, start, 1, false);
+        }
+        // field number 12
+        {
+            let start = tomino::begin(buf, &[0x62]);
+            for &el in self.arr.iter() {
+                tomino::put_uvarint(buf, el as u64);
+            }
+            tomino::end(buf, start, 1, false);
+        }
+        // field number 13
+        {
+            let start = tomino::begin(buf, &[0x6a]);
+            self.time.encode(buf);
+            tomino::end(buf, start, 1, true);
+        }
+        // field number 14
+        {
+            let start = tomino::begin(buf, &[0x72]);
+            self.duration.encode(buf);
+            tomino::end(buf, start, 1, true);
+        }
+        // field number 15
+        {
+            let start = tomino::begin(buf, &[0x7a]);
+            self.r#struct.encode(buf);
+            tomino::end(buf, start, 1, true);
+        }
+        // field number 16
+        for el in self.structs.iter() {
+            let start = tomino::begin(buf, &[0x82, 0x01]);
+            el.encode(buf);
+            tomino::end(buf, start, 2, false);
+        }
+    }
+
+    /// Returns the amino binary encoding of self.
+    pub fn encode_to_vec(&self) -> Vec<u8> {
+        let mut buf = Vec::new();
+        self.encode(&mut buf);
+        buf
+    }
+
+    /// Decodes a JSONType from its amino binary encoding.
+    pub fn decode(b: &[u8]) -> Result<Self, DecodeError> {
+        let mut r = tomino::Reader::new(b);
+        let mut msg = Self::default();
+        let mut i_12 = 0;
+        let mut i_16 = 0;
+        while !r.done() {
+            let (num, wt) = r.tag()?;
+            match num {
+                1 => {
+                    tomino::expect(wt, 0)?;
+                    msg.int8 = r.int::<i8>()?;
+                }
+                2 => {
+                    tomino::expect(wt, 2)?;
+                    msg.name = tomino::string(r.bytes()?)?;
+                }
+                3 => {
+                    tomino::expect(wt, 0)?;
+                    msg.int32 = r.int::<i32>()?;
+                }
+                4 => {
+                    tomino::expect(wt, 0)?;
+                    msg.int64 = r.int::<i64>()?;
+                }
+                5 => {
+                    tomino::expect(wt, 0)?;
+                    msg.uint = r.uvarint()?;
+                }
+                6 => {
+                    tomino::expect(wt, 0)?;
+                    msg.bool = r.bool()?;
+                }
+                7 => {
+                    tomino::expect(wt, 2)?;
+                    msg.bytes = r.bytes()?.to_vec();
+                }
+                8 => {
+                    tomino::expect(wt, 2)?;
+                    msg.strings.push(tomino::string(r.bytes()?)?);
+                }
+                9 => {
+                    tomino::expect(wt, 2)?;
+                    msg.string_ptr = Some(tomino::string(r.bytes()?)?);
+                }
+                10 => {
+                    let dst = msg.slice_ptr.get_or_insert_with(Vec::new);
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            dst.push(sub.int::<i16>()?);
+                        }
+                    } else {
+                        tomino::expect(wt, 0)?;
+                        dst.push(r.int::<i16>()?);
+                    }
+                }
+                11 => {
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            msg.ptrs.push(Some(sub.int::<i32>()?));
+                        }
+                    } else {
+                        tomino::expect(wt, 0)?;
+                        msg.ptrs.push(Some(r.int::<i32>()?));
+                    }
+                }
+                12 => {
+                    if wt == 2 {
+                        let mut sub = tomino::Reader::new(r.bytes()?);
+                        while !sub.done() {
+                            msg.arr[tomino::index(&mut i_12, 2)?] = sub.uint::<u16>()?;
+                        }
+                    } else {
+                        tomino::expect(wt, 0)?;
+                        msg.arr[tomino::index(&mut i_12, 2)?] = r.uint::<u16>()?;
+                    }
+                }
+                13 => {
+                    tomino::expect(wt, 2)?;
+                    msg.time = Timestamp::decode(r.bytes()?)?;
+                }
+                14 => {
+                    tomino::expect(wt, 2)?;
+                    msg.duration = Timestamp::decode(r.bytes()?)?;
+                }
+                15 => {
+                    tomino::expect(wt, 2)?;
+                    msg.r#struct = JSONTypeStruct::decode(r.bytes()?)?;
+                }
+                16 => {
+                    tomino::expect(wt, 2)?;
+                    msg.structs[tomino::index(&mut i_16, 1)?] = JSONTypeStructs::decode(r.bytes()?)?;
+                }
+                _ => r.skip(wt)?,
+            }
+        }
+        Ok(msg)
+    }
+}
+
+// ---
+// encoding runtime
+
+mod tomino {
+    #![allow(dead_code)]
+
+    use super::DecodeError;
+    use alloc::{string::String, vec::Vec};
+
+    pub fn zigzag(x: i64) -> u64 {
+        ((x << 1) ^ (x >> 63)) as u64
+    }
+
+    pub fn unzigzag(u: u64) -> i64 {
+        ((u >> 1) as i64) ^ -((u & 1) as i64)
+    }
+
+    pub fn put_uvarint(buf: &mut Vec<u8>, mut x: u64) {
+        while x >= 0x80 {
+            buf.push(x as u8 | 0x80);
+            x >>= 7;
+        }
+        buf.push(x as u8);
+    }
+
+    pub fn put_bytes(buf: &mut Vec<u8>, b: &[u8]) {
+        put_uvarint(buf, b.len() as u64);
+        buf.extend_from_slice(b);
+    }
+
+    /// Writes the tag of a length-prefixed value, returning the position
+    /// where the value starts.
+    pub fn begin(buf: &mut Vec<u8>, tag: &[u8]) -> usize {
+        buf.extend_from_slice(tag);
+        buf.len()
+    }
+
+    /// Inserts the length of the value started at start. If the value is
+    /// empty and omit_empty is set, its tag is removed instead.
+    pub fn end(buf: &mut Vec<u8>, start: usize, tag_len: usize, omit_empty: bool) {
+        let n = buf.len() - start;
+        if n == 0 && omit_empty {
+            buf.truncate(start - tag_len);
+            return;
+        }
+        // append the length, and move it before the value.
+        put_uvarint(buf, n as u64);
+        let prefix = buf.len() - start - n;
+        buf[start..].rotate_right(prefix);
+    }
+
+    pub fn expect(got: u8, want: u8) -> Result<(), DecodeError> {
+        if got != want {
+            return Err(DecodeError::InvalidWireType);
+        }
+        Ok(())
+    }
+
+    pub fn string(b: &[u8]) -> Result<String, DecodeError> {
+        String::from_utf8(b.to_vec()).map_err(|_| DecodeError::InvalidUtf8)
+    }
+
+    pub fn byte_array<const N: usize>(b: &[u8]) -> Result<[u8; N], DecodeError> {
+        b.try_into().map_err(|_| DecodeError::InvalidLength)
+    }
+
+    /// Returns the index of the next element of an array of length n.
+    pub fn index(i: &mut usize, n: usize) -> Result<usize, DecodeError> {
+        if *i >= n {
+            return Err(DecodeError::InvalidLength);
+        }
+        *i += 1;
+        Ok(*i - 1)
+    }
+
+    pub struct Reader<'a> {
+        buf: &'a [u8],
+        pos: usize,
+    }
+
+    impl<'a> Reader<'a> {
+        pub fn new(buf: &'a [u8]) -> Self {
+            Reader { buf, pos: 0 }
+        }
+
+        pub fn done(&self) -> bool {
+            self.pos >= self.buf.len()
+        }
+
+        pub fn uvarint(&mut self) -> Result<u64, DecodeError> {
+            let mut x: u64 = 0;
+            for i in 0..10 {
+                let b = *self.buf.get(self.pos).ok_or(DecodeError::UnexpectedEof)?;
+                self.pos += 1;
+                if i == 9 && b > 1 {
+                    return Err(DecodeError::Overflow);
+                }
+                x |= u64::from(b & 0x7f) << (i * 7);
+                if b < 0x80 {
+                    return Ok(x);
+                }
+            }
+            Err(DecodeError::Overflow)
+        }
+
+        // bool reads a single byte, which like in amino must be 0 or 1.
+        pub fn bool(&mut self) -> Result<bool, DecodeError> {
+            let b = *self.buf.get(self.pos).ok_or(DecodeError::UnexpectedEof)?;
+            self.pos += 1;
+            match b {
+                0 => Ok(false),
+                1 => Ok(true),
+                _ => Err(DecodeError::InvalidBool),
+            }
+        }
+
+        pub fn uint<T: TryFrom<u64>>(&mut self) -> Result<T, DecodeError> {
+            T::try_from(self.uvarint()?).map_err(|_| DecodeError::Overflow)
+        }
+
+        pub fn int<T: TryFrom<i64>>(&mut self) -> Result<T, DecodeError> {
+            T::try_from(unzigzag(self.uvarint()?)).map_err(|_| DecodeError::Overflow)
+        }
+
+        pub fn tag(&mut self) -> Result<(u64, u8), DecodeError> {
+            let x = self.uvarint()?;
+            Ok((x >> 3, (x & 7) as u8))
+        }
+
+        pub fn fixed<const N: usize>(&mut self) -> Result<[u8; N], DecodeError> {
+            let b = self.buf.get(self.pos..self.pos + N).ok_or(DecodeError::UnexpectedEof)?;
+            self.pos += N;
+            let mut x = [0; N];
+            x.copy_from_slice(b);
+            Ok(x)
+        }
+
+        pub fn bytes(&mut self) -> Result<&'a [u8], DecodeError> {
+            let n = self.uvarint()?;
+            if n > (self.buf.len() - self.pos) as u64 {
+                return Err(DecodeError::UnexpectedEof);
+            }
+            let b = &self.buf[self.pos..self.pos + n as usize];
+            self.pos += n as usize;
+            Ok(b)
+        }
+
+        pub fn skip(&mut self, wt: u8) -> Result<(), DecodeError> {
+            match wt {
+                0 => {
+                    self.uvarint()?;
+                }
+                1 => {
+                    self.fixed::<8>()?;
+                }
+                2 => {
+                    self.bytes()?;
+                }
+                5 => {
+                    self.fixed::<4>()?;
+                }
+                _ => return Err(DecodeError::InvalidWireType),
+            }
+            Ok(())
+        }
+    }
+}
@@ -0,0 +1,1244 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+
+// Userinfo is the tomino message for the type
+// net/url.Userinfo
+export interface Userinfo {}
+
+// defaultUserinfo returns a Userinfo with all fields set to their zero values.
+export function defaultUserinfo(): Userinfo {
+	return {};
+}
+
+// URL is the tomino message for the type
+// net/url.URL
+export interface URL {
+	Scheme: string;
+	Opaque: string;
+	User?: Userinfo;
+	Host: string;
+	Path: string;
+	RawPath: string;
+	OmitHost: boolean;
+	ForceQuery: boolean;
+	RawQuery: string;
+	Fragment: string;
+	RawFragment: string;
+}
+
+// defaultURL returns a URL with all fields set to their zero values.
+export function defaultURL(): URL {
+	return {
+		Scheme: "",
+		Opaque: "",
+		Host: "",
+		Path: "",
+		RawPath: "",
+		OmitHost: false,
+		ForceQuery: false,
+		RawQuery: "",
+		Fragment: "",
+		RawFragment: "",
+	};
+}
+
+// Time is the tomino message for the type
+// time.Time
+export interface Time {
+	seconds: bigint;
+	nanoseconds: number;
+}
+
+// defaultTime returns a Time with all fields set to their zero values.
+export function defaultTime(): Time {
+	return {
+		seconds: 0n,
+		nanoseconds: 0,
+	};
+}
+
+// Duration is the tomino message for the type
+// time.Duration
+export interface Duration {
+	seconds: bigint;
+	nanoseconds: number;
+}
+
+// defaultDuration returns a Duration with all fields set to their zero values.
+export function defaultDuration(): Duration {
+	return {
+		seconds: 0n,
+		nanoseconds: 0,
+	};
+}
+
+export interface TestTypeSlice {
+	A: bigint;
+	B: bigint;
+}
+
+// defaultTestTypeSlice returns a TestTypeSlice with all fields set to their zero values.
+export function defaultTestTypeSlice(): TestTypeSlice {
+	return {
+		A: 0n,
+		B: 0n,
+	};
+}
+
+// TestType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.TestType
+export interface TestType {
+	Time: Time;
+	Duration: Duration;
+	FixedUint: bigint;
+	Byte: number;
+	Bytes: Uint8Array;
+	ByteArr?: Uint8Array;
+	ZeroArr: Uint8Array;
+	IntPtr?: bigint;
+	Slice: TestTypeSlice[];
+}
+
+// defaultTestType returns a TestType with all fields set to their zero values.
+export function defaultTestType(): TestType {
+	return {
+		Time: defaultTime(),
+		Duration: defaultDuration(),
+		FixedUint: 0n,
+		Byte: 0,
+		Bytes: new Uint8Array(0),
+		ZeroArr: new Uint8Array(0),
+		Slice: [],
+	};
+}
+
+// FloatType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.FloatType
+export interface FloatType {
+	Float: number;
+	Float32: number;
+	FloatPtr?: number;
+	Floats: number[];
+	FloatArr: number[];
+	FloatPtrs: (number | null)[];
+}
+
+// defaultFloatType returns a FloatType with all fields set to their zero values.
+export function defaultFloatType(): FloatType {
+	return {
+		Float: 0,
+		Float32: 0,
+		Floats: [],
+		FloatArr: Array.from({ length: 2 }, () => 0),
+		FloatPtrs: [],
+	};
+}
+
+export interface JSONTypeStruct {
+	A: number;
+	B: string;
+}
+
+// defaultJSONTypeStruct returns a JSONTypeStruct with all fields set to their zero values.
+export function defaultJSONTypeStruct(): JSONTypeStruct {
+	return {
+		A: 0,
+		B: "",
+	};
+}
+
+export interface JSONTypeStructs {
+	C: boolean;
+}
+
+// defaultJSONTypeStructs returns a JSONTypeStructs with all fields set to their zero values.
+export function defaultJSONTypeStructs(): JSONTypeStructs {
+	return {
+		C: false,
+	};
+}
+
+// JSONType is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.JSONType
+export interface JSONType {
+	int8: number;
+	name: string;
+	int32: number;
+	int64: bigint;
+	Uint: bigint;
+	bool: boolean;
+	bytes: Uint8Array;
+	strings: string[];
+	string_ptr?: string;
+	slice_ptr?: number[];
+	ptrs: (number | null)[];
+	arr: number[];
+	time: Time;
+	duration: Duration;
+	struct: JSONTypeStruct;
+	structs: JSONTypeStructs[];
+}
+
+// defaultJSONType returns a JSONType with all fields set to their zero values.
+export function defaultJSONType(): JSONType {
+	return {
+		int8: 0,
+		name: "",
+		int32: 0,
+		int64: 0n,
+		Uint: 0n,
+		bool: false,
+		bytes: new Uint8Array(0),
+		strings: [],
+		ptrs: [],
+		arr: Array.from({ length: 2 }, () => 0),
+		time: defaultTime(),
+		duration: defaultDuration(),
+		struct: defaultJSONTypeStruct(),
+		structs: Array.from({ length: 1 }, () => defaultJSONTypeStructs()),
+	};
+}
+
+// encodeURL encodes msg in amino's binary encoding.
+export function encodeURL(msg: URL): Uint8Array {
+	const w = new TominoWriter();
+	writeURL(w, msg);
+	return w.finish();
+}
+
+// decodeURL decodes a URL from amino's binary encoding.
+export function decodeURL(b: Uint8Array): URL {
+	const r = new TominoReader(b);
+	return readURL(r, b.length);
+}
+
+// encodeTestType encodes msg in amino's binary encoding.
+export function encodeTestType(msg: TestType): Uint8Array {
+	const w = new TominoWriter();
+	writeTestType(w, msg);
+	return w.finish();
+}
+
+// decodeTestType decodes a TestType from amino's binary encoding.
+export function decodeTestType(b: Uint8Array): TestType {
+	const r = new TominoReader(b);
+	return readTestType(r, b.length);
+}
+
+// encodeFloatType encodes msg in amino's binary encoding.
+export function encodeFloatType(msg: FloatType): Uint8Array {
+	const w = new TominoWriter();
+	writeFloatType(w, msg);
+	return w.finish();
+}
+
+// decodeFloatType decodes a FloatType from amino's binary encoding.
+export function decodeFloatType(b: Uint8Array): FloatType {
+	const r = new TominoReader(b);
+	return readFloatType(r, b.length);
+}
+
+// encodeJSONType encodes msg in amino's binary encoding.
+export function encodeJSONType(msg: JSONType): Uint8Array {
+	const w = new TominoWriter();
+	writeJSONType(w, msg);
+	return w.finish();
+}
+
+// decodeJSONType decodes a JSONType from amino's binary encoding.
+export function decodeJSONType(b: Uint8Array): JSONType {
+	const r = new TominoReader(b);
+	return readJSONType(r, b.length);
+}
+
+function readUserinfo(r: TominoReader, end: number): Userinfo {
+	const msg = defaultUserinfo();
+	while (r.pos < end) {
+		r.skip(r.tag()[1]);
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeURL(w: TominoWriter, msg: URL): void {
+	// field number 1
+	if (msg.Scheme.length !== 0) {
+		w.raw(0x0a);
+		w.string(msg.Scheme);
+	}
+	// field number 2
+	if (msg.Opaque.length !== 0) {
+		w.raw(0x12);
+		w.string(msg.Opaque);
+	}
+	// field number 3
+	// (always empty, skip as there is no write_empty)
+	// field number 4
+	if (msg.Host.length !== 0) {
+		w.raw(0x22);
+		w.string(msg.Host);
+	}
+	// field number 5
+	if (msg.Path.length !== 0) {
+		w.raw(0x2a);
+		w.string(msg.Path);
+	}
+	// field number 6
+	if (msg.RawPath.length !== 0) {
+		w.raw(0x32);
+		w.string(msg.RawPath);
+	}
+	// field number 7
+	if (msg.OmitHost) {
+		w.raw(0x38);
+		w.bool(msg.OmitHost);
+	}
+	// field number 8
+	if (msg.ForceQuery) {
+		w.raw(0x40);
+		w.bool(msg.ForceQuery);
+	}
+	// field number 9
+	if (msg.RawQuery.length !== 0) {
+		w.raw(0x4a);
+		w.string(msg.RawQuery);
+	}
+	// field number 10
+	if (msg.Fragment.length !== 0) {
+		w.raw(0x52);
+		w.string(msg.Fragment);
+	}
+	// field number 11
+	if (msg.RawFragment.length !== 0) {
+		w.raw(0x5a);
+		w.string(msg.RawFragment);
+	}
+}
+
+function readURL(r: TominoReader, end: number): URL {
+	const msg = defaultURL();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 2);
+			msg.Scheme = r.string();
+		} else if (num === 2) {
+			r.expect(wt, 2);
+			msg.Opaque = r.string();
+		} else if (num === 3) {
+			r.expect(wt, 2);
+			msg.User = readUserinfo(r, r.end());
+		} else if (num === 4) {
+			r.expect(wt, 2);
+			msg.Host = r.string();
+		} else if (num === 5) {
+			r.expect(wt, 2);
+			msg.Path = r.string();
+		} else if (num === 6) {
+			r.expect(wt, 2);
+			msg.RawPath = r.string();
+		} else if (num === 7) {
+			r.expect(wt, 0);
+			msg.OmitHost = r.bool();
+		} else if (num === 8) {
+			r.expect(wt, 0);
+			msg.ForceQuery = r.bool();
+		} else if (num === 9) {
+			r.expect(wt, 2);
+			msg.RawQuery = r.string();
+		} else if (num === 10) {
+			r.expect(wt, 2);
+			msg.Fragment = r.string();
+		} else if (num === 11) {
+			r.expect(wt, 2);
+			msg.RawFragment = r.string();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeTime(w: TominoWriter, msg: Time): void {
+	// field number 1
+	if (msg.seconds !== 0n) {
+		w.raw(0x08);
+		w.uint64(msg.seconds);
+	}
+	// field number 2
+	if (msg.nanoseconds !== 0) {
+		w.raw(0x10);
+		w.uint32(msg.nanoseconds);
+	}
+}
+
+function readTime(r: TominoReader, end: number): Time {
+	const msg = defaultTime();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.seconds = r.uint64();
+		} else if (num === 2) {
+			r.expect(wt, 0);
+			msg.nanoseconds = r.uint32();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeDuration(w: TominoWriter, msg: Duration): void {
+	// field number 1
+	if (msg.seconds !== 0n) {
+		w.raw(0x08);
+		w.uint64(msg.seconds);
+	}
+	// field number 2
+	if (msg.nanoseconds !== 0) {
+		w.raw(0x10);
+		w.uint32(msg.nanoseconds);
+	}
+}
+
+function readDuration(r: TominoReader, end: number): Duration {
+	const msg = defaultDuration();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.seconds = r.uint64();
+		} else if (num === 2) {
+			r.expect(wt, 0);
+			msg.nanoseconds = r.uint32();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeTestTypeSlice(w: TominoWriter, msg: TestTypeSlice): void {
+	// field number 1
+	if (msg.A !== 0n) {
+		w.raw(0x08);
+		w.int64(msg.A);
+	}
+	// field number 2
+	if (msg.B !== 0n) {
+		w.raw(0x10);
+		w.int64(msg.B);
+	}
+}
+
+function readTestTypeSlice(r: TominoReader, end: number): TestTypeSlice {
+	const msg = defaultTestTypeSlice();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.A = r.int64();
+		} else if (num === 2) {
+			r.expect(wt, 0);
+			msg.B = r.int64();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeTestType(w: TominoWriter, msg: TestType): void {
+	// field number 1
+	{
+		const start = w.begin(0x0a);
+		writeTime(w, msg.Time);
+		w.end(start, 1, true);
+	}
+	// field number 2
+	{
+		const start = w.begin(0x12);
+		writeDuration(w, msg.Duration);
+		w.end(start, 1, true);
+	}
+	// field number 3
+	if (msg.FixedUint !== 0n) {
+		w.raw(0x19);
+		w.fixed64(msg.FixedUint);
+	}
+	// field number 4
+	if (msg.Byte !== 0) {
+		w.raw(0x20);
+		w.uint32(msg.Byte);
+	}
+	// field number 5
+	if (msg.Bytes.length !== 0) {
+		w.raw(0x2a);
+		w.bytes(msg.Bytes);
+	}
+	// field number 6
+	if (msg.ByteArr != null) {
+		w.raw(0x32);
+		w.byteArray(msg.ByteArr, 4);
+	}
+	// field number 7
+	// (always empty, skip as there is no write_empty)
+	// field number 8
+	if (msg.IntPtr != null) {
+		if (msg.IntPtr !== 0n) {
+			w.raw(0x40);
+			w.int64(msg.IntPtr);
+		}
+	}
+	// field number 9
+	for (const el of msg.Slice) {
+		const start = w.begin(0x4a);
+		writeTestTypeSlice(w, el);
+		w.end(start, 1, false);
+	}
+}
+
+function readTestType(r: TominoReader, end: number): TestType {
+	const msg = defaultTestType();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 2);
+			msg.Time = readTime(r, r.end());
+		} else if (num === 2) {
+			r.expect(wt, 2);
+			msg.Duration = readDuration(r, r.end());
+		} else if (num === 3) {
+			r.expect(wt, 1);
+			msg.FixedUint = r.fixed64();
+		} else if (num === 4) {
+			r.expect(wt, 0);
+			msg.Byte = r.uint32(8);
+		} else if (num === 5) {
+			r.expect(wt, 2);
+			msg.Bytes = r.bytes();
+		} else if (num === 6) {
+			r.expect(wt, 2);
+			msg.ByteArr = r.byteArray(4);
+		} else if (num === 7) {
+			r.expect(wt, 2);
+			msg.ZeroArr = r.byteArray(0);
+		} else if (num === 8) {
+			r.expect(wt, 0);
+			msg.IntPtr = r.int64();
+		} else if (num === 9) {
+			r.expect(wt, 2);
+			msg.Slice.push(readTestTypeSlice(r, r.end()));
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeFloatType(w: TominoWriter, msg: FloatType): void {
+	// field number 1
+	w.raw(0x09);
+	w.double(msg.Float);
+	// field number 2
+	w.raw(0x15);
+	w.float(msg.Float32);
+	// field number 3
+	if (msg.FloatPtr != null) {
+		w.raw(0x19);
+		w.double(msg.FloatPtr);
+	}
+	// field number 4
+	if (msg.Floats.length !== 0) {
+		const start = w.begin(0x22);
+		for (const el of msg.Floats) {
+			w.float(el);
+		}
+		w.end(start, 1, false);
+	}
+	// field number 5
+	checkLength(msg.FloatArr, 2);
+	{
+		const start = w.begin(0x2a);
+		for (const el of msg.FloatArr) {
+			w.double(el);
+		}
+		w.end(start, 1, false);
+	}
+	// field number 6
+	if (msg.FloatPtrs.length !== 0) {
+		const start = w.begin(0x32);
+		for (const el of msg.FloatPtrs) {
+			w.double(el ?? 0);
+		}
+		w.end(start, 1, false);
+	}
+}
+
+function readFloatType(r: TominoReader, end: number): FloatType {
+	const msg = defaultFloatType();
+	let i5 = 0;
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 1);
+			msg.Float = r.double();
+		} else if (num === 2) {
+			r.expect(wt, 5);
+			msg.Float32 = r.float();
+		} else if (num === 3) {
+			r.expect(wt, 1);
+			msg.FloatPtr = r.double();
+		} else if (num === 4) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					msg.Floats.push(r.float());
+				}
+			} else {
+				r.expect(wt, 5);
+				msg.Floats.push(r.float());
+			}
+		} else if (num === 5) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					msg.FloatArr[r.index(i5++, 2)] = r.double();
+				}
+			} else {
+				r.expect(wt, 1);
+				msg.FloatArr[r.index(i5++, 2)] = r.double();
+			}
+		} else if (num === 6) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					msg.FloatPtrs.push(r.double());
+				}
+			} else {
+				r.expect(wt, 1);
+				msg.FloatPtrs.push(r.double());
+			}
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeJSONTypeStruct(w: TominoWriter, msg: JSONTypeStruct): void {
+	// field number 1
+	if (msg.A !== 0) {
+		w.raw(0x08);
+		w.uint32(msg.A);
+	}
+	// field number 2
+	if (msg.B.length !== 0) {
+		w.raw(0x12);
+		w.string(msg.B);
+	}
+}
+
+function readJSONTypeStruct(r: TominoReader, end: number): JSONTypeStruct {
+	const msg = defaultJSONTypeStruct();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.A = r.uint32();
+		} else if (num === 2) {
+			r.expect(wt, 2);
+			msg.B = r.string();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeJSONTypeStructs(w: TominoWriter, msg: JSONTypeStructs): void {
+	// field number 1
+	if (msg.C) {
+		w.raw(0x08);
+		w.bool(msg.C);
+	}
+}
+
+function readJSONTypeStructs(r: TominoReader, end: number): JSONTypeStructs {
+	const msg = defaultJSONTypeStructs();
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.C = r.bool();
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+function writeJSONType(w: TominoWriter, msg: JSONType): void {
+	// field number 1
+	if (msg.int8 !== 0) {
+		w.raw(0x08);
+		w.int32(msg.int8);
+	}
+	// field number 2
+	if (msg.name.length !== 0) {
+		w.raw(0x12);
+		w.string(msg.name);
+	}
+	// field number 3
+	if (msg.int32 !== 0) {
+		w.raw(0x18);
+		w.int32(msg.int32);
+	}
+	// field number 4
+	if (msg.int64 !== 0n) {
+		w.raw(0x20);
+		w.int64(msg.int64);
+	}
+	// field number 5
+	if (msg.Uint !== 0n) {
+		w.raw(0x28);
+		w.uint64(msg.Uint);
+	}
+	// field number 6
+	if (msg.bool) {
+		w.raw(0x30);
+		w.bool(msg.bool);
+	}
+	// field number 7
+	if (msg.bytes.length !== 0) {
+		w.raw(0x3a);
+		w.bytes(msg.bytes);
+	}
+	// field number 8
+	for (const el of msg.strings) {
+		w.raw(0x42);
+		w.string(el);
+	}
+	// field number 9
+	if (msg.string_ptr != null) {
+		if (msg.string_ptr.length !== 0) {
+			w.raw(0x4a);
+			w.string(msg.string_ptr);
+		}
+	}
+	// field number 10
+	if (msg.slice_ptr != null) {
+		if (msg.slice_ptr.length !== 0) {
+			const start = w.begin(0x52);
+			for (const el of msg.slice_ptr) {
+				w.int32(el);
+			}
+			w.end(start, 1, false);
+		}
+	}
+	// field number 11
+	if (msg.ptrs.length !== 0) {
+		const start = w.begin(0x5a);
+		for (const el of msg.ptrs) {
+			w.int32(el ?? 0);
+		}
+		w.end(start, 1, false);
+	}
+	// field number 12
+	checkLength(msg.arr, 2);
+	{
+		const start = w.begin(0x62);
+		for (const el of msg.arr) {
+			w.uint32(el);
+		}
+		w.end(start, 1, false);
+	}
+	// field number 13
+	{
+		const start = w.begin(0x6a);
+		writeTime(w, msg.time);
+		w.end(start, 1, true);
+	}
+	// field number 14
+	{
+		const start = w.begin(0x72);
+		writeDuration(w, msg.duration);
+		w.end(start, 1, true);
+	}
+	// field number 15
+	{
+		const start = w.begin(0x7a);
+		writeJSONTypeStruct(w, msg.struct);
+		w.end(start, 1, true);
+	}
+	// field number 16
+	checkLength(msg.structs, 1);
+	for (const el of msg.structs) {
+		const start = w.begin(0x82, 0x01);
+		writeJSONTypeStructs(w, el);
+		w.end(start, 2, false);
+	}
+}
+
+function readJSONType(r: TominoReader, end: number): JSONType {
+	const msg = defaultJSONType();
+	let i12 = 0;
+	let i16 = 0;
+	while (r.pos < end) {
+		const [num, wt] = r.tag();
+		if (num === 1) {
+			r.expect(wt, 0);
+			msg.int8 = r.int32(8);
+		} else if (num === 2) {
+			r.expect(wt, 2);
+			msg.name = r.string();
+		} else if (num === 3) {
+			r.expect(wt, 0);
+			msg.int32 = r.int32();
+		} else if (num === 4) {
+			r.expect(wt, 0);
+			msg.int64 = r.int64();
+		} else if (num === 5) {
+			r.expect(wt, 0);
+			msg.Uint = r.uint64();
+		} else if (num === 6) {
+			r.expect(wt, 0);
+			msg.bool = r.bool();
+		} else if (num === 7) {
+			r.expect(wt, 2);
+			msg.bytes = r.bytes();
+		} else if (num === 8) {
+			r.expect(wt, 2);
+			msg.strings.push(r.string());
+		} else if (num === 9) {
+			r.expect(wt, 2);
+			msg.string_ptr = r.string();
+		} else if (num === 10) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					(msg.slice_ptr ??= []).push(r.int32(16));
+				}
+			} else {
+				r.expect(wt, 0);
+				(msg.slice_ptr ??= []).push(r.int32(16));
+			}
+		} else if (num === 11) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					msg.ptrs.push(r.int32());
+				}
+			} else {
+				r.expect(wt, 0);
+				msg.ptrs.push(r.int32());
+			}
+		} else if (num === 12) {
+			if (wt === 2) {
+				const stop = r.end();
+				while (r.pos < stop) {
+					msg.arr[r.index(i12++, 2)] = r.uint32(16);
+				}
+			} else {
+				r.expect(wt, 0);
+				msg.arr[r.index(i12++, 2)] = r.uint32(16);
+			}
+		} else if (num === 13) {
+			r.expect(wt, 2);
+			msg.time = readTime(r, r.end());
+		} else if (num === 14) {
+			r.expect(wt, 2);
+			msg.duration = readDuration(r, r.end());
+		} else if (num === 15) {
+			r.expect(wt, 2);
+			msg.struct = readJSONTypeStruct(r, r.end());
+		} else if (num === 16) {
+			r.expect(wt, 2);
+			msg.structs[r.index(i16++, 1)] = readJSONTypeStructs(r, r.end());
+		} else {
+			r.skip(wt);
+		}
+	}
+	r.done(end);
+	return msg;
+}
+
+// ---
+// encoding runtime
+
+const textEncoder = new TextEncoder();
+// strings are not required to be valid UTF-8, but a JavaScript string can't
+// hold invalid UTF-8, so it is rejected rather than replaced with U+FFFD.
+// Likewise, unpaired surrogates are rejected when encoding.
+const textDecoder = new TextDecoder("utf-8", { fatal: true });
+const unpairedSurrogate = /[\uD800-\uDBFF](?![\uDC00-\uDFFF])|(?<![\uD800-\uDBFF])[\uDC00-\uDFFF]/;
+
+function uvarintSize(x: number): number {
+	let n = 1;
+	for (; x > 0x7f; n++) {
+		x = Math.floor(x / 0x80);
+	}
+	return n;
+}
+
+// putUvarint writes x, a non-negative integer up to 2^53, at buf[pos].
+// It returns the position after the written bytes.
+function putUvarint(buf: Uint8Array, pos: number, x: number): number {
+	while (x > 0x7f) {
+		buf[pos++] = (x % 0x80) | 0x80;
+		x = Math.floor(x / 0x80);
+	}
+	buf[pos++] = x;
+	return pos;
+}
+
+function checkLength(x: { length: number }, n: number): void {
+	if (x.length !== n) {
+		throw new Error(`tomino: expected array of length ${n}, got ${x.length}`);
+	}
+}
+
+class TominoWriter {
+	buf: Uint8Array = new Uint8Array(64);
+	view: DataView = new DataView(this.buf.buffer);
+	pos = 0;
+
+	grow(n: number): void {
+		if (this.pos + n <= this.buf.length) {
+			return;
+		}
+		let size = this.buf.length * 2;
+		while (size < this.pos + n) {
+			size *= 2;
+		}
+		const buf = new Uint8Array(size);
+		buf.set(this.buf.subarray(0, this.pos));
+		this.buf = buf;
+		this.view = new DataView(buf.buffer);
+	}
+
+	finish(): Uint8Array {
+		return this.buf.slice(0, this.pos);
+	}
+
+	raw(...b: number[]): void {
+		this.grow(b.length);
+		for (const x of b) {
+			this.buf[this.pos++] = x;
+		}
+	}
+
+	uvarint(x: number): void {
+		this.grow(8);
+		this.pos = putUvarint(this.buf, this.pos, x);
+	}
+
+	uvarint64(x: bigint): void {
+		this.grow(10);
+		while (x > 0x7fn) {
+			this.buf[this.pos++] = Number(x & 0x7fn) | 0x80;
+			x >>= 7n;
+		}
+		this.buf[this.pos++] = Number(x);
+	}
+
+	bool(x: boolean): void {
+		this.raw(x ? 1 : 0);
+	}
+
+	uint32(x: number): void {
+		this.uvarint(x >>> 0);
+	}
+
+	int32(x: number): void {
+		this.uvarint(((x << 1) ^ (x >> 31)) >>> 0);
+	}
+
+	uint64(x: bigint): void {
+		this.uvarint64(BigInt.asUintN(64, x));
+	}
+
+	int64(x: bigint): void {
+		x = BigInt.asIntN(64, x);
+		this.uvarint64(BigInt.asUintN(64, (x << 1n) ^ (x >> 63n)));
+	}
+
+	fixed64(x: bigint): void {
+		this.grow(8);
+		this.view.setBigUint64(this.pos, BigInt.asUintN(64, x), true);
+		this.pos += 8;
+	}
+
+	sfixed64(x: bigint): void {
+		this.grow(8);
+		this.view.setBigInt64(this.pos, BigInt.asIntN(64, x), true);
+		this.pos += 8;
+	}
+
+	fixed32(x: number): void {
+		this.grow(4);
+		this.view.setUint32(this.pos, x >>> 0, true);
+		this.pos += 4;
+	}
+
+	sfixed32(x: number): void {
+		this.grow(4);
+		this.view.setInt32(this.pos, x | 0, true);
+		this.pos += 4;
+	}
+
+	double(x: number): void {
+		this.grow(8);
+		this.view.setFloat64(this.pos, x, true);
+		this.pos += 8;
+	}
+
+	float(x: number): void {
+		this.grow(4);
+		this.view.setFloat32(this.pos, x, true);
+		this.pos += 4;
+	}
+
+	bytes(x: Uint8Array): void {
+		this.uvarint(x.length);
+		this.grow(x.length);
+		this.buf.set(x, this.pos);
+		this.pos += x.length;
+	}
+
+	string(x: string): void {
+		if (unpairedSurrogate.test(x)) {
+			throw new Error("tomino: string with unpaired surrogates can't be encoded as UTF-8");
+		}
+		this.bytes(textEncoder.encode(x));
+	}
+
+	byteArray(x: Uint8Array, n: number): void {
+		checkLength(x, n);
+		this.bytes(x);
+	}
+
+	// begin writes the tag of a length-prefixed value, and returns its
+	// position, to be passed to end once the value has been written.
+	begin(...tag: number[]): number {
+		const start = this.pos;
+		this.raw(...tag);
+		return start;
+	}
+
+	// end inserts the length of the value started with begin.
+	// If the value is empty and omitEmpty is set, the tag is removed instead.
+	end(start: number, tagLen: number, omitEmpty: boolean): void {
+		const valueStart = start + tagLen;
+		const size = this.pos - valueStart;
+		if (size === 0 && omitEmpty) {
+			this.pos = start;
+			return;
+		}
+		const n = uvarintSize(size);
+		this.grow(n);
+		this.buf.copyWithin(valueStart + n, valueStart, this.pos);
+		putUvarint(this.buf, valueStart, size);
+		this.pos += n;
+	}
+}
+
+class TominoReader {
+	buf: Uint8Array;
+	view: DataView;
+	pos = 0;
+
+	constructor(buf: Uint8Array) {
+		this.buf = buf;
+		this.view = new DataView(buf.buffer, buf.byteOffset, buf.byteLength);
+	}
+
+	need(n: number): void {
+		if (this.pos + n > this.buf.length) {
+			throw new Error("tomino: unexpected end of input");
+		}
+	}
+
+	uvarint64(): bigint {
+		let x = 0n;
+		for (let shift = 0n; shift < 70n; shift += 7n) {
+			this.need(1);
+			const b = this.buf[this.pos++];
+			x |= BigInt(b & 0x7f) << shift;
+			if (b < 0x80) {
+				if (x >> 64n !== 0n) {
+					break;
+				}
+				return x;
+			}
+		}
+		throw new Error("tomino: varint overflows a 64-bit integer");
+	}
+
+	uvarint(): number {
+		const x = this.uvarint64();
+		if (x > BigInt(Number.MAX_SAFE_INTEGER)) {
+			throw new Error("tomino: length or tag too large");
+		}
+		return Number(x);
+	}
+
+	tag(): [number, number] {
+		const x = this.uvarint();
+		return [Math.floor(x / 8), x % 8];
+	}
+
+	expect(wt: number, want: number): void {
+		if (wt !== want) {
+			throw new Error(`tomino: invalid wire type ${wt}, expected ${want}`);
+		}
+	}
+
+	skip(wt: number): void {
+		if (wt === 0) {
+			this.uvarint64();
+		} else if (wt === 1) {
+			this.need(8);
+			this.pos += 8;
+		} else if (wt === 2) {
+			this.pos = this.end();
+		} else if (wt === 5) {
+			this.need(4);
+			this.pos += 4;
+		} else {
+			throw new Error(`tomino: invalid wire type ${wt}`);
+		}
+	}
+
+	// end reads the length prefix of a value, and returns the position
+	// where the value ends.
+	end(): number {
+		const n = this.uvarint();
+		this.need(n);
+		return this.pos + n;
+	}
+
+	done(end: number): void {
+		if (this.pos !== end) {
+			throw new Error("tomino: value overflows its length prefix");
+		}
+	}
+
+	index(i: number, n: number): number {
+		if (i >= n) {
+			throw new Error(`tomino: too many elements for array of length ${n}`);
+		}
+		return i;
+	}
+
+	// bool reads a single byte, which like in amino must be 0 or 1.
+	bool(): boolean {
+		this.need(1);
+		const x = this.buf[this.pos++];
+		if (x > 1) {
+			throw new Error("tomino: invalid bool");
+		}
+		return x === 1;
+	}
+
+	uint32(bits = 32): number {
+		const x = this.uvarint64();
+		if (x >> BigInt(bits) !== 0n) {
+			throw new Error(`tomino: varint overflows a ${bits}-bit integer`);
+		}
+		return Number(x);
+	}
+
+	int32(bits = 32): number {
+		const x = this.int64();
+		const max = (1n << BigInt(bits - 1)) - 1n;
+		if (x < -max - 1n || x > max) {
+			throw new Error(`tomino: varint overflows a ${bits}-bit integer`);
+		}
+		return Number(x);
+	}
+
+	uint64(): bigint {
+		return this.uvarint64();
+	}
+
+	int64(): bigint {
+		const x = this.uvarint64();
+		return BigInt.asIntN(64, (x >> 1n) ^ -(x & 1n));
+	}
+
+	fixed64(): bigint {
+		this.need(8);
+		const x = this.view.getBigUint64(this.pos, true);
+		this.pos += 8;
+		return x;
+	}
+
+	sfixed64(): bigint {
+		this.need(8);
+		const x = this.view.getBigInt64(this.pos, true);
+		this.pos += 8;
+		return x;
+	}
+
+	fixed32(): number {
+		this.need(4);
+		const x = this.view.getUint32(this.pos, true);
+		this.pos += 4;
+		return x;
+	}
+
+	sfixed32(): number {
+		this.need(4);
+		const x = this.view.getInt32(this.pos, true);
+		this.pos += 4;
+		return x;
+	}
+
+	double(): number {
+		this.need(8);
+		const x = this.view.getFloat64(this.pos, true);
+		this.pos += 8;
+		return x;
+	}
+
+	float(): number {
+		this.need(4);
+		const x = this.view.getFloat32(this.pos, true);
+		this.pos += 4;
+		return x;
+	}
+
+	bytes(): Uint8Array {
+		const end = this.end();
+		const b = new Uint8Array(this.buf.subarray(this.pos, end));
+		this.pos = end;
+		return b;
+	}
+
+	string(): string {
+		const end = this.end();
+		let s: string;
+		try {
+			s = textDecoder.decode(this.buf.subarray(this.pos, end));
+		} catch {
+			throw new Error("tomino: invalid UTF-8 string");
+		}
+		this.pos = end;
+		return s;
+	}
+
+	byteArray(n: number): Uint8Array {
+		const b = this.bytes();
+		checkLength(b, n);
+		return b;
+	}
+}
@@ -0,0 +1,1338 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+
+const std = @import("std");
+
+/// DecodeError is the error returned by the decoders.
+pub const DecodeError = error{
+    EndOfStream,
+    Overflow,
+    InvalidWireType,
+    InvalidLength,
+    InvalidBool,
+} || std.mem.Allocator.Error;
+
+/// Userinfo is the tomino message for the type
+/// net/url.Userinfo
+pub const Userinfo = struct {
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: Userinfo, writer: anytype) !void {
+        _ = self;
+        _ = writer;
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: Userinfo) usize {
+        _ = self;
+        return 0;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: Userinfo, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a Userinfo from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!Userinfo {
+        _ = allocator;
+        var r = tomino.Reader{ .buf = bytes };
+        while (r.pos < r.buf.len) {
+            try r.skip((try r.tag()).wire);
+        }
+        return .{};
+    }
+};
+
+/// URL is the tomino message for the type
+/// net/url.URL
+pub const URL = struct {
+    Scheme: []const u8 = "",
+    Opaque: []const u8 = "",
+    User: ?Userinfo = null,
+    Host: []const u8 = "",
+    Path: []const u8 = "",
+    RawPath: []const u8 = "",
+    OmitHost: bool = false,
+    ForceQuery: bool = false,
+    RawQuery: []const u8 = "",
+    Fragment: []const u8 = "",
+    RawFragment: []const u8 = "",
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: URL, writer: anytype) !void {
+        // field number 1
+        if (self.Scheme.len != 0) {
+            try writer.writeAll(&[_]u8{0x0a});
+            try tomino.writeBytes(writer, self.Scheme);
+        }
+        // field number 2
+        if (self.Opaque.len != 0) {
+            try writer.writeAll(&[_]u8{0x12});
+            try tomino.writeBytes(writer, self.Opaque);
+        }
+        // field number 3
+        // (always empty, skip as there is no write_empty)
+        // field number 4
+        if (self.Host.len != 0) {
+            try writer.writeAll(&[_]u8{0x22});
+            try tomino.writeBytes(writer, self.Host);
+        }
+        // field number 5
+        if (self.Path.len != 0) {
+            try writer.writeAll(&[_]u8{0x2a});
+            try tomino.writeBytes(writer, self.Path);
+        }
+        // field number 6
+        if (self.RawPath.len != 0) {
+            try writer.writeAll(&[_]u8{0x32});
+            try tomino.writeBytes(writer, self.RawPath);
+        }
+        // field number 7
+        if (self.OmitHost) {
+            try writer.writeAll(&[_]u8{0x38});
+            try writer.writeByte(@intFromBool(self.OmitHost));
+        }
+        // field number 8
+        if (self.ForceQuery) {
+            try writer.writeAll(&[_]u8{0x40});
+            try writer.writeByte(@intFromBool(self.ForceQuery));
+        }
+        // field number 9
+        if (self.RawQuery.len != 0) {
+            try writer.writeAll(&[_]u8{0x4a});
+            try tomino.writeBytes(writer, self.RawQuery);
+        }
+        // field number 10
+        if (self.Fragment.len != 0) {
+            try writer.writeAll(&[_]u8{0x52});
+            try tomino.writeBytes(writer, self.Fragment);
+        }
+        // field number 11
+        if (self.RawFragment.len != 0) {
+            try writer.writeAll(&[_]u8{0x5a});
+            try tomino.writeBytes(writer, self.RawFragment);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: URL) usize {
+        var n: usize = 0;
+        if (self.Scheme.len != 0) {
+            n += 1 + tomino.lenSize(self.Scheme.len);
+        }
+        if (self.Opaque.len != 0) {
+            n += 1 + tomino.lenSize(self.Opaque.len);
+        }
+        if (self.Host.len != 0) {
+            n += 1 + tomino.lenSize(self.Host.len);
+        }
+        if (self.Path.len != 0) {
+            n += 1 + tomino.lenSize(self.Path.len);
+        }
+        if (self.RawPath.len != 0) {
+            n += 1 + tomino.lenSize(self.RawPath.len);
+        }
+        if (self.OmitHost) {
+            n += 1 + 1;
+        }
+        if (self.ForceQuery) {
+            n += 1 + 1;
+        }
+        if (self.RawQuery.len != 0) {
+            n += 1 + tomino.lenSize(self.RawQuery.len);
+        }
+        if (self.Fragment.len != 0) {
+            n += 1 + tomino.lenSize(self.Fragment.len);
+        }
+        if (self.RawFragment.len != 0) {
+            n += 1 + tomino.lenSize(self.RawFragment.len);
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: URL, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a URL from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!URL {
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: URL = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Scheme = try allocator.dupe(u8, try r.bytes());
+                },
+                2 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Opaque = try allocator.dupe(u8, try r.bytes());
+                },
+                3 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.User = try Userinfo.decode(allocator, try r.bytes());
+                },
+                4 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Host = try allocator.dupe(u8, try r.bytes());
+                },
+                5 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Path = try allocator.dupe(u8, try r.bytes());
+                },
+                6 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.RawPath = try allocator.dupe(u8, try r.bytes());
+                },
+                7 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.OmitHost = try r.boolean();
+                },
+                8 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.ForceQuery = try r.boolean();
+                },
+                9 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.RawQuery = try allocator.dupe(u8, try r.bytes());
+                },
+                10 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Fragment = try allocator.dupe(u8, try r.bytes());
+                },
+                11 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.RawFragment = try allocator.dupe(u8, try r.bytes());
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+/// Time is the tomino message for the type
+/// time.Time
+pub const Time = struct {
+    seconds: u64 = 0,
+    nanoseconds: u32 = 0,
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: Time, writer: anytype) !void {
+        // field number 1
+        if (self.seconds != 0) {
+            try writer.writeAll(&[_]u8{0x08});
+            try tomino.writeUvarint(writer, self.seconds);
+        }
+        // field number 2
+        if (self.nanoseconds != 0) {
+            try writer.writeAll(&[_]u8{0x10});
+            try tomino.writeUvarint(writer, self.nanoseconds);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: Time) usize {
+        var n: usize = 0;
+        if (self.seconds != 0) {
+            n += 1 + tomino.uvarintSize(self.seconds);
+        }
+        if (self.nanoseconds != 0) {
+            n += 1 + tomino.uvarintSize(self.nanoseconds);
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: Time, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a Time from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!Time {
+        _ = allocator;
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: Time = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.seconds = try tomino.cast(u64, try r.uvarint());
+                },
+                2 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.nanoseconds = try tomino.cast(u32, try r.uvarint());
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+/// Duration is the tomino message for the type
+/// time.Duration
+pub const Duration = struct {
+    seconds: u64 = 0,
+    nanoseconds: u32 = 0,
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: Duration, writer: anytype) !void {
+        // field number 1
+        if (self.seconds != 0) {
+            try writer.writeAll(&[_]u8{0x08});
+            try tomino.writeUvarint(writer, self.seconds);
+        }
+        // field number 2
+        if (self.nanoseconds != 0) {
+            try writer.writeAll(&[_]u8{0x10});
+            try tomino.writeUvarint(writer, self.nanoseconds);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: Duration) usize {
+        var n: usize = 0;
+        if (self.seconds != 0) {
+            n += 1 + tomino.uvarintSize(self.seconds);
+        }
+        if (self.nanoseconds != 0) {
+            n += 1 + tomino.uvarintSize(self.nanoseconds);
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: Duration, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a Duration from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!Duration {
+        _ = allocator;
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: Duration = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.seconds = try tomino.cast(u64, try r.uvarint());
+                },
+                2 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.nanoseconds = try tomino.cast(u32, try r.uvarint());
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+pub const TestTypeSlice = struct {
+    A: i64 = 0,
+    B: i64 = 0,
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: TestTypeSlice, writer: anytype) !void {
+        // field number 1
+        if (self.A != 0) {
+            try writer.writeAll(&[_]u8{0x08});
+            try tomino.writeUvarint(writer, tomino.zigzag(self.A));
+        }
+        // field number 2
+        if (self.B != 0) {
+            try writer.writeAll(&[_]u8{0x10});
+            try tomino.writeUvarint(writer, tomino.zigzag(self.B));
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: TestTypeSlice) usize {
+        var n: usize = 0;
+        if (self.A != 0) {
+            n += 1 + tomino.uvarintSize(tomino.zigzag(self.A));
+        }
+        if (self.B != 0) {
+            n += 1 + tomino.uvarintSize(tomino.zigzag(self.B));
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: TestTypeSlice, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a TestTypeSlice from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!TestTypeSlice {
+        _ = allocator;
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: TestTypeSlice = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.A = try tomino.cast(i64, tomino.unzigzag(try r.uvarint()));
+                },
+                2 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.B = try tomino.cast(i64, tomino.unzigzag(try r.uvarint()));
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+/// TestType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.TestType
+pub const TestType = struct {
+    Time: Time = .{},
+    Duration: Duration = .{},
+    FixedUint: u64 = 0,
+    Byte: u8 = 0,
+    Bytes: []const u8 = "",
+    ByteArr: ?[4]u8 = null,
+    ZeroArr: [0]u8 = .{},
+    IntPtr: ?i64 = null,
+    Slice: []const TestTypeSlice = &.{},
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: TestType, writer: anytype) !void {
+        // field number 1
+        {
+            const size = self.Time.encodedSize();
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x0a});
+                try tomino.writeUvarint(writer, size);
+                try self.Time.encode(writer);
+            }
+        }
+        // field number 2
+        {
+            const size = self.Duration.encodedSize();
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x12});
+                try tomino.writeUvarint(writer, size);
+                try self.Duration.encode(writer);
+            }
+        }
+        // field number 3
+        if (self.FixedUint != 0) {
+            try writer.writeAll(&[_]u8{0x19});
+            try writer.writeInt(u64, @bitCast(self.FixedUint), .little);
+        }
+        // field number 4
+        if (self.Byte != 0) {
+            try writer.writeAll(&[_]u8{0x20});
+            try tomino.writeUvarint(writer, self.Byte);
+        }
+        // field number 5
+        if (self.Bytes.len != 0) {
+            try writer.writeAll(&[_]u8{0x2a});
+            try tomino.writeBytes(writer, self.Bytes);
+        }
+        // field number 6
+        if (self.ByteArr) |v| {
+            try writer.writeAll(&[_]u8{0x32});
+            try tomino.writeBytes(writer, &v);
+        }
+        // field number 7
+        // (always empty, skip as there is no write_empty)
+        // field number 8
+        if (self.IntPtr) |v| {
+            if (v != 0) {
+                try writer.writeAll(&[_]u8{0x40});
+                try tomino.writeUvarint(writer, tomino.zigzag(v));
+            }
+        }
+        // field number 9
+        for (self.Slice) |el| {
+            try writer.writeAll(&[_]u8{0x4a});
+            try tomino.writeUvarint(writer, el.encodedSize());
+            try el.encode(writer);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: TestType) usize {
+        var n: usize = 0;
+        {
+            const size = self.Time.encodedSize();
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            const size = self.Duration.encodedSize();
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        if (self.FixedUint != 0) {
+            n += 1 + 8;
+        }
+        if (self.Byte != 0) {
+            n += 1 + tomino.uvarintSize(self.Byte);
+        }
+        if (self.Bytes.len != 0) {
+            n += 1 + tomino.lenSize(self.Bytes.len);
+        }
+        if (self.ByteArr != null) {
+            n += 1 + tomino.lenSize(4);
+        }
+        if (self.IntPtr) |v| {
+            if (v != 0) {
+                n += 1 + tomino.uvarintSize(tomino.zigzag(v));
+            }
+        }
+        for (self.Slice) |el| {
+            n += 1 + tomino.lenSize(el.encodedSize());
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: TestType, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a TestType from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!TestType {
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: TestType = .{};
+        var list_9: std.ArrayListUnmanaged(TestTypeSlice) = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Time = try Time.decode(allocator, try r.bytes());
+                },
+                2 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Duration = try Duration.decode(allocator, try r.bytes());
+                },
+                3 => {
+                    try tomino.expect(t.wire, 1);
+                    msg.FixedUint = @as(u64, @bitCast(try r.fixed(u64)));
+                },
+                4 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.Byte = try tomino.cast(u8, try r.uvarint());
+                },
+                5 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.Bytes = try allocator.dupe(u8, try r.bytes());
+                },
+                6 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.ByteArr = try tomino.byteArray(4, try r.bytes());
+                },
+                7 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.ZeroArr = try tomino.byteArray(0, try r.bytes());
+                },
+                8 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.IntPtr = try tomino.cast(i64, tomino.unzigzag(try r.uvarint()));
+                },
+                9 => {
+                    try tomino.expect(t.wire, 2);
+                    try list_9.append(allocator, try TestTypeSlice.decode(allocator, try r.bytes()));
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        msg.Slice = try list_9.toOwnedSlice(allocator);
+        return msg;
+    }
+};
+
+/// FloatType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.FloatType
+pub const FloatType = struct {
+    Float: f64 = 0,
+    Float32: f32 = 0,
+    FloatPtr: ?f64 = null,
+    Floats: []const f32 = &.{},
+    FloatArr: [2]f64 = [_]f64{0} ** 2,
+    FloatPtrs: []const ?f64 = &.{},
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: FloatType, writer: anytype) !void {
+        // field number 1
+        try writer.writeAll(&[_]u8{0x09});
+        try writer.writeInt(u64, @bitCast(self.Float), .little);
+        // field number 2
+        try writer.writeAll(&[_]u8{0x15});
+        try writer.writeInt(u32, @bitCast(self.Float32), .little);
+        // field number 3
+        if (self.FloatPtr) |v| {
+            try writer.writeAll(&[_]u8{0x19});
+            try writer.writeInt(u64, @bitCast(v), .little);
+        }
+        // field number 4
+        {
+            const size: usize = self.Floats.len * 4;
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x22});
+                try tomino.writeUvarint(writer, size);
+                for (self.Floats) |el| {
+                    try writer.writeInt(u32, @bitCast(el), .little);
+                }
+            }
+        }
+        // field number 5
+        {
+            const size: usize = self.FloatArr.len * 8;
+            try writer.writeAll(&[_]u8{0x2a});
+            try tomino.writeUvarint(writer, size);
+            for (self.FloatArr) |el| {
+                try writer.writeInt(u64, @bitCast(el), .little);
+            }
+        }
+        // field number 6
+        {
+            const size: usize = self.FloatPtrs.len * 8;
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x32});
+                try tomino.writeUvarint(writer, size);
+                for (self.FloatPtrs) |el| {
+                    try writer.writeInt(u64, @bitCast(el orelse 0), .little);
+                }
+            }
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: FloatType) usize {
+        var n: usize = 0;
+        n += 1 + 8;
+        n += 1 + 4;
+        if (self.FloatPtr != null) {
+            n += 1 + 8;
+        }
+        {
+            const size: usize = self.Floats.len * 4;
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            const size: usize = self.FloatArr.len * 8;
+            n += 1 + tomino.lenSize(size);
+        }
+        {
+            const size: usize = self.FloatPtrs.len * 8;
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: FloatType, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a FloatType from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!FloatType {
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: FloatType = .{};
+        var list_4: std.ArrayListUnmanaged(f32) = .{};
+        var i_5: usize = 0;
+        var list_6: std.ArrayListUnmanaged(?f64) = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 1);
+                    msg.Float = @as(f64, @bitCast(try r.fixed(u64)));
+                },
+                2 => {
+                    try tomino.expect(t.wire, 5);
+                    msg.Float32 = @as(f32, @bitCast(try r.fixed(u32)));
+                },
+                3 => {
+                    try tomino.expect(t.wire, 1);
+                    msg.FloatPtr = @as(f64, @bitCast(try r.fixed(u64)));
+                },
+                4 => {
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            try list_4.append(allocator, @as(f32, @bitCast(try sub.fixed(u32))));
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 5);
+                        try list_4.append(allocator, @as(f32, @bitCast(try r.fixed(u32))));
+                    }
+                },
+                5 => {
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            msg.FloatArr[try tomino.index(&i_5, 2)] = @as(f64, @bitCast(try sub.fixed(u64)));
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 1);
+                        msg.FloatArr[try tomino.index(&i_5, 2)] = @as(f64, @bitCast(try r.fixed(u64)));
+                    }
+                },
+                6 => {
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            try list_6.append(allocator, @as(f64, @bitCast(try sub.fixed(u64))));
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 1);
+                        try list_6.append(allocator, @as(f64, @bitCast(try r.fixed(u64))));
+                    }
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        msg.Floats = try list_4.toOwnedSlice(allocator);
+        msg.FloatPtrs = try list_6.toOwnedSlice(allocator);
+        return msg;
+    }
+};
+
+pub const JSONTypeStruct = struct {
+    A: u32 = 0,
+    B: []const u8 = "",
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: JSONTypeStruct, writer: anytype) !void {
+        // field number 1
+        if (self.A != 0) {
+            try writer.writeAll(&[_]u8{0x08});
+            try tomino.writeUvarint(writer, self.A);
+        }
+        // field number 2
+        if (self.B.len != 0) {
+            try writer.writeAll(&[_]u8{0x12});
+            try tomino.writeBytes(writer, self.B);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: JSONTypeStruct) usize {
+        var n: usize = 0;
+        if (self.A != 0) {
+            n += 1 + tomino.uvarintSize(self.A);
+        }
+        if (self.B.len != 0) {
+            n += 1 + tomino.lenSize(self.B.len);
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: JSONTypeStruct, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a JSONTypeStruct from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!JSONTypeStruct {
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: JSONTypeStruct = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.A = try tomino.cast(u32, try r.uvarint());
+                },
+                2 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.B = try allocator.dupe(u8, try r.bytes());
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+pub const JSONTypeStructs = struct {
+    C: bool = false,
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: JSONTypeStructs, writer: anytype) !void {
+        // field number 1
+        if (self.C) {
+            try writer.writeAll(&[_]u8{0x08});
+            try writer.writeByte(@intFromBool(self.C));
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: JSONTypeStructs) usize {
+        var n: usize = 0;
+        if (self.C) {
+            n += 1 + 1;
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: JSONTypeStructs, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a JSONTypeStructs from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!JSONTypeStructs {
+        _ = allocator;
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: JSONTypeStructs = .{};
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.C = try r.boolean();
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        return msg;
+    }
+};
+
+/// JSONType is the tomino message for the type
+/// github.com/thehowl/tomino/tests/golden.JSONType
+pub const JSONType = struct {
+    int8: i8 = 0,
+    name: []const u8 = "",
+    int32: i32 = 0,
+    int64: i64 = 0,
+    Uint: u64 = 0,
+    @"bool": bool = false,
+    bytes: []const u8 = "",
+    strings: []const []const u8 = &.{},
+    string_ptr: ?[]const u8 = null,
+    slice_ptr: ?[]const i16 = null,
+    ptrs: []const ?i32 = &.{},
+    arr: [2]u16 = [_]u16{0} ** 2,
+    time: Time = .{},
+    duration: Duration = .{},
+    @"struct": JSONTypeStruct = .{},
+    structs: [1]JSONTypeStructs = [_]JSONTypeStructs{.{}} ** 1,
+
+    /// Writes the amino binary encoding of self to writer.
+    pub fn encode(self: JSONType, writer: anytype) !void {
+        // field number 1
+        if (self.int8 != 0) {
+            try writer.writeAll(&[_]u8{0x08});
+            try tomino.writeUvarint(writer, tomino.zigzag(self.int8));
+        }
+        // field number 2
+        if (self.name.len != 0) {
+            try writer.writeAll(&[_]u8{0x12});
+            try tomino.writeBytes(writer, self.name);
+        }
+        // field number 3
+        if (self.int32 != 0) {
+            try writer.writeAll(&[_]u8{0x18});
+            try tomino.writeUvarint(writer, tomino.zigzag(self.int32));
+        }
+        // field number 4
+        if (self.int64 != 0) {
+            try writer.writeAll(&[_]u8{0x20});
+            try tomino.writeUvarint(writer, tomino.zigzag(self.int64));
+        }
+        // field number 5
+        if (self.Uint != 0) {
+            try writer.writeAll(&[_]u8{0x28});
+            try tomino.writeUvarint(writer, self.Uint);
+        }
+        // field number 6
+        if (self.@"bool") {
+            try writer.writeAll(&[_]u8{0x30});
+            try writer.writeByte(@intFromBool(self.@"bool"));
+        }
+        // field number 7
+        if (self.bytes.len != 0) {
+            try writer.writeAll(&[_]u8{0x3a});
+            try tomino.writeBytes(writer, self.bytes);
+        }
+        // field number 8
+        for (self.strings) |el| {
+            try writer.writeAll(&[_]u8{0x42});
+            try tomino.writeBytes(writer, el);
+        }
+        // field number 9
+        if (self.string_ptr) |v| {
+            if (v.len != 0) {
+                try writer.writeAll(&[_]u8{0x4a});
+                try tomino.writeBytes(writer, v);
+            }
+        }
+        // field number 10
+        if (self.slice_ptr) |v| {
+            var size: usize = 0;
+            for (v) |el| {
+                size += tomino.uvarintSize(tomino.zigzag(el));
+            }
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x52});
+                try tomino.writeUvarint(writer, size);
+                for (v) |el| {
+                    try tomino.writeUvarint(writer, tomino.zigzag(el));
+                }
+            }
+        }
+        // field number 11
+        {
+            var size: usize = 0;
+            for (self.ptrs) |el| {
+                size += tomino.uvarintSize(tomino.zigzag(el orelse 0));
+            }
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x5a});
+                try tomino.writeUvarint(writer, size);
+                for (self.ptrs) |el| {
+                    try tomino.writeUvarint(writer, tomino.zigzag(el orelse 0));
+                }
+            }
+        }
+        // field number 12
+        {
+            var size: usize = 0;
+            for (self.arr) |el| {
+                size += tomino.uvarintSize(el);
+            }
+            try writer.writeAll(&[_]u8{0x62});
+            try tomino.writeUvarint(writer, size);
+            for (self.arr) |el| {
+                try tomino.writeUvarint(writer, el);
+            }
+        }
+        // field number 13
+        {
+            const size = self.time.encodedSize();
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x6a});
+                try tomino.writeUvarint(writer, size);
+                try self.time.encode(writer);
+            }
+        }
+        // field number 14
+        {
+            const size = self.duration.encodedSize();
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x72});
+                try tomino.writeUvarint(writer, size);
+                try self.duration.encode(writer);
+            }
+        }
+        // field number 15
+        {
+            const size = self.@"struct".encodedSize();
+            if (size != 0) {
+                try writer.writeAll(&[_]u8{0x7a});
+                try tomino.writeUvarint(writer, size);
+                try self.@"struct".encode(writer);
+            }
+        }
+        // field number 16
+        for (self.structs) |el| {
+            try writer.writeAll(&[_]u8{ 0x82, 0x01 });
+            try tomino.writeUvarint(writer, el.encodedSize());
+            try el.encode(writer);
+        }
+    }
+
+    /// Returns the size of the amino binary encoding of self.
+    pub fn encodedSize(self: JSONType) usize {
+        var n: usize = 0;
+        if (self.int8 != 0) {
+            n += 1 + tomino.uvarintSize(tomino.zigzag(self.int8));
+        }
+        if (self.name.len != 0) {
+            n += 1 + tomino.lenSize(self.name.len);
+        }
+        if (self.int32 != 0) {
+            n += 1 + tomino.uvarintSize(tomino.zigzag(self.int32));
+        }
+        if (self.int64 != 0) {
+            n += 1 + tomino.uvarintSize(tomino.zigzag(self.int64));
+        }
+        if (self.Uint != 0) {
+            n += 1 + tomino.uvarintSize(self.Uint);
+        }
+        if (self.@"bool") {
+            n += 1 + 1;
+        }
+        if (self.bytes.len != 0) {
+            n += 1 + tomino.lenSize(self.bytes.len);
+        }
+        for (self.strings) |el| {
+            n += 1 + tomino.lenSize(el.len);
+        }
+        if (self.string_ptr) |v| {
+            if (v.len != 0) {
+                n += 1 + tomino.lenSize(v.len);
+            }
+        }
+        if (self.slice_ptr) |v| {
+            var size: usize = 0;
+            for (v) |el| {
+                size += tomino.uvarintSize(tomino.zigzag(el));
+            }
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            var size: usize = 0;
+            for (self.ptrs) |el| {
+                size += tomino.uvarintSize(tomino.zigzag(el orelse 0));
+            }
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            var size: usize = 0;
+            for (self.arr) |el| {
+                size += tomino.uvarintSize(el);
+            }
+            n += 1 + tomino.lenSize(size);
+        }
+        {
+            const size = self.time.encodedSize();
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            const size = self.duration.encodedSize();
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        {
+            const size = self.@"struct".encodedSize();
+            if (size != 0) {
+                n += 1 + tomino.lenSize(size);
+            }
+        }
+        for (self.structs) |el| {
+            n += 2 + tomino.lenSize(el.encodedSize());
+        }
+        return n;
+    }
+
+    /// Returns the amino binary encoding of self, allocated using allocator.
+    pub fn encodeAlloc(self: JSONType, allocator: std.mem.Allocator) std.mem.Allocator.Error![]u8 {
+        const buf = try allocator.alloc(u8, self.encodedSize());
+        var stream = std.io.fixedBufferStream(buf);
+        self.encode(stream.writer()) catch unreachable;
+        return buf;
+    }
+
+    /// Decodes a JSONType from its amino binary encoding.
+    /// Slices in the result are allocated using allocator, and are not freed
+    /// if decoding fails: use an arena allocator to free them all at once.
+    pub fn decode(allocator: std.mem.Allocator, bytes: []const u8) DecodeError!JSONType {
+        var r = tomino.Reader{ .buf = bytes };
+        var msg: JSONType = .{};
+        var list_8: std.ArrayListUnmanaged([]const u8) = .{};
+        var list_10: std.ArrayListUnmanaged(i16) = .{};
+        var list_11: std.ArrayListUnmanaged(?i32) = .{};
+        var i_12: usize = 0;
+        var i_16: usize = 0;
+        while (r.pos < r.buf.len) {
+            const t = try r.tag();
+            switch (t.num) {
+                1 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.int8 = try tomino.cast(i8, tomino.unzigzag(try r.uvarint()));
+                },
+                2 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.name = try allocator.dupe(u8, try r.bytes());
+                },
+                3 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.int32 = try tomino.cast(i32, tomino.unzigzag(try r.uvarint()));
+                },
+                4 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.int64 = try tomino.cast(i64, tomino.unzigzag(try r.uvarint()));
+                },
+                5 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.Uint = try tomino.cast(u64, try r.uvarint());
+                },
+                6 => {
+                    try tomino.expect(t.wire, 0);
+                    msg.@"bool" = try r.boolean();
+                },
+                7 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.bytes = try allocator.dupe(u8, try r.bytes());
+                },
+                8 => {
+                    try tomino.expect(t.wire, 2);
+                    try list_8.append(allocator, try allocator.dupe(u8, try r.bytes()));
+                },
+                9 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.string_ptr = try allocator.dupe(u8, try r.bytes());
+                },
+                10 => {
+                    msg.slice_ptr = @as([]const i16, &.{});
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            try list_10.append(allocator, try tomino.cast(i16, tomino.unzigzag(try sub.uvarint())));
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 0);
+                        try list_10.append(allocator, try tomino.cast(i16, tomino.unzigzag(try r.uvarint())));
+                    }
+                },
+                11 => {
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            try list_11.append(allocator, try tomino.cast(i32, tomino.unzigzag(try sub.uvarint())));
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 0);
+                        try list_11.append(allocator, try tomino.cast(i32, tomino.unzigzag(try r.uvarint())));
+                    }
+                },
+                12 => {
+                    if (t.wire == 2) {
+                        var sub = tomino.Reader{ .buf = try r.bytes() };
+                        while (sub.pos < sub.buf.len) {
+                            msg.arr[try tomino.index(&i_12, 2)] = try tomino.cast(u16, try sub.uvarint());
+                        }
+                    } else {
+                        try tomino.expect(t.wire, 0);
+                        msg.arr[try tomino.index(&i_12, 2)] = try tomino.cast(u16, try r.uvarint());
+                    }
+                },
+                13 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.time = try Time.decode(allocator, try r.bytes());
+                },
+                14 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.duration = try Duration.decode(allocator, try r.bytes());
+                },
+                15 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.@"struct" = try JSONTypeStruct.decode(allocator, try r.bytes());
+                },
+                16 => {
+                    try tomino.expect(t.wire, 2);
+                    msg.structs[try tomino.index(&i_16, 1)] = try JSONTypeStructs.decode(allocator, try r.bytes());
+                },
+                else => try r.skip(t.wire),
+            }
+        }
+        msg.strings = try list_8.toOwnedSlice(allocator);
+        if (msg.slice_ptr != null) msg.slice_ptr = try list_10.toOwnedSlice(allocator);
+        msg.ptrs = try list_11.toOwnedSlice(allocator);
+        return msg;
+    }
+};
+
+// ---
+// encoding runtime
+
+const tomino = struct {
+    fn uvarintSize(x: u64) usize {
+        return @max(1, (64 - @clz(x) + 6) / 7);
+    }
+
+    fn lenSize(n: usize) usize {
+        return uvarintSize(n) + n;
+    }
+
+    fn zigzag(x: i64) u64 {
+        const u: u64 = @bitCast(x);
+        return (u << 1) ^ @as(u64, @bitCast(x >> 63));
+    }
+
+    fn unzigzag(u: u64) i64 {
+        return @as(i64, @bitCast(u >> 1)) ^ -@as(i64, @bitCast(u & 1));
+    }
+
+    fn writeUvarint(writer: anytype, x: u64) !void {
+        var v = x;
+        while (v >= 0x80) : (v >>= 7) {
+            try writer.writeByte(@as(u8, @truncate(v)) | 0x80);
+        }
+        try writer.writeByte(@truncate(v));
+    }
+
+    fn writeBytes(writer: anytype, b: []const u8) !void {
+        try writeUvarint(writer, b.len);
+        try writer.writeAll(b);
+    }
+
+    fn cast(comptime T: type, x: anytype) DecodeError!T {
+        return std.math.cast(T, x) orelse error.Overflow;
+    }
+
+    fn expect(got: u3, want: u3) DecodeError!void {
+        if (got != want) {
+            return error.InvalidWireType;
+        }
+    }
+
+    fn byteArray(comptime n: usize, b: []const u8) DecodeError![n]u8 {
+        if (b.len != n) {
+            return error.InvalidLength;
+        }
+        return b[0..n].*;
+    }
+
+    // index returns the index of the next element of an array of length n.
+    fn index(i: *usize, n: usize) DecodeError!usize {
+        if (i.* >= n) {
+            return error.InvalidLength;
+        }
+        i.* += 1;
+        return i.* - 1;
+    }
+
+    const Tag = struct {
+        num: u64,
+        wire: u3,
+    };
+
+    const Reader = struct {
+        buf: []const u8,
+        pos: usize = 0,
+
+        fn uvarint(r: *Reader) DecodeError!u64 {
+            var x: u64 = 0;
+            for (0..10) |i| {
+                if (r.pos >= r.buf.len) {
+                    return error.EndOfStream;
+                }
+                const b = r.buf[r.pos];
+                r.pos += 1;
+                if (i == 9 and b > 1) {
+                    return error.Overflow;
+                }
+                x |= @as(u64, b & 0x7f) << @intCast(i * 7);
+                if (b < 0x80) {
+                    return x;
+                }
+            }
+            return error.Overflow;
+        }
+
+        // boolean reads a single byte, which like in amino must be 0 or 1.
+        fn boolean(r: *Reader) DecodeError!bool {
+            if (r.pos >= r.buf.len) {
+                return error.EndOfStream;
+            }
+            const b = r.buf[r.pos];
+            r.pos += 1;
+            return switch (b) {
+                0 => false,
+                1 => true,
+                else => error.InvalidBool,
+            };
+        }
+
+        fn tag(r: *Reader) DecodeError!Tag {
+            const x = try r.uvarint();
+            return .{ .num = x >> 3, .wire = @truncate(x) };
+        }
+
+        fn fixed(r: *Reader, comptime T: type) DecodeError!T {
+            const n = @sizeOf(T);
+            if (r.buf.len - r.pos < n) {
+                return error.EndOfStream;
+            }
+            const x = std.mem.readInt(T, r.buf[r.pos..][0..n], .little);
+            r.pos += n;
+            return x;
+        }
+
+        fn bytes(r: *Reader) DecodeError![]const u8 {
+            const n = try r.uvarint();
+            if (n > r.buf.len - r.pos) {
+                return error.EndOfStream;
+            }
+            const len: usize = @intCast(n);
+            const b = r.buf[r.pos..][0..len];
+            r.pos += len;
+            return b;
+        }
+
+        fn skip(r: *Reader, wire: u3) DecodeError!void {
+            switch (wire) {
+                0 => _ = try r.uvarint(),
+                1 => _ = try r.fixed(u64),
+                2 => _ = try r.bytes(),
+                5 => _ = try r.fixed(u32),
+                else => return error.InvalidWireType,
+            }
+        }
+    };
+};
@@ -15,3 +15,93 @@ type TestType struct {
 
 	testName string
 }
+
+// OldTestType has the first fields of TestType, like an older version of it,
+// to test keeping the unknown fields of the messages written by TestType.
+type OldTestType struct {
+	Time      time.Time
+	Duration  time.Duration
+	FixedUint uint64 `binary:"fixed64"`
+	Byte      byte
+}
+
+// OldSliceTestType is like TestType, but the structs in Slice are an older
+// version with only the field A, to test keeping the unknown fields of the
+// structs contained in a message.
+type OldSliceTestType struct {
+	Time      time.Time
+	Duration  time.Duration
+	FixedUint uint64 `binary:"fixed64"`
+	Byte      byte
+	Bytes     []byte
+	ByteArr   *[4]byte
+	ZeroArr   [0]byte
+	IntPtr    *int
+	Slice     []struct{ A int }
+}
+
+// FloatType contains floating points, which amino only supports with the
+// `amino:"unsafe"` tag. They are separate from TestType, as amino always
+// writes them, even when they are zero.
+type FloatType struct {
+	Float     float64    `amino:"unsafe"`
+	Float32   float32    `amino:"unsafe"`
+	FloatPtr  *float64   `amino:"unsafe"`
+	Floats    []float32  `amino:"unsafe"`
+	FloatArr  [2]float64 `amino:"unsafe"`
+	FloatPtrs []*float64 `amino:"unsafe"`
+}
+
+// JSONType contains the options which only matter for the JSON encoding:
+// field names, `json:"-"` and omitempty, for each kind of value.
+type JSONType struct {
+	Skipped   string        `json:"-"`
+	Int8      int8          `json:"int8,omitempty"`
+	Name      string        `json:"name"`
+	Int32     int32         `json:"int32"`
+	Int64     int64         `json:"int64,omitempty"`
+	Uint      uint          `json:",omitempty"`
+	Bool      bool          `json:"bool,omitempty"`
+	Bytes     []byte        `json:"bytes,omitempty"`
+	Strings   []string      `json:"strings,omitempty"`
+	StringPtr *string       `json:"string_ptr,omitempty"`
+	SlicePtr  *[]int16      `json:"slice_ptr,omitempty"`
+	Ptrs      []*int32      `json:"ptrs"`
+	Arr       [2]uint16     `json:"arr,omitempty"`
+	Time      time.Time     `json:"time,omitempty"`
+	Duration  time.Duration `json:"duration,omitempty"`
+	Struct    struct {
+		A uint32
+		B string
+	} `json:"struct,omitempty"`
+	Structs [1]struct{ C bool } `json:"structs,omitempty"`
+}
+
+// Animal is an interface, to test the encoding of interfaces as
+// google.protobuf.Any. It is implemented by Cat and Fish, and by pointers to
+// Dog, which are decoded as pointers like amino's pointer-preferred types.
+type Animal interface{ Sound() string }
+
+type Cat struct {
+	Name  string
+	Lives int
+}
+
+func (Cat) Sound() string { return "meow" }
+
+type Dog struct {
+	Name string
+}
+
+func (*Dog) Sound() string { return "woof" }
+
+// Fish has no fields, so the value of its Any is always omitted.
+type Fish struct{}
+
+func (Fish) Sound() string { return "" }
+
+// Zoo contains interfaces, in a field and in a slice, where they can be nil.
+type Zoo struct {
+	Star    Animal
+	Animals []Animal
+}
@@ -0,0 +1,3265 @@
+// Code generated by tomgen (tomino). DO NOT EDIT.
+
+package unknown
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// OldTestTypeMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.OldTestType
+type OldTestTypeMessage struct {
+	Time struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Time"`
+	Duration struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Duration"`
+	FixedUint   uint64 `json:"FixedUint" binary:"fixed64"`
+	Byte        uint8  `json:"Byte"`
+	XXX_unknown []byte `json:"-"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [OldTestTypeMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg OldTestTypeMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg OldTestTypeMessage) AppendBinary(b []byte) ([]byte, error) {
+	// the unknown fields are written in field number order, between the others.
+	unknown := msg.XXX_unknown
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 1)
+	}
+	// field number 1
+	{
+		startLen := len(b)
+		if msg.Time.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Seconds))]
+		}
+		if msg.Time.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 2)
+	}
+	// field number 2
+	{
+		startLen := len(b)
+		if msg.Duration.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Seconds))]
+		}
+		if msg.Duration.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 3)
+	}
+	{
+		u64 := uint64(msg.FixedUint)
+		if u64 != 0 {
+			// field number 3
+			b = append(b, (3<<3)|1 /* 0x19 */)
+			b = growBytes(b, 8)[:len(b)+8]
+			putUint64(b[len(b)-8:], u64)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 4)
+	}
+	if msg.Byte != 0 {
+		// field number 4
+		b = append(b, (4<<3)|0 /* 0x20 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Byte))]
+	}
+	b = append(b, unknown...)
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *OldTestTypeMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [OldTestTypeMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *OldTestTypeMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *OldTestTypeMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = OldTestTypeMessage{}
+	for len(b) > 0 {
+		rec := b
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Time
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Duration
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 3:
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.XXX_unknown = append(msg.XXX_unknown, rec[:len(rec)-len(b)]...)
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [OldTestTypeMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg OldTestTypeMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg OldTestTypeMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *OldTestTypeMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [OldTestTypeMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg OldTestTypeMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *OldTestTypeMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = OldTestTypeMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *OldTestTypeMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Time
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Duration
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+			msg.XXX_unknown = append(msg.XXX_unknown, sr.val...)
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.OldTestType, like amino.MarshalAny.
+func (msg OldTestTypeMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg OldTestTypeMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.OldTestType", msg)
+}
+
+// MarshalAnySized encodes the message like [OldTestTypeMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg OldTestTypeMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg OldTestTypeMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.OldTestType.
+// The message is reset before decoding.
+func (msg *OldTestTypeMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.OldTestType" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.OldTestType")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *OldTestTypeMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [OldTestTypeMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg OldTestTypeMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg OldTestTypeMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [OldTestTypeMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg OldTestTypeMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg OldTestTypeMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *OldTestTypeMessage) UnmarshalJSON(b []byte) error {
+	*msg = OldTestTypeMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Time":
+				msg.Time.Seconds = 0
+				msg.Time.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONTime(v)
+					if err != nil {
+						return err
+					}
+					msg.Time.Seconds, msg.Time.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "Duration":
+				msg.Duration.Seconds = 0
+				msg.Duration.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONDuration(v)
+					if err != nil {
+						return err
+					}
+					msg.Duration.Seconds, msg.Duration.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "FixedUint":
+				msg.FixedUint = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 64, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.FixedUint = x
+				}
+			case "Byte":
+				msg.Byte = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 8, false)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Byte = uint8(x)
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.OldTestType, in the "@type" member, like amino.MarshalJSONAny.
+func (msg OldTestTypeMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// OldSliceTestTypeMessage is the tomino message for the type
+// github.com/thehowl/tomino/tests/golden.OldSliceTestType
+type OldSliceTestTypeMessage struct {
+	Time struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Time"`
+	Duration struct {
+		Seconds     uint64 `json:"seconds"`
+		Nanoseconds uint32 `json:"nanoseconds"`
+	} `json:"Duration"`
+	FixedUint uint64   `json:"FixedUint" binary:"fixed64"`
+	Byte      uint8    `json:"Byte"`
+	Bytes     []byte   `json:"Bytes"`
+	ByteArr   *[4]byte `json:"ByteArr"`
+	ZeroArr   [0]byte  `json:"ZeroArr"`
+	IntPtr    *int     `json:"IntPtr"`
+	Slice     []struct {
+		A           int    `json:"A"`
+		XXX_unknown []byte `json:"-"`
+	} `json:"Slice"`
+	XXX_unknown []byte `json:"-"`
+}
+
+// MarshalBinary encodes the data in the message using the generated tomino
+// marshaler. It calls [OldSliceTestTypeMessage.AppendBinary] with a pre-allocated buffer
+// of 64 bytes, as opposed to Go's default of 8, which can improve performance
+// by avoiding extra allocations on low byte counts. For the best performance,
+// re-use buffers with AppendBinary.
+func (msg OldSliceTestTypeMessage) MarshalBinary() ([]byte, error) {
+	return msg.AppendBinary(make([]byte, 0, 64))
+}
+
+// AppendBinary encodes the data in the message using the generated tomino
+// marshaler, appending the encoded bytes to b and returning the result.
+func (msg OldSliceTestTypeMessage) AppendBinary(b []byte) ([]byte, error) {
+	// the unknown fields are written in field number order, between the others.
+	unknown := msg.XXX_unknown
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 1)
+	}
+	// field number 1
+	{
+		startLen := len(b)
+		if msg.Time.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Seconds))]
+		}
+		if msg.Time.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Time.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (1<<3)|2 /* 0x0a */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 2)
+	}
+	// field number 2
+	{
+		startLen := len(b)
+		if msg.Duration.Seconds != 0 {
+			// field number 1
+			b = append(b, (1<<3)|0 /* 0x08 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Seconds))]
+		}
+		if msg.Duration.Nanoseconds != 0 {
+			// field number 2
+			b = append(b, (2<<3)|0 /* 0x10 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Duration.Nanoseconds))]
+		}
+		encodedSize := uint64(len(b) - startLen)
+		switch {
+		case encodedSize == 0:
+			// empty -- nothing to do.
+		case encodedSize <= maxVarint1:
+			const shift = 1 + 1
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */, byte(encodedSize))
+		default:
+			shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+			b = growBytes(b, shift)[:len(b)+shift]
+			copy(b[startLen+shift:], b[startLen:len(b)-shift])
+			_ = append(b[:startLen], (2<<3)|2 /* 0x12 */)
+			putUvarint(b[startLen+1:startLen+shift], encodedSize)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 3)
+	}
+	{
+		u64 := uint64(msg.FixedUint)
+		if u64 != 0 {
+			// field number 3
+			b = append(b, (3<<3)|1 /* 0x19 */)
+			b = growBytes(b, 8)[:len(b)+8]
+			putUint64(b[len(b)-8:], u64)
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 4)
+	}
+	if msg.Byte != 0 {
+		// field number 4
+		b = append(b, (4<<3)|0 /* 0x20 */)
+		b = growBytes(b, 10)
+		b = b[:len(b)+putUvarint(b[len(b):len(b)+10], uint64(msg.Byte))]
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 5)
+	}
+	// field number 5
+	switch {
+	case len(msg.Bytes) == 0:
+		// nothing to write
+	case len(msg.Bytes) <= maxVarint1:
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Bytes)))
+		b = append(b, msg.Bytes...)
+	case len(msg.Bytes) <= maxVarint2:
+		b = append(b, (5<<3)|2 /* 0x2a */, byte(len(msg.Bytes)|0x80), byte(len(msg.Bytes)>>7))
+		b = append(b, msg.Bytes...)
+	default:
+		b = growBytes(b, 1+10+len(msg.Bytes))
+		b = append(b, (5<<3)|2 /* 0x2a */)
+		uvlen := putUvarint(b[len(b):len(b)+10], uint64(len(msg.Bytes)))
+		b = b[:len(b)+uvlen]
+		b = append(b, msg.Bytes...)
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 6)
+	}
+	if msg.ByteArr != nil {
+		// field number 6
+		b = append(b, (6<<3)|2 /* 0x32 */, 4) // tag, size
+		b = append(b, (*msg.ByteArr)[:]...)
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 7)
+	}
+	// field number 7
+	// (always empty, skip as there is no write_empty)
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 8)
+	}
+	if msg.IntPtr != nil {
+		if *msg.IntPtr != 0 {
+			// field number 8
+			b = append(b, (8<<3)|0 /* 0x40 */)
+			b = growBytes(b, 10)
+			b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(*msg.IntPtr))]
+		}
+	}
+	if len(unknown) > 0 {
+		b, unknown = appendUnknown(b, unknown, 9)
+	}
+	for _, el := range msg.Slice {
+		// field number 9
+		{
+			startLen := len(b)
+			// the unknown fields are written in field number order, between the others.
+			unknown := el.XXX_unknown
+			if len(unknown) > 0 {
+				b, unknown = appendUnknown(b, unknown, 1)
+			}
+			if el.A != 0 {
+				// field number 1
+				b = append(b, (1<<3)|0 /* 0x08 */)
+				b = growBytes(b, 10)
+				b = b[:len(b)+putVarint(b[len(b):len(b)+10], int64(el.A))]
+			}
+			b = append(b, unknown...)
+			encodedSize := uint64(len(b) - startLen)
+			switch {
+			case encodedSize == 0:
+				// empty -- append tag and 0.
+				b = append(b, (9<<3)|2 /* 0x4a */, 0)
+			case encodedSize <= maxVarint1:
+				const shift = 1 + 1
+				b = growBytes(b, shift)[:len(b)+shift]
+				copy(b[startLen+shift:], b[startLen:len(b)-shift])
+				_ = append(b[:startLen], (9<<3)|2 /* 0x4a */, byte(encodedSize))
+			default:
+				shift := 1 + uvarintSize(encodedSize) // tag length + uvarint size
+				b = growBytes(b, shift)[:len(b)+shift]
+				copy(b[startLen+shift:], b[startLen:len(b)-shift])
+				_ = append(b[:startLen], (9<<3)|2 /* 0x4a */)
+				putUvarint(b[startLen+1:startLen+shift], encodedSize)
+			}
+		}
+	}
+	b = append(b, unknown...)
+	return b, nil
+}
+
+// UnmarshalBinary decodes the message from b using the generated tomino
+// unmarshaler. The message is reset before decoding.
+func (msg *OldSliceTestTypeMessage) UnmarshalBinary(b []byte) error {
+	return msg.unmarshalBinary(b, false)
+}
+
+// UnmarshalBinaryNoCopy is like [OldSliceTestTypeMessage.UnmarshalBinary], but the byte
+// slices and strings of the message alias b instead of being copied from it.
+// b must not be modified for as long as the message is in use, as the changes
+// would be visible in the message, breaking the immutability of its strings.
+// The capacity of the byte slices is limited to their length, so appending to
+// them doesn't modify b.
+func (msg *OldSliceTestTypeMessage) UnmarshalBinaryNoCopy(b []byte) error {
+	return msg.unmarshalBinary(b, true)
+}
+
+// unmarshalBinary decodes the message from b, aliasing b in byte slices and
+// strings if alias is set.
+func (msg *OldSliceTestTypeMessage) unmarshalBinary(b []byte, alias bool) error {
+	*msg = OldSliceTestTypeMessage{}
+	for len(b) > 0 {
+		rec := b
+		num, wt, n, err := consumeTag(b)
+		if err != nil {
+			return err
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Time
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Duration
+				for len(b) > 0 {
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+					}
+				}
+			}
+		case 3:
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		case 5:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 6:
+			if msg.ByteArr == nil {
+				msg.ByteArr = new([4]byte)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 4 {
+				return errArrayLength
+			}
+			copy((*msg.ByteArr)[:], v)
+		case 7:
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 0 {
+				return errArrayLength
+			}
+			copy(msg.ZeroArr[:], v)
+		case 8:
+			if msg.IntPtr == nil {
+				msg.IntPtr = new(int)
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			*msg.IntPtr = int(x)
+		case 9:
+			if wt != 2 {
+				return errWireType
+			}
+			msg.Slice = append(msg.Slice, struct {
+				A           int    `json:"A"`
+				XXX_unknown []byte `json:"-"`
+			}{})
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			{
+				b := v
+				m := &msg.Slice[len(msg.Slice)-1]
+				for len(b) > 0 {
+					rec := b
+					num, wt, n, err := consumeTag(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					switch num {
+					case 1:
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.A = int(x)
+					default:
+						n, err := skipField(b, wt)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.XXX_unknown = append(m.XXX_unknown, rec[:len(rec)-len(b)]...)
+					}
+				}
+			}
+		default:
+			n, err := skipField(b, wt)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.XXX_unknown = append(msg.XXX_unknown, rec[:len(rec)-len(b)]...)
+		}
+	}
+	return nil
+}
+
+// MarshalBinarySized encodes the message like [OldSliceTestTypeMessage.MarshalBinary],
+// prefixed by its length as a uvarint, like amino.MarshalSized.
+func (msg OldSliceTestTypeMessage) MarshalBinarySized() ([]byte, error) {
+	return msg.AppendBinarySized(make([]byte, 0, 64))
+}
+
+// AppendBinarySized encodes the message prefixed by its length as a uvarint,
+// appending the encoded bytes to b and returning the result.
+func (msg OldSliceTestTypeMessage) AppendBinarySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalBinarySized decodes the message from b, prefixed by its length as
+// a uvarint, like amino.UnmarshalSized. The length must match the rest of b.
+// If maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *OldSliceTestTypeMessage) UnmarshalBinarySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// WriteTo writes the message to w, prefixed by its length as a uvarint like
+// [OldSliceTestTypeMessage.MarshalBinarySized], so that a stream of messages can be read
+// back with ReadMessageFrom. The message is encoded whole before it is written.
+func (msg OldSliceTestTypeMessage) WriteTo(w io.Writer) (int64, error) {
+	b, err := msg.MarshalBinarySized()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadMessageFrom reads a message prefixed by its length as a uvarint from r,
+// as written by WriteTo, and returns the number of bytes read. Only the bytes
+// of the message are read from r, so it can be called again to read the next
+// message of a stream; io.EOF is returned if the stream ends before a message.
+// The message is decoded as it is read, and the embedded messages are not
+// buffered. Messages larger than 64 MiB are rejected before they are read.
+// The message is reset before decoding.
+func (msg *OldSliceTestTypeMessage) ReadMessageFrom(r io.Reader) (int64, error) {
+	*msg = OldSliceTestTypeMessage{}
+	sr := &streamReader{r: r}
+	if err := sr.push(); err != nil {
+		return sr.n, err
+	}
+	err := msg.readStream(sr)
+	return sr.n, err
+}
+
+// readStream decodes the message being read by sr, after its length prefix.
+func (msg *OldSliceTestTypeMessage) readStream(sr *streamReader) error {
+	const alias = false
+	for sr.more() {
+		num, wt, err := sr.tag()
+		if err != nil {
+			return err
+		}
+		switch num {
+		case 1:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Time
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 2:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			{
+				m := &msg.Duration
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						m.Seconds = uint64(x)
+					case 2:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeUvarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if uint64(uint32(x)) != x {
+							return errOverflow
+						}
+						m.Nanoseconds = uint32(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			sr.pop()
+		case 3:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 1 {
+				return errWireType
+			}
+			x, n, err := consumeFixed64(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			msg.FixedUint = uint64(x)
+		case 4:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeUvarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if uint64(uint8(x)) != x {
+				return errOverflow
+			}
+			msg.Byte = uint8(x)
+		case 5:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if alias {
+				msg.Bytes = aliasBytes(v)
+			} else {
+				msg.Bytes = append([]byte(nil), v...)
+			}
+		case 6:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.ByteArr == nil {
+				msg.ByteArr = new([4]byte)
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 4 {
+				return errArrayLength
+			}
+			copy((*msg.ByteArr)[:], v)
+		case 7:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if wt != 2 {
+				return errWireType
+			}
+			v, n, err := consumeBytes(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if len(v) != 0 {
+				return errArrayLength
+			}
+			copy(msg.ZeroArr[:], v)
+		case 8:
+			b, err := sr.record(wt)
+			if err != nil {
+				return err
+			}
+			if msg.IntPtr == nil {
+				msg.IntPtr = new(int)
+			}
+			if wt != 0 {
+				return errWireType
+			}
+			x, n, err := consumeVarint(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if int64(int(x)) != x {
+				return errOverflow
+			}
+			*msg.IntPtr = int(x)
+		case 9:
+			if wt != 2 {
+				return errWireType
+			}
+			if err := sr.push(); err != nil {
+				return err
+			}
+			msg.Slice = append(msg.Slice, struct {
+				A           int    `json:"A"`
+				XXX_unknown []byte `json:"-"`
+			}{})
+			{
+				m := &msg.Slice[len(msg.Slice)-1]
+				for sr.more() {
+					num, wt, err := sr.tag()
+					if err != nil {
+						return err
+					}
+					switch num {
+					case 1:
+						b, err := sr.record(wt)
+						if err != nil {
+							return err
+						}
+						if wt != 0 {
+							return errWireType
+						}
+						x, n, err := consumeVarint(b)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if int64(int(x)) != x {
+							return errOverflow
+						}
+						m.A = int(x)
+					default:
+						if _, err := sr.record(wt); err != nil {
+							return err
+						}
+						m.XXX_unknown = append(m.XXX_unknown, sr.val...)
+					}
+				}
+			}
+			sr.pop()
+		default:
+			if _, err := sr.record(wt); err != nil {
+				return err
+			}
+			msg.XXX_unknown = append(msg.XXX_unknown, sr.val...)
+		}
+	}
+	return nil
+}
+
+// MarshalAny encodes the message wrapped in a google.protobuf.Any with its
+// type URL, /tomtypes.OldSliceTestType, like amino.MarshalAny.
+func (msg OldSliceTestTypeMessage) MarshalAny() ([]byte, error) {
+	return msg.AppendAny(make([]byte, 0, 64))
+}
+
+// AppendAny encodes the message wrapped in a google.protobuf.Any, appending
+// the encoded bytes to b and returning the result.
+func (msg OldSliceTestTypeMessage) AppendAny(b []byte) ([]byte, error) {
+	return appendAny(b, "/tomtypes.OldSliceTestType", msg)
+}
+
+// MarshalAnySized encodes the message like [OldSliceTestTypeMessage.MarshalAny],
+// prefixed by its length as a uvarint, like amino.MarshalAnySized.
+func (msg OldSliceTestTypeMessage) MarshalAnySized() ([]byte, error) {
+	return msg.AppendAnySized(make([]byte, 0, 64))
+}
+
+// AppendAnySized encodes the message wrapped in a google.protobuf.Any and
+// prefixed by its length as a uvarint, appending the encoded bytes to b and
+// returning the result.
+func (msg OldSliceTestTypeMessage) AppendAnySized(b []byte) ([]byte, error) {
+	start := len(b)
+	b, err := msg.AppendAny(b)
+	if err != nil {
+		return nil, err
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// UnmarshalAny decodes the message from the google.protobuf.Any in b, like
+// amino.UnmarshalAny. The type URL of the Any must be /tomtypes.OldSliceTestType.
+// The message is reset before decoding.
+func (msg *OldSliceTestTypeMessage) UnmarshalAny(b []byte) error {
+	url, v, err := consumeAny(b)
+	if err != nil {
+		return err
+	}
+	if string(url) != "/tomtypes.OldSliceTestType" {
+		return unknownTypeError(url, "github.com/thehowl/tomino/tests/golden.OldSliceTestType")
+	}
+	return msg.UnmarshalBinary(v)
+}
+
+// UnmarshalAnySized decodes the message from the google.protobuf.Any in b,
+// prefixed by its length as a uvarint, as written by MarshalAnySized. If
+// maxSize is greater than 0, longer messages are rejected before decoding.
+func (msg *OldSliceTestTypeMessage) UnmarshalAnySized(b []byte, maxSize int) error {
+	v, err := consumeSized(b, maxSize)
+	if err != nil {
+		return err
+	}
+	return msg.UnmarshalAny(v)
+}
+
+// MarshalJSON encodes the message in amino's JSON encoding, using the
+// generated tomino marshaler. It calls [OldSliceTestTypeMessage.AppendJSON] with a
+// pre-allocated buffer of 64 bytes.
+func (msg OldSliceTestTypeMessage) MarshalJSON() ([]byte, error) {
+	return msg.AppendJSON(make([]byte, 0, 64))
+}
+
+// AppendJSON encodes the message in amino's JSON encoding, appending the
+// encoded bytes to b and returning the result.
+func (msg OldSliceTestTypeMessage) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
+
+// SignBytes returns the canonical JSON encoding of the message, used for
+// signing it: the same as MustSortJSON(amino.MarshalJSON(msg)). It calls
+// [OldSliceTestTypeMessage.AppendCanonicalJSON] with a pre-allocated buffer of 64 bytes.
+func (msg OldSliceTestTypeMessage) SignBytes() ([]byte, error) {
+	return msg.AppendCanonicalJSON(make([]byte, 0, 64))
+}
+
+// AppendCanonicalJSON encodes the message in amino's JSON encoding, with
+// the keys of the objects sorted, appending the encoded bytes to b and
+// returning the result.
+func (msg OldSliceTestTypeMessage) AppendCanonicalJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, `"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, '}')
+	return b, nil
+}
+
+// UnmarshalJSON decodes the message from amino's JSON encoding, using the
+// generated tomino unmarshaler. The message is reset before decoding.
+func (msg *OldSliceTestTypeMessage) UnmarshalJSON(b []byte) error {
+	*msg = OldSliceTestTypeMessage{}
+	b = b[jsonSpace(b):]
+	if isJSONNull(b) {
+		b = b[4:]
+	} else {
+		for first := true; ; first = false {
+			more, n, err := nextJSONElem(b, "{}", first)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			if !more {
+				break
+			}
+			key, n, err := consumeJSONKey(b)
+			if err != nil {
+				return err
+			}
+			b = b[n:]
+			switch string(key) {
+			case "Time":
+				msg.Time.Seconds = 0
+				msg.Time.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONTime(v)
+					if err != nil {
+						return err
+					}
+					msg.Time.Seconds, msg.Time.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "Duration":
+				msg.Duration.Seconds = 0
+				msg.Duration.Nanoseconds = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					s, ns, err := parseJSONDuration(v)
+					if err != nil {
+						return err
+					}
+					msg.Duration.Seconds, msg.Duration.Nanoseconds = uint64(s), uint32(ns)
+				}
+			case "FixedUint":
+				msg.FixedUint = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 64, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.FixedUint = x
+				}
+			case "Byte":
+				msg.Byte = 0
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					x, n, err := consumeJSONUint(b, 8, false)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Byte = uint8(x)
+				}
+			case "Bytes":
+				msg.Bytes = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					msg.Bytes, err = decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+				}
+			case "ByteArr":
+				msg.ByteArr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.ByteArr = new([4]byte)
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					p, err := decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+					if len(p) != 4 {
+						return errArrayLength
+					}
+					copy((*msg.ByteArr)[:], p)
+				}
+			case "ZeroArr":
+				msg.ZeroArr = [0]byte{}
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					v, n, err := consumeJSONString(b)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					p, err := decodeJSONBase64(v)
+					if err != nil {
+						return err
+					}
+					if len(p) != 0 {
+						return errArrayLength
+					}
+					copy(msg.ZeroArr[:], p)
+				}
+			case "IntPtr":
+				msg.IntPtr = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					msg.IntPtr = new(int)
+					x, n, err := consumeJSONInt(b, 0, true)
+					if err != nil {
+						return err
+					}
+					b = b[n:]
+					*msg.IntPtr = int(x)
+				}
+			case "Slice":
+				msg.Slice = nil
+				if isJSONNull(b) {
+					b = b[4:]
+				} else {
+					for first := true; ; first = false {
+						more, n, err := nextJSONElem(b, "[]", first)
+						if err != nil {
+							return err
+						}
+						b = b[n:]
+						if !more {
+							break
+						}
+						msg.Slice = append(msg.Slice, struct {
+							A           int    `json:"A"`
+							XXX_unknown []byte `json:"-"`
+						}{})
+						if isJSONNull(b) {
+							b = b[4:]
+						} else {
+							for first := true; ; first = false {
+								more, n, err := nextJSONElem(b, "{}", first)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								if !more {
+									break
+								}
+								key, n, err := consumeJSONKey(b)
+								if err != nil {
+									return err
+								}
+								b = b[n:]
+								switch string(key) {
+								case "A":
+									msg.Slice[len(msg.Slice)-1].A = 0
+									if isJSONNull(b) {
+										b = b[4:]
+									} else {
+										x, n, err := consumeJSONInt(b, 0, true)
+										if err != nil {
+											return err
+										}
+										b = b[n:]
+										msg.Slice[len(msg.Slice)-1].A = int(x)
+									}
+								default:
+									n, err := skipJSONValue(b, 0)
+									if err != nil {
+										return err
+									}
+									b = b[n:]
+								}
+							}
+						}
+					}
+				}
+			default:
+				n, err := skipJSONValue(b, 0)
+				if err != nil {
+					return err
+				}
+				b = b[n:]
+			}
+		}
+	}
+	if len(b) != jsonSpace(b) {
+		return errJSONSyntax
+	}
+	return nil
+}
+
+// MarshalJSONAny encodes the message in amino's JSON encoding, with its type
+// URL, /tomtypes.OldSliceTestType, in the "@type" member, like amino.MarshalJSONAny.
+func (msg OldSliceTestTypeMessage) MarshalJSONAny() ([]byte, error) {
+	return msg.appendJSONAny(make([]byte, 0, 64), false)
+}
+
+// ---
+// encoding helpers
+
+// Non-generic version of slices.Grow.
+func growBytes(s []byte, n int) []byte {
+	if n -= cap(s) - len(s); n > 0 {
+		s = append(s[:cap(s)], make([]byte, n)...)[:len(s)]
+	}
+	return s
+}
+
+const (
+	// These are common when encoding lengths, and have fast paths instead of
+	// calling putUvarint.
+	maxVarint1 = (1 << 7) - 1
+	maxVarint2 = (1 << 14) - 1
+
+	len8tab = "" +
+		"\x00\x01\x02\x02\x03\x03\x03\x03\x04\x04\x04\x04\x04\x04\x04\x04" +
+		"\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05\x05" +
+		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
+		"\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06\x06" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07\x07" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08" +
+		"\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08\x08"
+)
+
+// len64 returns the minimum number of bits required to represent x; the result is 0 for x == 0.
+// from math/bits.
+func len64(x uint64) (n int) {
+	if x >= 1<<32 {
+		x >>= 32
+		n = 32
+	}
+	if x >= 1<<16 {
+		x >>= 16
+		n += 16
+	}
+	if x >= 1<<8 {
+		x >>= 8
+		n += 8
+	}
+	return n + int(len8tab[x])
+}
+
+func uvarintSize(x uint64) int {
+	// +6 allows us to count any "remainder" as a full byte to be encoded.
+	return (len64(x) + 6) / 7
+}
+
+// putUvarint encodes a uint64 into buf and returns the number of bytes written.
+// If the buffer is too small, PutUvarint will panic.
+// Copied from package binary.
+func putUvarint(buf []byte, x uint64) int {
+	i := 0
+	for x >= 0x80 {
+		buf[i] = byte(x) | 0x80
+		x >>= 7
+		i++
+	}
+	buf[i] = byte(x)
+	return i + 1
+}
+
+// insertUvarint inserts the uvarint encoding of x into b at position pos,
+// shifting the following bytes.
+func insertUvarint(b []byte, pos int, x uint64) []byte {
+	n := uvarintSize(x)
+	if n == 0 {
+		n = 1
+	}
+	b = growBytes(b, n)[:len(b)+n]
+	copy(b[pos+n:], b[pos:len(b)-n])
+	putUvarint(b[pos:pos+n], x)
+	return b
+}
+
+// putVarint encodes an int64 into buf and returns the number of bytes written.
+// If the buffer is too small, PutVarint will panic.
+func putVarint(buf []byte, x int64) int {
+	ux := uint64(x) << 1
+	if x < 0 {
+		ux = ^ux
+	}
+	return putUvarint(buf, ux)
+}
+
+func putUint64(b []byte, v uint64) {
+	_ = b[7] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+	b[4] = byte(v >> 32)
+	b[5] = byte(v >> 40)
+	b[6] = byte(v >> 48)
+	b[7] = byte(v >> 56)
+}
+
+func putUint32(b []byte, v uint32) {
+	_ = b[3] // early bounds check to guarantee safety of writes below
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+}
+
+// ---
+// decoding helpers
+
+var (
+	errUnexpectedEOF = errors.New("tomino: unexpected end of input")
+	errOverflow      = errors.New("tomino: integer overflows its type")
+	errInvalidBool   = errors.New("tomino: invalid bool")
+	errWireType      = errors.New("tomino: invalid wire type")
+	errArrayLength   = errors.New("tomino: invalid array length")
+	errTooLarge      = errors.New("tomino: message exceeds the maximum size")
+	errTrailingData  = errors.New("tomino: trailing data after the message")
+	errInvalidAny    = errors.New("tomino: invalid google.protobuf.Any")
+	errNilAny        = errors.New("tomino: nil pointer in interface")
+)
+
+// consumeUvarint decodes an uvarint from b, and returns it together with the
+// number of bytes read.
+func consumeUvarint(b []byte) (uint64, int, error) {
+	var x uint64
+	for i := 0; i < 10; i++ {
+		if i >= len(b) {
+			return 0, 0, errUnexpectedEOF
+		}
+		c := b[i]
+		if i == 9 && c > 1 {
+			break
+		}
+		x |= uint64(c&0x7f) << uint(7*i)
+		if c < 0x80 {
+			return x, i + 1, nil
+		}
+	}
+	return 0, 0, errOverflow
+}
+
+// consumeVarint decodes a zig-zag encoded varint from b.
+func consumeVarint(b []byte) (int64, int, error) {
+	ux, n, err := consumeUvarint(b)
+	x := int64(ux >> 1)
+	if ux&1 != 0 {
+		x = ^x
+	}
+	return x, n, err
+}
+
+// consumeTag decodes the tag of a record, returning its field number and
+// wire type.
+func consumeTag(b []byte) (uint64, int, int, error) {
+	x, n, err := consumeUvarint(b)
+	return x >> 3, int(x & 7), n, err
+}
+
+// consumeBool decodes a bool from b. Like in amino, it must be a single byte
+// of value 0 or 1.
+func consumeBool(b []byte) (bool, int, error) {
+	if len(b) == 0 {
+		return false, 0, errUnexpectedEOF
+	}
+	if b[0] > 1 {
+		return false, 0, errInvalidBool
+	}
+	return b[0] == 1, 1, nil
+}
+
+func consumeFixed64(b []byte) (uint64, int, error) {
+	if len(b) < 8 {
+		return 0, 0, errUnexpectedEOF
+	}
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56, 8, nil
+}
+
+func consumeFixed32(b []byte) (uint32, int, error) {
+	if len(b) < 4 {
+		return 0, 0, errUnexpectedEOF
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24, 4, nil
+}
+
+// appendUnknown appends to b the records at the start of the unknown fields u
+// with a field number lower than num, and returns the remaining ones.
+// If the records of u are malformed, they are kept in the remaining ones.
+func appendUnknown(b, u []byte, num uint64) ([]byte, []byte) {
+	i := 0
+	for i < len(u) {
+		n, wt, tn, err := consumeTag(u[i:])
+		if err != nil || n >= num {
+			break
+		}
+		vn, err := skipField(u[i+tn:], wt)
+		if err != nil {
+			break
+		}
+		i += tn + vn
+	}
+	return append(b, u[:i]...), u[i:]
+}
+
+// consumeSized returns the message in b, prefixed by its length as a uvarint.
+// The length must cover the rest of b, and it must not be greater than
+// maxSize, unless maxSize is 0.
+func consumeSized(b []byte, maxSize int) ([]byte, error) {
+	l, n, err := consumeUvarint(b)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case maxSize > 0 && l > uint64(maxSize):
+		return nil, errTooLarge
+	case l > uint64(len(b)-n):
+		return nil, errUnexpectedEOF
+	case l < uint64(len(b)-n):
+		return nil, errTrailingData
+	}
+	return b[n:], nil
+}
+
+// maxStreamSize is the maximum size of the messages read by ReadMessageFrom.
+const maxStreamSize = 64 << 20
+
+// streamReader reads a message from a stream for ReadMessageFrom, without
+// reading past its end. The embedded messages are decoded as they are read;
+// only the other values are read whole, so that a length prefix not followed
+// by the data doesn't cause a large allocation.
+type streamReader struct {
+	r io.Reader
+	// n is the number of bytes consumed from r.
+	n int64
+	// limits are the values of n where the message and the embedded messages
+	// being read end, from the outermost to the innermost.
+	limits []int64
+	// buf[pos:end] are the bytes read from r and not consumed yet.
+	buf      [512]byte
+	pos, end int
+	// val holds the tag and the value of the last record read.
+	val []byte
+}
+
+// readByte consumes a byte of the innermost message.
+func (s *streamReader) readByte() (byte, error) {
+	if len(s.limits) > 0 && s.n >= s.limits[len(s.limits)-1] {
+		return 0, errUnexpectedEOF
+	}
+	if s.pos == s.end {
+		if err := s.fill(); err != nil {
+			return 0, err
+		}
+	}
+	c := s.buf[s.pos]
+	s.pos++
+	s.n++
+	return c, nil
+}
+
+// fill reads more bytes into buf, once they have all been consumed. Until the
+// length of the message is known, the bytes are read one at a time.
+func (s *streamReader) fill() error {
+	want := int64(1)
+	if len(s.limits) > 0 {
+		want = s.limits[0] - s.n
+		if want > int64(len(s.buf)) {
+			want = int64(len(s.buf))
+		}
+	}
+	m, err := io.ReadAtLeast(s.r, s.buf[:want], 1)
+	s.pos, s.end = 0, m
+	if err == io.EOF && s.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// uvarint consumes a uvarint, appending its bytes to val.
+func (s *streamReader) uvarint() (uint64, error) {
+	start := len(s.val)
+	for i := 0; i < 10; i++ {
+		c, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		s.val = append(s.val, c)
+		if c < 0x80 {
+			break
+		}
+	}
+	x, _, err := consumeUvarint(s.val[start:])
+	return x, err
+}
+
+// tag consumes the tag of a record, returning its field number and wire type.
+func (s *streamReader) tag() (uint64, int, error) {
+	s.val = s.val[:0]
+	x, err := s.uvarint()
+	return x >> 3, int(x & 7), err
+}
+
+// record consumes the value of a record of wire type wt, after its tag, and
+// returns it. The value is only valid until the next call to tag.
+func (s *streamReader) record(wt int) ([]byte, error) {
+	start := len(s.val)
+	var l uint64
+	switch wt {
+	case 0:
+		_, err := s.uvarint()
+		return s.val[start:], err
+	case 1:
+		l = 8
+	case 2:
+		var err error
+		if l, err = s.uvarint(); err != nil {
+			return nil, err
+		}
+	case 5:
+		l = 4
+	default:
+		return nil, errWireType
+	}
+	if l > uint64(s.limits[len(s.limits)-1]-s.n) {
+		return nil, errUnexpectedEOF
+	}
+	for l > 0 {
+		if s.pos == s.end {
+			if err := s.fill(); err != nil {
+				return nil, err
+			}
+		}
+		k := s.end - s.pos
+		if uint64(k) > l {
+			k = int(l)
+		}
+		s.val = append(s.val, s.buf[s.pos:s.pos+k]...)
+		s.pos += k
+		s.n += int64(k)
+		l -= uint64(k)
+	}
+	return s.val[start:], nil
+}
+
+// push consumes the length prefix of a message, which is then read until pop
+// is called. The outermost message must not be larger than maxStreamSize.
+func (s *streamReader) push() error {
+	s.val = s.val[:0]
+	l, err := s.uvarint()
+	if err != nil {
+		return err
+	}
+	if len(s.limits) == 0 {
+		if l > maxStreamSize {
+			return errTooLarge
+		}
+	} else if l > uint64(s.limits[len(s.limits)-1]-s.n) {
+		return errUnexpectedEOF
+	}
+	s.limits = append(s.limits, s.n+int64(l))
+	return nil
+}
+
+// pop ends the innermost message, which must have been read entirely.
+func (s *streamReader) pop() {
+	s.limits = s.limits[:len(s.limits)-1]
+}
+
+// more returns whether the innermost message has more bytes to read.
+func (s *streamReader) more() bool {
+	return s.n < s.limits[len(s.limits)-1]
+}
+
+// aliasBytes returns v as a decoded byte slice, without copying it.
+// Like when copying, empty slices are decoded as nil.
+func aliasBytes(v []byte) []byte {
+	if len(v) == 0 {
+		return nil
+	}
+	return v[:len(v):len(v)]
+}
+
+// consumeBytes decodes a length-prefixed value from b. The returned slice
+// aliases b.
+func consumeBytes(b []byte) ([]byte, int, error) {
+	l, n, err := consumeUvarint(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if l > uint64(len(b)-n) {
+		return nil, 0, errUnexpectedEOF
+	}
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+// skipField returns the size of the value of an unknown field.
+func skipField(b []byte, wt int) (int, error) {
+	switch wt {
+	case 0:
+		_, n, err := consumeUvarint(b)
+		return n, err
+	case 1:
+		_, n, err := consumeFixed64(b)
+		return n, err
+	case 2:
+		_, n, err := consumeBytes(b)
+		return n, err
+	case 5:
+		_, n, err := consumeFixed32(b)
+		return n, err
+	}
+	return 0, errWireType
+}
+
+// ---
+// google.protobuf.Any
+
+// appendAny appends to b the google.protobuf.Any holding the message m,
+// identified by url. Like in amino, the value is omitted if it is empty.
+func appendAny(b []byte, url string, m interface{ AppendBinary([]byte) ([]byte, error) }) ([]byte, error) {
+	b = append(b, (1<<3)|2 /* 0x0a */)
+	b = insertUvarint(b, len(b), uint64(len(url)))
+	b = append(b, url...)
+	b = append(b, (2<<3)|2 /* 0x12 */)
+	start := len(b)
+	b, err := m.AppendBinary(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == start {
+		return b[:start-1], nil
+	}
+	return insertUvarint(b, start, uint64(len(b)-start)), nil
+}
+
+// consumeAny decodes the google.protobuf.Any in b, returning its type URL and
+// its value. Like in amino, the type URL must come first, followed by the
+// value if it is not empty, and there can be no other fields.
+func consumeAny(b []byte) ([]byte, []byte, error) {
+	if len(b) == 0 || b[0] != (1<<3)|2 {
+		return nil, nil, errInvalidAny
+	}
+	url, n, err := consumeBytes(b[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	b = b[1+n:]
+	if len(b) == 0 {
+		return url, nil, nil
+	}
+	if b[0] != (2<<3)|2 {
+		return nil, nil, errInvalidAny
+	}
+	v, n, err := consumeBytes(b[1:])
+	switch {
+	case err != nil:
+		return nil, nil, err
+	case 1+n != len(b):
+		return nil, nil, errTrailingData
+	}
+	return url, v, nil
+}
+
+// unknownTypeError is returned when decoding an interface holding a type URL
+// which is not one of its implementations.
+func unknownTypeError(url []byte, iface string) error {
+	return fmt.Errorf("tomino: unknown type URL %q for %s", url, iface)
+}
+
+// notImplementationError is returned when encoding an interface holding a
+// value which is not one of its implementations.
+func notImplementationError(v interface{}, iface string) error {
+	return fmt.Errorf("tomino: %T is not an implementation of %s", v, iface)
+}
+
+// ---
+// JSON encoding helpers
+
+var (
+	errJSONFloat = errors.New("tomino: cannot encode NaN or infinity in JSON")
+	errJSONTime  = errors.New("tomino: time out of range")
+)
+
+const (
+	jsonHex = "0123456789abcdef"
+
+	// The range of times supported by amino: years 1 through 9999.
+	minJSONTime = -62135596800
+	maxJSONTime = 253402300800
+)
+
+// appendJSONString appends s as a JSON string, escaped like encoding/json
+// does: invalid UTF-8 is replaced with U+FFFD, and the HTML characters <, >
+// and &, as well as U+2028 and U+2029, are escaped.
+// U+FFFD is written as \ufffd, unless canonical is set: then it is written
+// as is, like encoding/json does when sorting the output of amino.
+func appendJSONString(b []byte, s string, canonical bool) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', jsonHex[c>>4], jsonHex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1 && canonical:
+			b = append(b, s[start:i]...)
+			b = append(b, "\uFFFD"...)
+		case r == utf8.RuneError && size == 1:
+			b = append(b, s[start:i]...)
+			b = append(b, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', jsonHex[r&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// appendJSONBytes appends p as a base64-encoded JSON string.
+func appendJSONBytes(b, p []byte) []byte {
+	n := base64.StdEncoding.EncodedLen(len(p))
+	b = append(b, '"')
+	b = growBytes(b, n)[:len(b)+n]
+	base64.StdEncoding.Encode(b[len(b)-n:], p)
+	return append(b, '"')
+}
+
+// appendJSONFloat appends f, which has the given bit size, formatted like
+// encoding/json does.
+func appendJSONFloat(b []byte, f float64, bits int) ([]byte, error) {
+	// f-f is NaN for NaN and infinities.
+	if f-f != 0 {
+		return nil, errJSONFloat
+	}
+	abs := f
+	if abs < 0 {
+		abs = -abs
+	}
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9.
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b, nil
+}
+
+// appendJSONNanos appends the fraction of a second of ns nanoseconds, with 0,
+// 3, 6 or 9 digits, as amino does for times and durations.
+func appendJSONNanos(b []byte, ns uint32) []byte {
+	if ns == 0 {
+		return b
+	}
+	digits := 9
+	for digits > 3 && ns%1000 == 0 {
+		ns /= 1000
+		digits -= 3
+	}
+	b = append(b, '.')
+	b = growBytes(b, digits)[:len(b)+digits]
+	for i := len(b) - 1; digits > 0; i, digits = i-1, digits-1 {
+		b[i] = byte('0' + ns%10)
+		ns /= 10
+	}
+	return b
+}
+
+// appendJSONTime appends the time at s seconds and ns nanoseconds since the
+// Unix epoch as an RFC 3339 string in UTC, like "2006-01-02T15:04:05.999Z".
+func appendJSONTime(b []byte, s int64, ns int32) ([]byte, error) {
+	if s < minJSONTime || s >= maxJSONTime || ns < 0 || ns >= 1e9 {
+		return nil, errJSONTime
+	}
+	b = append(b, '"')
+	b = append(b, time.Unix(s, int64(ns)).UTC().Format("2006-01-02T15:04:05")...)
+	b = appendJSONNanos(b, uint32(ns))
+	return append(b, 'Z', '"'), nil
+}
+
+// appendJSONDuration appends the duration of s seconds and ns nanoseconds as
+// a string of seconds, like "-1.5s".
+func appendJSONDuration(b []byte, s int64, ns int32) []byte {
+	d := s*1e9 + int64(ns)
+	u := uint64(d)
+	b = append(b, '"')
+	if d < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	b = strconv.AppendUint(b, u/1e9, 10)
+	b = appendJSONNanos(b, uint32(u%1e9))
+	return append(b, 's', '"')
+}
+
+// ---
+// JSON decoding helpers
+
+var (
+	errJSONSyntax = errors.New("tomino: invalid JSON")
+	errJSONValue  = errors.New("tomino: invalid JSON value for the type")
+)
+
+// maxJSONDepth is the maximum nesting of skipped JSON values, as in
+// encoding/json.
+const maxJSONDepth = 10000
+
+// jsonSpace returns the number of whitespace characters at the start of b.
+func jsonSpace(b []byte) int {
+	n := 0
+	for n < len(b) && (b[n] == ' ' || b[n] == '\t' || b[n] == '\n' || b[n] == '\r') {
+		n++
+	}
+	return n
+}
+
+// isJSONNull returns whether b starts with null.
+func isJSONNull(b []byte) bool {
+	return len(b) >= 4 && string(b[:4]) == "null"
+}
+
+// nextJSONElem consumes what precedes the next element of an object or array,
+// delimited by the two characters in delims: the opening character for the
+// first element, or a comma for the following ones, and the whitespace
+// around it. If there are no more elements, it consumes the closing character
+// and returns false.
+func nextJSONElem(b []byte, delims string, first bool) (bool, int, error) {
+	n := 0
+	if first {
+		switch {
+		case len(b) == 0:
+			return false, 0, errUnexpectedEOF
+		case b[0] != delims[0]:
+			return false, 0, errJSONValue
+		}
+		n = 1
+	}
+	n += jsonSpace(b[n:])
+	switch {
+	case n == len(b):
+		return false, 0, errUnexpectedEOF
+	case b[n] == delims[1]:
+		return false, n + 1, nil
+	case first:
+		return true, n, nil
+	case b[n] != ',':
+		return false, 0, errJSONSyntax
+	}
+	n++
+	return true, n + jsonSpace(b[n:]), nil
+}
+
+// consumeJSONKey decodes the key of an object member from b, consuming the
+// colon following it and the whitespace around it.
+func consumeJSONKey(b []byte) ([]byte, int, error) {
+	key, n, err := consumeJSONString(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	n += jsonSpace(b[n:])
+	if n == len(b) || b[n] != ':' {
+		return nil, 0, errJSONSyntax
+	}
+	n++
+	return key, n + jsonSpace(b[n:]), nil
+}
+
+// consumeJSONString decodes a JSON string from b. The returned slice aliases
+// b if the string contains no escape sequences and is ASCII.
+func consumeJSONString(b []byte) ([]byte, int, error) {
+	switch {
+	case len(b) == 0:
+		return nil, 0, errUnexpectedEOF
+	case b[0] != '"':
+		return nil, 0, errJSONValue
+	}
+	for i := 1; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == '"':
+			return b[1:i], i + 1, nil
+		case c == '\\' || c < 0x20 || c >= utf8.RuneSelf:
+			return unescapeJSONString(b, i)
+		}
+	}
+	return nil, 0, errUnexpectedEOF
+}
+
+// unescapeJSONString decodes the JSON string at the start of b, where b[i] is
+// the first character which needs to be unescaped or validated.
+// Like encoding/json, it replaces invalid UTF-8 and surrogates with U+FFFD.
+func unescapeJSONString(b []byte, i int) ([]byte, int, error) {
+	s := append([]byte(nil), b[1:i]...)
+	for i < len(b) {
+		switch c := b[i]; {
+		case c == '"':
+			return s, i + 1, nil
+		case c < 0x20:
+			return nil, 0, errJSONSyntax
+		case c == '\\':
+			if i+1 == len(b) {
+				return nil, 0, errUnexpectedEOF
+			}
+			c = b[i+1]
+			i += 2
+			switch c {
+			case '"', '\\', '/':
+				s = append(s, c)
+			case 'b':
+				s = append(s, '\b')
+			case 'f':
+				s = append(s, '\f')
+			case 'n':
+				s = append(s, '\n')
+			case 'r':
+				s = append(s, '\r')
+			case 't':
+				s = append(s, '\t')
+			case 'u':
+				r := jsonHexRune(b[i:])
+				if r < 0 {
+					return nil, 0, errJSONSyntax
+				}
+				i += 4
+				if 0xd800 <= r && r < 0xe000 {
+					// combine the surrogate with the following one, if valid.
+					r2 := rune(-1)
+					if r < 0xdc00 && i+1 < len(b) && b[i] == '\\' && b[i+1] == 'u' {
+						r2 = jsonHexRune(b[i+2:])
+					}
+					if 0xdc00 <= r2 && r2 < 0xe000 {
+						r = (r-0xd800)<<10 | (r2 - 0xdc00) + 0x10000
+						i += 6
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				var buf [utf8.UTFMax]byte
+				s = append(s, buf[:utf8.EncodeRune(buf[:], r)]...)
+			default:
+				return nil, 0, errJSONSyntax
+			}
+		case c < utf8.RuneSelf:
+			s = append(s, c)
+			i++
+		default:
+			r, size := utf8.DecodeRune(b[i:])
+			if r == utf8.RuneError && size == 1 {
+				s = append(s, "\uFFFD"...)
+			} else {
+				s = append(s, b[i:i+size]...)
+			}
+			i += size
+		}
+	}
+	return nil, 0, errUnexpectedEOF
+}
+
+// jsonHexRune decodes the 4 hexadecimal digits at the start of b, returning
+// -1 if they are not valid.
+func jsonHexRune(b []byte) rune {
+	if len(b) < 4 {
+		return -1
+	}
+	var r rune
+	for _, c := range b[:4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return -1
+		}
+		r = r<<4 | rune(c)
+	}
+	return r
+}
+
+// consumeJSONBool decodes a JSON boolean from b.
+func consumeJSONBool(b []byte) (bool, int, error) {
+	switch {
+	case len(b) >= 4 && string(b[:4]) == "true":
+		return true, 4, nil
+	case len(b) >= 5 && string(b[:5]) == "false":
+		return false, 5, nil
+	}
+	return false, 0, errJSONValue
+}
+
+// jsonNumber returns the length of the JSON number at the start of b, or 0 if
+// there is none. If integer is set, the fraction and exponent are not read.
+func jsonNumber(b []byte, integer bool) int {
+	n := 0
+	if n < len(b) && b[n] == '-' {
+		n++
+	}
+	switch {
+	case n < len(b) && b[n] == '0':
+		n++
+	case n < len(b) && '1' <= b[n] && b[n] <= '9':
+		n += jsonDigits(b[n:])
+	default:
+		return 0
+	}
+	if integer {
+		return n
+	}
+	if n < len(b) && b[n] == '.' {
+		d := jsonDigits(b[n+1:])
+		if d == 0 {
+			return 0
+		}
+		n += 1 + d
+	}
+	if n < len(b) && (b[n] == 'e' || b[n] == 'E') {
+		n++
+		if n < len(b) && (b[n] == '+' || b[n] == '-') {
+			n++
+		}
+		d := jsonDigits(b[n:])
+		if d == 0 {
+			return 0
+		}
+		n += d
+	}
+	return n
+}
+
+// jsonDigits returns the number of decimal digits at the start of b.
+func jsonDigits(b []byte) int {
+	n := 0
+	for n < len(b) && '0' <= b[n] && b[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// jsonInteger returns the JSON integer at the start of b. If quoted is set,
+// it must be in a string, as amino does for 64-bit integers.
+func jsonInteger(b []byte, quoted bool) ([]byte, int, error) {
+	start := 0
+	if quoted {
+		if len(b) == 0 || b[0] != '"' {
+			return nil, 0, errJSONValue
+		}
+		start = 1
+	}
+	n := start + jsonNumber(b[start:], true)
+	if n == start {
+		return nil, 0, errJSONValue
+	}
+	s := b[start:n]
+	if quoted {
+		if n == len(b) || b[n] != '"' {
+			return nil, 0, errJSONValue
+		}
+		n++
+	}
+	return s, n, nil
+}
+
+// consumeJSONInt decodes a signed integer of the given bit size from b.
+func consumeJSONInt(b []byte, bitSize int, quoted bool) (int64, int, error) {
+	s, n, err := jsonInteger(b, quoted)
+	if err != nil {
+		return 0, 0, err
+	}
+	x, err := strconv.ParseInt(string(s), 10, bitSize)
+	if err != nil {
+		return 0, 0, errOverflow
+	}
+	return x, n, nil
+}
+
+// consumeJSONUint decodes an unsigned integer of the given bit size from b.
+func consumeJSONUint(b []byte, bitSize int, quoted bool) (uint64, int, error) {
+	s, n, err := jsonInteger(b, quoted)
+	if err != nil {
+		return 0, 0, err
+	}
+	x, err := strconv.ParseUint(string(s), 10, bitSize)
+	if err != nil {
+		return 0, 0, errOverflow
+	}
+	return x, n, nil
+}
+
+// consumeJSONFloat decodes a floating point of the given bit size from b.
+func consumeJSONFloat(b []byte, bitSize int) (float64, int, error) {
+	n := jsonNumber(b, false)
+	if n == 0 {
+		return 0, 0, errJSONValue
+	}
+	x, err := strconv.ParseFloat(string(b[:n]), bitSize)
+	if err != nil {
+		return 0, 0, errJSONValue
+	}
+	return x, n, nil
+}
+
+// decodeJSONBase64 decodes the base64-encoded bytes in the JSON string v.
+// Empty strings are decoded as nil.
+func decodeJSONBase64(v []byte) ([]byte, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	p := make([]byte, base64.StdEncoding.DecodedLen(len(v)))
+	n, err := base64.StdEncoding.Decode(p, v)
+	if err != nil {
+		return nil, errJSONValue
+	}
+	return p[:n], nil
+}
+
+// parseJSONTime parses the time in v, an RFC 3339 string in UTC, returning
+// its seconds and nanoseconds since the Unix epoch.
+func parseJSONTime(v []byte) (int64, int32, error) {
+	if len(v) == 0 || v[len(v)-1] != 'Z' {
+		return 0, 0, errJSONValue
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(v))
+	if err != nil {
+		return 0, 0, errJSONValue
+	}
+	return t.Unix(), int32(t.Nanosecond()), nil
+}
+
+// parseJSONDuration parses the duration in v, a string of seconds with up to
+// 9 fractional digits, like "-1.5s", returning its seconds and nanoseconds.
+func parseJSONDuration(v []byte) (int64, int32, error) {
+	if len(v) < 2 || v[len(v)-1] != 's' {
+		return 0, 0, errJSONValue
+	}
+	v = v[:len(v)-1]
+	neg := v[0] == '-'
+	if neg {
+		v = v[1:]
+	}
+	d := jsonDigits(v)
+	if d == 0 {
+		return 0, 0, errJSONValue
+	}
+	var s, ns uint64
+	for _, c := range v[:d] {
+		s = s*10 + uint64(c-'0')
+		if s > (1<<63)/1000000000 {
+			return 0, 0, errOverflow
+		}
+	}
+	v = v[d:]
+	if len(v) > 0 {
+		if v[0] != '.' || len(v) == 1 || len(v) > 10 || jsonDigits(v[1:]) != len(v)-1 {
+			return 0, 0, errJSONValue
+		}
+		for i := 1; i < 10; i++ {
+			ns *= 10
+			if i < len(v) {
+				ns += uint64(v[i] - '0')
+			}
+		}
+	}
+	u := s*1e9 + ns
+	if u > 1<<63 || u == 1<<63 && !neg {
+		return 0, 0, errOverflow
+	}
+	x := int64(u)
+	if neg {
+		x = -x
+	}
+	return x / 1e9, int32(x % 1e9), nil
+}
+
+// skipJSONValue returns the size of the JSON value at the start of b, which
+// is nested in depth objects or arrays.
+func skipJSONValue(b []byte, depth int) (int, error) {
+	if len(b) == 0 {
+		return 0, errUnexpectedEOF
+	}
+	switch b[0] {
+	case '"':
+		_, n, err := consumeJSONString(b)
+		return n, err
+	case '{', '[':
+		if depth >= maxJSONDepth {
+			return 0, errJSONSyntax
+		}
+		delims := "[]"
+		if b[0] == '{' {
+			delims = "{}"
+		}
+		n := 0
+		for first := true; ; first = false {
+			more, m, err := nextJSONElem(b[n:], delims, first)
+			if err != nil {
+				return 0, err
+			}
+			n += m
+			if !more {
+				return n, nil
+			}
+			if delims[0] == '{' {
+				if _, m, err = consumeJSONKey(b[n:]); err != nil {
+					return 0, err
+				}
+				n += m
+			}
+			if m, err = skipJSONValue(b[n:], depth+1); err != nil {
+				return 0, err
+			}
+			n += m
+		}
+	case 't':
+		if len(b) >= 4 && string(b[:4]) == "true" {
+			return 4, nil
+		}
+	case 'f':
+		if len(b) >= 5 && string(b[:5]) == "false" {
+			return 5, nil
+		}
+	case 'n':
+		if isJSONNull(b) {
+			return 4, nil
+		}
+	default:
+		if n := jsonNumber(b, false); n > 0 {
+			return n, nil
+		}
+	}
+	return 0, errJSONSyntax
+}
+
+// ---
+// JSON encoding of interfaces
+
+var errJSONTypeURL = errors.New("tomino: missing @type in the JSON value of an interface")
+
+// consumeJSONTypeURL returns the "@type" member of the JSON object at the
+// start of b, which is the value of an interface, and the size of the object.
+func consumeJSONTypeURL(b []byte) ([]byte, int, error) {
+	var url []byte
+	n := 0
+	for first := true; ; first = false {
+		more, m, err := nextJSONElem(b[n:], "{}", first)
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+		if !more {
+			break
+		}
+		key, m, err := consumeJSONKey(b[n:])
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+		if string(key) == "@type" && url == nil {
+			url, m, err = consumeJSONString(b[n:])
+		} else {
+			m, err = skipJSONValue(b[n:], 1)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		n += m
+	}
+	if url == nil {
+		return nil, 0, errJSONTypeURL
+	}
+	return url, n, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg OldTestTypeMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.OldTestType"`...)
+		b = append(b, `,"Byte":`...)
+		b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+		b = append(b, `,"Duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+		b = append(b, `,"FixedUint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+		b = append(b, '"')
+		b = append(b, `,"Time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.OldTestType"`...)
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, '}')
+	return b, nil
+}
+
+// appendJSONAny encodes the message in amino's JSON encoding as the value of
+// an interface, with its type URL in the "@type" member, like MarshalJSONAny.
+// If canonical is set, the keys of the objects are sorted.
+func (msg OldSliceTestTypeMessage) appendJSONAny(b []byte, canonical bool) ([]byte, error) {
+	var err error
+	if canonical {
+		b = append(b, '{')
+		b = append(b, `"@type":"/tomtypes.OldSliceTestType"`...)
+		b = append(b, `,"Byte":`...)
+		b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+		b = append(b, `,"ByteArr":`...)
+		if msg.ByteArr == nil {
+			b = append(b, "null"...)
+		} else {
+			b = appendJSONBytes(b, (*msg.ByteArr)[:])
+		}
+		b = append(b, `,"Bytes":`...)
+		if msg.Bytes == nil {
+			b = append(b, "null"...)
+		} else {
+			b = appendJSONBytes(b, msg.Bytes)
+		}
+		b = append(b, `,"Duration":`...)
+		b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+		b = append(b, `,"FixedUint":`...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+		b = append(b, '"')
+		b = append(b, `,"IntPtr":`...)
+		if msg.IntPtr == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+			b = append(b, '"')
+		}
+		b = append(b, `,"Slice":`...)
+		if msg.Slice == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i := range msg.Slice {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = append(b, '{')
+				b = append(b, `"A":`...)
+				b = append(b, '"')
+				b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+				b = append(b, '"')
+				b = append(b, '}')
+			}
+			b = append(b, ']')
+		}
+		b = append(b, `,"Time":`...)
+		b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, `,"ZeroArr":`...)
+		b = appendJSONBytes(b, msg.ZeroArr[:])
+		b = append(b, '}')
+		return b, nil
+	}
+	b = append(b, '{')
+	b = append(b, `"@type":"/tomtypes.OldSliceTestType"`...)
+	b = append(b, `,"Time":`...)
+	b, err = appendJSONTime(b, int64(msg.Time.Seconds), int32(msg.Time.Nanoseconds))
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, `,"Duration":`...)
+	b = appendJSONDuration(b, int64(msg.Duration.Seconds), int32(msg.Duration.Nanoseconds))
+	b = append(b, `,"FixedUint":`...)
+	b = append(b, '"')
+	b = strconv.AppendUint(b, uint64(msg.FixedUint), 10)
+	b = append(b, '"')
+	b = append(b, `,"Byte":`...)
+	b = strconv.AppendUint(b, uint64(msg.Byte), 10)
+	b = append(b, `,"Bytes":`...)
+	if msg.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, msg.Bytes)
+	}
+	b = append(b, `,"ByteArr":`...)
+	if msg.ByteArr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = appendJSONBytes(b, (*msg.ByteArr)[:])
+	}
+	b = append(b, `,"ZeroArr":`...)
+	b = appendJSONBytes(b, msg.ZeroArr[:])
+	b = append(b, `,"IntPtr":`...)
+	if msg.IntPtr == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '"')
+		b = strconv.AppendInt(b, int64(*msg.IntPtr), 10)
+		b = append(b, '"')
+	}
+	b = append(b, `,"Slice":`...)
+	if msg.Slice == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i := range msg.Slice {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '{')
+			b = append(b, `"A":`...)
+			b = append(b, '"')
+			b = strconv.AppendInt(b, int64(msg.Slice[i].A), 10)
+			b = append(b, '"')
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+	b = append(b, '}')
+	return b, nil
+}
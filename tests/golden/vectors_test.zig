@@ -0,0 +1,140 @@
+// Checks the Zig output in result.zig against the test vectors in
+// vectors.json and invalid_vectors.json, which are verified against amino by
+// TestVectors and TestInvalidVectors.
+// This file is next to result.zig, as Zig doesn't allow importing files
+// outside of the directory of the root source file.
+//
+// Run with: zig test vectors_test.zig
+const std = @import("std");
+const result = @import("result.zig");
+
+const Vector = struct {
+    name: []const u8,
+    @"type": []const u8,
+    binary: []const u8,
+};
+
+fn loadVectors(allocator: std.mem.Allocator) ![]const Vector {
+    return std.json.parseFromSliceLeaky([]const Vector, allocator, @embedFile("vectors.json"), .{});
+}
+
+fn loadInvalidVectors(allocator: std.mem.Allocator) ![]const Vector {
+    return std.json.parseFromSliceLeaky([]const Vector, allocator, @embedFile("invalid_vectors.json"), .{});
+}
+
+fn decodeVector(comptime T: type, allocator: std.mem.Allocator, vectors: []const Vector, name: []const u8) !T {
+    for (vectors) |v| {
+        if (std.mem.eql(u8, v.name, name)) {
+            const bin = try allocator.alloc(u8, v.binary.len / 2);
+            return T.decode(allocator, try std.fmt.hexToBytes(bin, v.binary));
+        }
+    }
+    return error.VectorNotFound;
+}
+
+/// Decodes bin as a T and checks that re-encoding it produces the same bytes.
+fn expectRoundTrip(comptime T: type, allocator: std.mem.Allocator, bin: []const u8) !void {
+    const msg = try T.decode(allocator, bin);
+    try std.testing.expectEqual(bin.len, msg.encodedSize());
+    try std.testing.expectEqualSlices(u8, bin, try msg.encodeAlloc(allocator));
+}
+
+test "vectors round-trip" {
+    var arena = std.heap.ArenaAllocator.init(std.testing.allocator);
+    defer arena.deinit();
+    const allocator = arena.allocator();
+
+    for (try loadVectors(allocator)) |v| {
+        errdefer std.debug.print("vector: {s}\n", .{v.name});
+
+        // decoding and re-encoding the vector should produce the same bytes.
+        const bin = try std.fmt.hexToBytes(try allocator.alloc(u8, v.binary.len / 2), v.binary);
+        if (std.mem.eql(u8, v.@"type", "TestType")) {
+            try expectRoundTrip(result.TestType, allocator, bin);
+        } else if (std.mem.eql(u8, v.@"type", "FloatType")) {
+            try expectRoundTrip(result.FloatType, allocator, bin);
+        } else {
+            return error.UnknownType;
+        }
+    }
+}
+
+/// Checks that decoding bin as a T fails.
+fn expectDecodeError(comptime T: type, allocator: std.mem.Allocator, bin: []const u8) !void {
+    if (T.decode(allocator, bin)) |_| {
+        return error.TestUnexpectedResult;
+    } else |_| {}
+}
+
+test "invalid vectors" {
+    var arena = std.heap.ArenaAllocator.init(std.testing.allocator);
+    defer arena.deinit();
+    const allocator = arena.allocator();
+
+    for (try loadInvalidVectors(allocator)) |v| {
+        errdefer std.debug.print("vector: {s}\n", .{v.name});
+
+        const bin = try std.fmt.hexToBytes(try allocator.alloc(u8, v.binary.len / 2), v.binary);
+        if (std.mem.eql(u8, v.@"type", "TestType")) {
+            try expectDecodeError(result.TestType, allocator, bin);
+        } else if (std.mem.eql(u8, v.@"type", "JSONType")) {
+            try expectDecodeError(result.JSONType, allocator, bin);
+        } else {
+            return error.UnknownType;
+        }
+    }
+}
+
+test "decoded values" {
+    var arena = std.heap.ArenaAllocator.init(std.testing.allocator);
+    defer arena.deinit();
+    const allocator = arena.allocator();
+    const vectors = try loadVectors(allocator);
+
+    const bytes = try decodeVector(result.TestType, allocator, vectors, "bytes");
+    try std.testing.expectEqualSlices(u8, &.{ 1, 2, 3, 4 }, bytes.Bytes);
+
+    const ptr = try decodeVector(result.TestType, allocator, vectors, "ptr_-1337");
+    try std.testing.expectEqual(@as(?i64, -1337), ptr.IntPtr);
+
+    const td = try decodeVector(result.TestType, allocator, vectors, "time_duration");
+    try std.testing.expectEqual(@as(u64, 900000), td.Time.seconds);
+    try std.testing.expectEqual(@as(u64, @bitCast(@as(i64, -1337))), td.Duration.seconds);
+    try std.testing.expectEqual(@as(u32, 1), td.Duration.nanoseconds);
+}
+
+test "invalid UTF-8" {
+    var arena = std.heap.ArenaAllocator.init(std.testing.allocator);
+    defer arena.deinit();
+    const allocator = arena.allocator();
+
+    // like amino, the bytes of strings are kept even if they are not UTF-8.
+    const bin = [_]u8{ 0x12, 0x01, 0xff };
+    const msg = try result.JSONType.decode(allocator, &bin);
+    try std.testing.expectEqualSlices(u8, "\xff", msg.name);
+    // name is the first field written.
+    try std.testing.expectEqualSlices(u8, &bin, (try msg.encodeAlloc(allocator))[0..bin.len]);
+}
+
+test "floats" {
+    var arena = std.heap.ArenaAllocator.init(std.testing.allocator);
+    defer arena.deinit();
+    const allocator = arena.allocator();
+    const vectors = try loadVectors(allocator);
+
+    const values = try decodeVector(result.FloatType, allocator, vectors, "float_values");
+    try std.testing.expectEqual(@as(f64, 3.14159), values.Float);
+    try std.testing.expectEqual(@as(f32, -2.5), values.Float32);
+    try std.testing.expectEqual(@as(?f64, 1e300), values.FloatPtr);
+    try std.testing.expectEqualSlices(f32, &.{ 1, 0, -0.5 }, values.Floats);
+    try std.testing.expectEqual([2]f64{ std.math.inf(f64), -std.math.inf(f64) }, values.FloatArr);
+
+    const nz = try decodeVector(result.FloatType, allocator, vectors, "float_neg_zero");
+    try std.testing.expectEqual(@as(u64, 0x8000_0000_0000_0000), @as(u64, @bitCast(nz.Float)));
+    try std.testing.expectEqual(@as(u32, 0x8000_0000), @as(u32, @bitCast(nz.Float32)));
+    const nan = try decodeVector(result.FloatType, allocator, vectors, "float_nan");
+    try std.testing.expect(std.math.isNan(nan.Float));
+
+    // floats are written even when zero: Float, Float32 and FloatArr.
+    try std.testing.expectEqual(@as(usize, (1 + 8) + (1 + 4) + (2 + 16)), (result.FloatType{}).encodedSize());
+}
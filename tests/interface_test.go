@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+)
+
+// zooMessages returns the messages containing interfaces, together with
+// their original values, which are encoded with amino.
+func zooMessages() map[string]struct {
+	msg  tomtypes.ZooMessage
+	orig tomtypes.Zoo
+} {
+	return map[string]struct {
+		msg  tomtypes.ZooMessage
+		orig tomtypes.Zoo
+	}{
+		"empty": {},
+		"star": {
+			tomtypes.ZooMessage{Star: tomtypes.CatMessage{Name: "tom", Lives: 9}},
+			tomtypes.Zoo{Star: tomtypes.Cat{Name: "tom", Lives: 9}},
+		},
+		"animals": {
+			tomtypes.ZooMessage{Animals: []interface{}{
+				&tomtypes.DogMessage{Name: "rex"},
+				nil,
+				tomtypes.FishMessage{},
+			}},
+			tomtypes.Zoo{Animals: []tomtypes.Animal{
+				&tomtypes.Dog{Name: "rex"},
+				nil,
+				tomtypes.Fish{},
+			}},
+		},
+	}
+}
+
+func TestInterfaceCompatibility(t *testing.T) {
+	zm := zooMessages()
+	for _, name := range sortedMapKeys(zm) {
+		v := zm[name]
+		t.Run(name, func(t *testing.T) {
+			aminoRes, err := amino.Marshal(v.orig)
+			require.NoError(t, err)
+			tominoRes, err := v.msg.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes))
+
+			var dec tomtypes.ZooMessage
+			require.NoError(t, dec.UnmarshalBinary(aminoRes))
+			assert.Equal(t, v.msg, dec)
+
+			aminoJSON, err := amino.MarshalJSON(v.orig)
+			require.NoError(t, err)
+			tominoJSON, err := v.msg.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, string(aminoJSON), string(tominoJSON))
+
+			dec = tomtypes.ZooMessage{}
+			require.NoError(t, dec.UnmarshalJSON(aminoJSON))
+			assert.Equal(t, v.msg, dec)
+		})
+	}
+}
+
+func TestInterfaceBinary(t *testing.T) {
+	const (
+		catURL  = "0a0d" + "2f746f6d74797065732e436174"   // "/tomtypes.Cat"
+		dogURL  = "0a0d" + "2f746f6d74797065732e446f67"   // "/tomtypes.Dog"
+		fishURL = "0a0e" + "2f746f6d74797065732e46697368" // "/tomtypes.Fish"
+	)
+	tt := []struct {
+		name string
+		msg  tomtypes.ZooMessage
+		want string
+	}{
+		{"nil", tomtypes.ZooMessage{}, ""},
+		{
+			"cat",
+			tomtypes.ZooMessage{Star: tomtypes.CatMessage{Name: "tom", Lives: 9}},
+			"0a18" + catURL + "1207" + "0a03746f6d" + "1012",
+		},
+		{
+			// the value of the Any is omitted when it is empty.
+			"fish",
+			tomtypes.ZooMessage{Star: tomtypes.FishMessage{}},
+			"0a10" + fishURL,
+		},
+		{
+			// nil elements are written as empty Anys, and decoded as nil.
+			"animals",
+			tomtypes.ZooMessage{Animals: []interface{}{&tomtypes.DogMessage{Name: "rex"}, nil}},
+			"1216" + dogURL + "1205" + "0a03726578" + "1200",
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.msg.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, hex.EncodeToString(b))
+
+			var dec tomtypes.ZooMessage
+			require.NoError(t, dec.UnmarshalBinary(b))
+			assert.Equal(t, tc.msg, dec)
+		})
+	}
+}
+
+func TestInterfacePointers(t *testing.T) {
+	// Cat is decoded as a value and Dog as a pointer, like amino does, but
+	// both can be encoded either way.
+	msg := tomtypes.ZooMessage{Animals: []interface{}{
+		&tomtypes.CatMessage{Name: "tom"},
+		tomtypes.DogMessage{Name: "rex"},
+	}}
+	b, err := msg.MarshalBinary()
+	require.NoError(t, err)
+
+	var dec tomtypes.ZooMessage
+	require.NoError(t, dec.UnmarshalBinary(b))
+	assert.Equal(t, []interface{}{
+		tomtypes.CatMessage{Name: "tom"},
+		&tomtypes.DogMessage{Name: "rex"},
+	}, dec.Animals)
+
+	_, err = tomtypes.ZooMessage{Star: (*tomtypes.DogMessage)(nil)}.MarshalBinary()
+	assert.ErrorContains(t, err, "nil pointer in interface")
+	_, err = tomtypes.ZooMessage{Star: tomtypes.ZooMessage{}}.MarshalBinary()
+	assert.EqualError(t, err, "tomino: tomtypes.ZooMessage is not an implementation of github.com/thehowl/tomino/tests/golden.Animal")
+	_, err = tomtypes.ZooMessage{Star: 1}.MarshalJSON()
+	assert.EqualError(t, err, "tomino: int is not an implementation of github.com/thehowl/tomino/tests/golden.Animal")
+}
+
+func TestInterfaceBinaryErrors(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"unknown type URL", "0a08" + "0a06" + "2f782e4d7367", `tomino: unknown type URL "/x.Msg" for github.com/thehowl/tomino/tests/golden.Animal`},
+		{"no type URL", "0a02" + "1200", "invalid google.protobuf.Any"},
+		{"other field", "0a12" + "0a0e" + "2f746f6d74797065732e46697368" + "1a00", "invalid google.protobuf.Any"},
+		{"trailing data", "0a13" + "0a0e" + "2f746f6d74797065732e46697368" + "1200" + "00", "trailing data"},
+		{"bad value", "0a13" + "0a0e" + "2f746f6d74797065732e46697368" + "1201" + "ff", "unexpected end of input"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.ZooMessage
+			assert.ErrorContains(t, msg.UnmarshalBinary(b), tc.err)
+		})
+	}
+}
+
+func TestInterfaceJSON(t *testing.T) {
+	msg := tomtypes.ZooMessage{
+		Star: tomtypes.CatMessage{Name: "tom", Lives: 9},
+		Animals: []interface{}{
+			&tomtypes.DogMessage{Name: "rex"},
+			nil,
+			tomtypes.FishMessage{},
+		},
+	}
+	b, err := msg.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"Star":{"@type":"/tomtypes.Cat","Name":"tom","Lives":"9"},`+
+		`"Animals":[{"@type":"/tomtypes.Dog","Name":"rex"},null,{"@type":"/tomtypes.Fish"}]}`, string(b))
+
+	// in the canonical JSON, "@type" is sorted like the other keys.
+	b, err = msg.SignBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"Animals":[{"@type":"/tomtypes.Dog","Name":"rex"},null,{"@type":"/tomtypes.Fish"}],`+
+		`"Star":{"@type":"/tomtypes.Cat","Lives":"9","Name":"tom"}}`, string(b))
+
+	var dec tomtypes.ZooMessage
+	require.NoError(t, dec.UnmarshalJSON(b))
+	assert.Equal(t, msg, dec)
+
+	// "@type" may be anywhere in the object.
+	dec = tomtypes.ZooMessage{}
+	require.NoError(t, dec.UnmarshalJSON([]byte(`{"Star":{"Name":"tom","@type":"/tomtypes.Cat"}}`)))
+	assert.Equal(t, tomtypes.ZooMessage{Star: tomtypes.CatMessage{Name: "tom"}}, dec)
+}
+
+func TestInterfaceJSONErrors(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"unknown type URL", `{"Star":{"@type":"/x.Msg"}}`, `tomino: unknown type URL "/x.Msg" for github.com/thehowl/tomino/tests/golden.Animal`},
+		{"no type URL", `{"Star":{"Name":"tom"}}`, "missing @type"},
+		{"not an object", `{"Star":"/tomtypes.Cat"}`, "invalid JSON"},
+		{"bad field", `{"Star":{"@type":"/tomtypes.Cat","Lives":1}}`, "invalid JSON value"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var msg tomtypes.ZooMessage
+			assert.ErrorContains(t, msg.UnmarshalJSON([]byte(tc.input)), tc.err)
+		})
+	}
+}
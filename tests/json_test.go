@@ -0,0 +1,480 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+)
+
+// jsonCompatMessages returns the messages containing JSON options which are
+// encoded both with amino and tomino, and checked to produce the same output.
+func jsonCompatMessages() map[string]tomtypes.JSONTypeMessage {
+	return map[string]tomtypes.JSONTypeMessage{
+		// Time is 1970-01-01, so it is not omitted.
+		"json_empty": {},
+		"json_zero_time": func() tomtypes.JSONTypeMessage {
+			var v tomtypes.JSONTypeMessage
+			s := int64(zeroTimeSeconds)
+			v.Time.Seconds = uint64(s)
+			return v
+		}(),
+		"json_values": func() tomtypes.JSONTypeMessage {
+			v := tomtypes.JSONTypeMessage{
+				Int8:      -8,
+				Name:      "tomino",
+				Int32:     -32,
+				Int64:     math.MinInt64,
+				Uint:      math.MaxUint64,
+				Bool:      true,
+				Bytes:     []byte("hello"),
+				Strings:   []string{"a", ""},
+				StringPtr: ptrTo("ptr"),
+				SlicePtr:  &[]int16{-1, 1},
+				Ptrs:      []*int32{ptrTo(int32(1))},
+				Arr:       [2]uint16{0, math.MaxUint16},
+			}
+			v.Time.Seconds = 1_700_000_000
+			v.Time.Nanoseconds = 120_000_000
+			v.Duration.Seconds = 90
+			v.Duration.Nanoseconds = 1000
+			v.Struct.B = "b"
+			v.Structs[0].C = true
+			return v
+		}(),
+		// not nil, but still empty.
+		"json_empty_values": {
+			Bytes:     []byte{},
+			Strings:   []string{},
+			StringPtr: ptrTo(""),
+			SlicePtr:  &[]int16{},
+			Ptrs:      []*int32{},
+		},
+		"json_nil_elements": {Ptrs: []*int32{nil, ptrTo(int32(-5)), nil}},
+		"json_escapes": {
+			Name:    "<a href=\"x\">&amp;</a>\u2028\u2029\x00\x1f\b\f\t\n\r\\ é 😀 \xff",
+			Strings: []string{"\u007f", "'"},
+		},
+	}
+}
+
+// zeroTimeSeconds are the seconds since the Unix epoch of the zero time.Time.
+const zeroTimeSeconds = -62135596800
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// toOriginal converts a tomino message into a value of the type it was
+// generated from, so that it can be encoded with amino.
+func toOriginal[T any](msg any) T {
+	var v T
+	convertMessage(reflect.ValueOf(&v).Elem(), reflect.ValueOf(msg))
+	return v
+}
+
+func convertMessage(dst, src reflect.Value) {
+	switch {
+	case dst.Type() == timeType:
+		s, ns := src.FieldByName("Seconds").Uint(), src.FieldByName("Nanoseconds").Uint()
+		dst.Set(reflect.ValueOf(time.Unix(int64(s), int64(ns)).UTC()))
+		return
+	case dst.Type() == durationType:
+		s, ns := src.FieldByName("Seconds").Uint(), src.FieldByName("Nanoseconds").Uint()
+		dst.SetInt(int64(s)*1e9 + int64(int32(ns)))
+		return
+	case src.Type().ConvertibleTo(dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+		return
+	}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if !src.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+			convertMessage(dst.Elem(), src.Elem())
+		}
+	case reflect.Slice:
+		if !src.IsNil() {
+			dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+			for i := 0; i < src.Len(); i++ {
+				convertMessage(dst.Index(i), src.Index(i))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			convertMessage(dst.Index(i), src.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			f := dst.Type().Field(i)
+			if sf := src.FieldByName(f.Name); f.IsExported() && sf.IsValid() {
+				convertMessage(dst.Field(i), sf)
+			}
+		}
+	default:
+		panic(fmt.Sprintf("cannot convert %v to %v", src.Type(), dst.Type()))
+	}
+}
+
+// jsonMessage is a pointer to a message with JSON encoders.
+type jsonMessage[M any] interface {
+	*M
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// testJSONCompatibility checks that msg is encoded in JSON like amino encodes
+// its original type O, and decoded without errors by both. If roundTrip is
+// set, the decoded values are also checked to be re-encoded to the same JSON.
+func testJSONCompatibility[O, M any, P jsonMessage[M]](t *testing.T, msg M, roundTrip bool) {
+	t.Helper()
+
+	orig := toOriginal[O](msg)
+	aminoRes, aminoErr := amino.MarshalJSON(orig)
+	tominoRes, err := P(&msg).MarshalJSON()
+	if aminoErr != nil {
+		// amino can't encode NaN and infinities.
+		assert.Error(t, err, "amino error: %v", aminoErr)
+		return
+	}
+	require.NoError(t, err)
+	require.Equal(t, string(aminoRes), string(tominoRes))
+
+	var aminoDec O
+	var tominoDec M
+	require.NoError(t, amino.UnmarshalJSON(aminoRes, &aminoDec))
+	require.NoError(t, P(&tominoDec).UnmarshalJSON(aminoRes))
+	if !roundTrip {
+		return
+	}
+
+	// the decoded messages are compared by re-encoding them, as the values
+	// of time.Time may have different locations.
+	reenc, err := P(&tominoDec).MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(aminoRes), string(reenc))
+	aminoReenc, err := amino.MarshalJSON(aminoDec)
+	require.NoError(t, err)
+	tominoReenc, err := amino.MarshalJSON(toOriginal[O](tominoDec))
+	require.NoError(t, err)
+	assert.Equal(t, string(aminoReenc), string(tominoReenc))
+}
+
+func TestJSONCompatibility(t *testing.T) {
+	tm := compatMessages()
+	for _, name := range sortedMapKeys(tm) {
+		t.Run(name, func(t *testing.T) {
+			testJSONCompatibility[tomtypes.TestType](t, tm[name], true)
+		})
+	}
+	fm := floatCompatMessages()
+	for _, name := range sortedMapKeys(fm) {
+		t.Run(name, func(t *testing.T) {
+			testJSONCompatibility[tomtypes.FloatType](t, fm[name], true)
+		})
+	}
+	// Not every message is stable through a round trip, like in amino:
+	// empty slices are decoded as nil, omitted times are decoded as 1970
+	// rather than as the zero time, and invalid UTF-8 is replaced.
+	unstable := map[string]bool{
+		"json_empty_values": true,
+		"json_escapes":      true,
+		"json_zero_time":    true,
+	}
+	jm := jsonCompatMessages()
+	for _, name := range sortedMapKeys(jm) {
+		t.Run(name, func(t *testing.T) {
+			testJSONCompatibility[tomtypes.JSONType](t, jm[name], !unstable[name])
+		})
+	}
+}
+
+func TestJSONValues(t *testing.T) {
+	tm := compatMessages()
+	jm := jsonCompatMessages()
+	tt := []struct {
+		name string
+		msg  json.Marshaler
+		want string
+	}{
+		{
+			"time_duration", tm["time_duration"],
+			`{"Time":"1970-01-11T10:00:00Z","Duration":"-1336.999999999s","FixedUint":"0","Byte":0,` +
+				`"Bytes":null,"ByteArr":null,"ZeroArr":"","IntPtr":null,"Slice":null}`,
+		},
+		{
+			"slice", tomtypes.TestTypeMessage{
+				Bytes:   []byte{1, 2, 3, 4},
+				ByteArr: &[4]byte{0xff},
+				IntPtr:  ptrTo(-1),
+				Slice:   tm["slice"].Slice,
+			},
+			`{"Time":"1970-01-01T00:00:00Z","Duration":"0s","FixedUint":"0","Byte":0,` +
+				`"Bytes":"AQIDBA==","ByteArr":"/wAAAA==","ZeroArr":"","IntPtr":"-1",` +
+				`"Slice":[{"A":"1","B":"5"},{"A":"0","B":"4"},{"A":"1337","B":"0"}]}`,
+		},
+		{
+			"json_empty", jm["json_empty"],
+			`{"name":"","int32":0,"ptrs":null,"time":"1970-01-01T00:00:00Z"}`,
+		},
+		{"json_zero_time", jm["json_zero_time"], `{"name":"","int32":0,"ptrs":null}`},
+		{
+			"json_values", jm["json_values"],
+			`{"int8":-8,"name":"tomino","int32":-32,"int64":"-9223372036854775808",` +
+				`"Uint":"18446744073709551615","bool":true,"bytes":"aGVsbG8=","strings":["a",""],` +
+				`"string_ptr":"ptr","slice_ptr":[-1,1],"ptrs":[1],"arr":[0,65535],` +
+				`"time":"2023-11-14T22:13:20.120Z","duration":"90.000001s",` +
+				`"struct":{"A":0,"B":"b"},"structs":[{"C":true}]}`,
+		},
+		{"json_empty_values", jm["json_empty_values"], `{"name":"","int32":0,"ptrs":[],"time":"1970-01-01T00:00:00Z"}`},
+		{"json_nil_elements", jm["json_nil_elements"], `{"name":"","int32":0,"ptrs":[null,-5,null],"time":"1970-01-01T00:00:00Z"}`},
+		{
+			"float_values", tomtypes.FloatTypeMessage{
+				Float:     0.000001,
+				Float32:   0.1,
+				FloatPtr:  ptrTo(1e21),
+				Floats:    []float32{1e-7, 123456789},
+				FloatArr:  [2]float64{math.Copysign(0, -1), 1e20},
+				FloatPtrs: []*float64{nil, ptrTo(-2.5e-10)},
+			},
+			`{"Float":0.000001,"Float32":0.1,"FloatPtr":1e+21,"Floats":[1e-7,123456790],` +
+				`"FloatArr":[-0,100000000000000000000],"FloatPtrs":[null,-2.5e-10]}`,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.msg.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(b))
+			assert.True(t, json.Valid(b))
+		})
+	}
+}
+
+// TestJSONStrings checks that strings are encoded and decoded like
+// encoding/json does, which is what amino uses for strings.
+func TestJSONStrings(t *testing.T) {
+	ss := []string{
+		"", "hello", `"quoted" \ backslash /`, "<script>&</script>",
+		"\x00\x01\x1f\x7f\b\f\n\r\t", "\u2028\u2029", "é 日本 😀",
+		"invalid \xff \xc3 utf-8 \xed\xa0\x80",
+	}
+	for _, s := range ss {
+		t.Run(fmt.Sprintf("%q", s), func(t *testing.T) {
+			want, err := json.Marshal(s)
+			require.NoError(t, err)
+			b, err := tomtypes.JSONTypeMessage{Name: s}.MarshalJSON()
+			require.NoError(t, err)
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(b, &fields))
+			assert.Equal(t, string(want), string(fields["name"]))
+
+			var dec tomtypes.JSONTypeMessage
+			require.NoError(t, dec.UnmarshalJSON(b))
+			var jsonDec string
+			require.NoError(t, json.Unmarshal(want, &jsonDec))
+			assert.Equal(t, jsonDec, dec.Name)
+		})
+	}
+
+	// escapes which are not produced by the encoder.
+	inputs := []string{
+		`"é日😀"`, `"\/\"\\\b\f\n\r\t"`,
+		`"lone \ud83d surrogate"`, `"\ud83dA invalid pair"`, `"\ude00 low"`,
+		"\"raw \xff invalid\"",
+	}
+	for _, in := range inputs {
+		t.Run(in, func(t *testing.T) {
+			var want string
+			require.NoError(t, json.Unmarshal([]byte(in), &want))
+			var dec tomtypes.JSONTypeMessage
+			require.NoError(t, dec.UnmarshalJSON([]byte(`{"name":`+in+`}`)))
+			assert.Equal(t, want, dec.Name)
+		})
+	}
+}
+
+// TestJSONFloats checks that floating points are formatted like
+// encoding/json does, which is what amino uses for floating points.
+func TestJSONFloats(t *testing.T) {
+	fs := []float64{
+		0, math.Copysign(0, -1), 1, -1.5, 0.1, 1e-6, 9.99e-7, 1e20, 1e21, 123456789.125,
+		math.MaxFloat64, math.SmallestNonzeroFloat64, math.MaxFloat32, 1e-45, 3.4e38,
+	}
+	for _, f := range fs {
+		t.Run(fmt.Sprint(f), func(t *testing.T) {
+			f32 := float32(f)
+			if math.IsInf(float64(f32), 0) {
+				// not representable as a float32.
+				f32 = math.MaxFloat32
+			}
+			msg := tomtypes.FloatTypeMessage{Float: f, Float32: f32}
+			b, err := msg.MarshalJSON()
+			require.NoError(t, err)
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(b, &fields))
+
+			want64, err := json.Marshal(f)
+			require.NoError(t, err)
+			assert.Equal(t, string(want64), string(fields["Float"]))
+			want32, err := json.Marshal(f32)
+			require.NoError(t, err)
+			assert.Equal(t, string(want32), string(fields["Float32"]))
+
+			var dec tomtypes.FloatTypeMessage
+			require.NoError(t, dec.UnmarshalJSON(b))
+			assert.Equal(t, math.Float64bits(f), math.Float64bits(dec.Float))
+			assert.Equal(t, math.Float32bits(f32), math.Float32bits(dec.Float32))
+		})
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := tomtypes.FloatTypeMessage{Float: f}.MarshalJSON()
+		assert.Error(t, err)
+		_, err = tomtypes.FloatTypeMessage{Floats: []float32{float32(f)}}.MarshalJSON()
+		assert.Error(t, err)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	// Not every message is stable through a round trip, like in amino:
+	// empty slices are decoded as nil, omitted times are decoded as 1970
+	// rather than as the zero time, and invalid UTF-8 is replaced.
+	jm := jsonCompatMessages()
+	for _, name := range []string{"json_values", "json_nil_elements"} {
+		t.Run(name, func(t *testing.T) {
+			b, err := jm[name].MarshalJSON()
+			require.NoError(t, err)
+			var dec tomtypes.JSONTypeMessage
+			require.NoError(t, dec.UnmarshalJSON(b))
+			reenc, err := dec.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, string(b), string(reenc))
+		})
+	}
+
+	// omitted or empty values are decoded as nil, except for the elements of
+	// non-empty slices.
+	var dec tomtypes.JSONTypeMessage
+	require.NoError(t, dec.UnmarshalJSON([]byte(`{"bytes":"","strings":[],"ptrs":[null]}`)))
+	assert.Nil(t, dec.Bytes)
+	assert.Nil(t, dec.Strings)
+	assert.Equal(t, []*int32{nil}, dec.Ptrs)
+}
+
+// sortJSON sorts the keys of the JSON objects in b, like MustSortJSON in tm2.
+func sortJSON(b []byte) ([]byte, error) {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+type signer interface {
+	MarshalJSON() ([]byte, error)
+	SignBytes() ([]byte, error)
+}
+
+func testSignBytes(t *testing.T, msg signer) {
+	t.Helper()
+	b, err := msg.MarshalJSON()
+	sb, sbErr := msg.SignBytes()
+	if err != nil {
+		assert.Equal(t, err, sbErr)
+		return
+	}
+	require.NoError(t, sbErr)
+	want, err := sortJSON(b)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(sb))
+}
+
+func TestSignBytes(t *testing.T) {
+	tm := compatMessages()
+	for _, name := range sortedMapKeys(tm) {
+		t.Run(name, func(t *testing.T) { testSignBytes(t, tm[name]) })
+	}
+	fm := floatCompatMessages()
+	for _, name := range sortedMapKeys(fm) {
+		t.Run(name, func(t *testing.T) { testSignBytes(t, fm[name]) })
+	}
+	jm := jsonCompatMessages()
+	for _, name := range sortedMapKeys(jm) {
+		t.Run(name, func(t *testing.T) { testSignBytes(t, jm[name]) })
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var dec tomtypes.TestTypeMessage
+	input := ` {
+		"Unknown": {"a": [1, 2.5e3, "x", true, false, null, {}]},
+		"Byte": 255, "Byte": 7,
+		"Slice": [{"A": "1"}, null, {"B": "-2", "A": null}],
+		"IntPtr": "-3",
+		"Time": "2023-11-14T22:13:20.000000001Z",
+		"Duration": "-0.5s",
+		"Bytes": null
+	} `
+	require.NoError(t, dec.UnmarshalJSON([]byte(input)))
+	assert.Equal(t, uint8(7), dec.Byte)
+	require.Len(t, dec.Slice, 3)
+	assert.Equal(t, 1, dec.Slice[0].A)
+	assert.Equal(t, 0, dec.Slice[1].A)
+	assert.Equal(t, 0, dec.Slice[2].A)
+	assert.Equal(t, -2, dec.Slice[2].B)
+	assert.Equal(t, ptrTo(-3), dec.IntPtr)
+	assert.Equal(t, uint64(1_700_000_000), dec.Time.Seconds)
+	assert.Equal(t, uint32(1), dec.Time.Nanoseconds)
+	// durations are decoded like amino encodes them in binary.
+	assert.Equal(t, uint64(0), dec.Duration.Seconds)
+	assert.Equal(t, int32(-500_000_000), int32(dec.Duration.Nanoseconds))
+	assert.Nil(t, dec.Bytes)
+
+	require.NoError(t, dec.UnmarshalJSON([]byte("null")))
+	assert.Equal(t, tomtypes.TestTypeMessage{}, dec)
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"empty", ``, "unexpected end of input"},
+		{"truncated", `{"Byte":1`, "unexpected end of input"},
+		{"trailing data", `{} {}`, "invalid JSON"},
+		{"trailing comma", `{"Byte":1,}`, "invalid JSON"},
+		{"not an object", `[]`, "invalid JSON value"},
+		{"unquoted int64", `{"FixedUint":1}`, "invalid JSON value"},
+		{"quoted uint8", `{"Byte":"1"}`, "invalid JSON value"},
+		{"fractional int", `{"Byte":1.5}`, "invalid JSON"},
+		{"uint8 overflow", `{"Byte":256}`, "overflows"},
+		{"negative uint", `{"FixedUint":"-1"}`, "overflows"},
+		{"array length", `{"ByteArr":"AQID"}`, "invalid array length"},
+		{"bad base64", `{"Bytes":"!"}`, "invalid JSON value"},
+		{"time without Z", `{"Time":"2023-11-14T22:13:20+01:00"}`, "invalid JSON value"},
+		{"duration without s", `{"Duration":"1.5"}`, "invalid JSON value"},
+		{"duration overflow", `{"Duration":"9223372037s"}`, "overflows"},
+		{"control character", "{\"Unknown\":\"\x01\"}", "invalid JSON"},
+		{"bad escape", `{"Unknown":"\x"}`, "invalid JSON"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var msg tomtypes.TestTypeMessage
+			assert.ErrorContains(t, msg.UnmarshalJSON([]byte(tc.input)), tc.err)
+		})
+	}
+
+	var msg tomtypes.JSONTypeMessage
+	assert.ErrorContains(t, msg.UnmarshalJSON([]byte(`{"arr":[1]}`)), "invalid array length")
+	assert.ErrorContains(t, msg.UnmarshalJSON([]byte(`{"arr":[1,2,3]}`)), "invalid array length")
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+	"github.com/thehowl/tomino/tests/golden/purego"
+)
+
+func TestUnmarshalBinaryNoCopy(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var want, got tomtypes.TestTypeMessage
+			require.NoError(t, want.UnmarshalBinary(b))
+			require.NoError(t, got.UnmarshalBinaryNoCopy(b))
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestUnmarshalBinaryNoCopyAliasing(t *testing.T) {
+	b, err := tomtypes.TestTypeMessage{Bytes: []byte("abc"), IntPtr: ptrTo(5)}.MarshalBinary()
+	require.NoError(t, err)
+	var msg tomtypes.TestTypeMessage
+	require.NoError(t, msg.UnmarshalBinaryNoCopy(b))
+	require.Equal(t, []byte("abc"), msg.Bytes)
+	assert.Equal(t, len(msg.Bytes), cap(msg.Bytes))
+
+	// appending must not overwrite the following fields.
+	_ = append(msg.Bytes, 0xff)
+	var dec tomtypes.TestTypeMessage
+	require.NoError(t, dec.UnmarshalBinary(b))
+	assert.Equal(t, ptrTo(5), dec.IntPtr)
+
+	// changes to the input are visible in the message.
+	b[bytes.Index(b, []byte("abc"))] = 'x'
+	assert.Equal(t, []byte("xbc"), msg.Bytes)
+}
+
+func TestUnmarshalBinaryNoCopyStrings(t *testing.T) {
+	u := tomtypes.URLMessage{Scheme: "https", Host: "example.com"}
+	b, err := u.MarshalBinary()
+	require.NoError(t, err)
+
+	var msg tomtypes.URLMessage
+	require.NoError(t, msg.UnmarshalBinaryNoCopy(b))
+	assert.Equal(t, u, msg)
+	var pmsg purego.URLMessage
+	require.NoError(t, pmsg.UnmarshalBinaryNoCopy(b))
+	assert.Equal(t, purego.URLMessage(u), pmsg)
+
+	// the strings alias b, except with -purego where they are copied.
+	copy(b[len(b)-len("example.com"):], "EXAMPLE.COM")
+	assert.Equal(t, "EXAMPLE.COM", msg.Host)
+	assert.Equal(t, "example.com", pmsg.Host)
+}
@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thehowl/tomino/tests/golden/purego"
+)
+
+// TestPureGo checks that the code generated with -purego, which doesn't use
+// unsafe, produces the same encoding as the default output.
+func TestPureGo(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			want, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			// the messages have identical underlying types.
+			pv := purego.TestTypeMessage(v)
+			got, err := pv.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			var dec purego.TestTypeMessage
+			require.NoError(t, dec.UnmarshalBinary(want))
+			got, err = dec.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
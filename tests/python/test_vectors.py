@@ -0,0 +1,136 @@
+"""Checks the Python output in golden/result.py against the test vectors in
+golden/vectors.json and golden/invalid_vectors.json, which are verified
+against amino by TestVectors and TestInvalidVectors.
+
+Run with: python3 -m unittest discover -s python (in the tests directory)
+"""
+
+import datetime
+import json
+import math
+import pathlib
+import sys
+import unittest
+
+GOLDEN = pathlib.Path(__file__).resolve().parent.parent / "golden"
+sys.path.insert(0, str(GOLDEN))
+
+from result import (  # noqa: E402
+    DecodeError,
+    FloatType,
+    JSONType,
+    TestType,
+    decode_float_type,
+    decode_json_type,
+    decode_test_type,
+    encode_float_type,
+    encode_json_type,
+    encode_test_type,
+)
+
+VECTORS = json.loads((GOLDEN / "vectors.json").read_text())
+INVALID_VECTORS = json.loads((GOLDEN / "invalid_vectors.json").read_text())
+
+# vectors which don't round-trip, as Python's datetime types only have a
+# precision of microseconds.
+SUB_MICROSECOND = {"time_duration"}
+
+# the encoders and decoders of each message type in the vectors.
+CODECS = {
+    "TestType": (encode_test_type, decode_test_type),
+    "FloatType": (encode_float_type, decode_float_type),
+}
+
+
+def decode_vector(name: str):
+    v = next(v for v in VECTORS if v["name"] == name)
+    return CODECS[v["type"]][1](bytes.fromhex(v["binary"]))
+
+
+class TestVectors(unittest.TestCase):
+    def test_round_trip(self):
+        for v in VECTORS:
+            if v["name"] in SUB_MICROSECOND:
+                continue
+            with self.subTest(v["name"]):
+                encode, decode = CODECS[v["type"]]
+                # decoding and re-encoding the vector should produce the
+                # same bytes.
+                msg = decode(bytes.fromhex(v["binary"]))
+                self.assertEqual(encode(msg).hex(), v["binary"])
+
+    def test_invalid_vectors(self):
+        decoders = {"TestType": decode_test_type, "JSONType": decode_json_type}
+        for v in INVALID_VECTORS:
+            with self.subTest(v["name"]):
+                with self.assertRaises(DecodeError):
+                    decoders[v["type"]](bytes.fromhex(v["binary"]))
+
+    def test_decoded_values(self):
+        self.assertEqual(decode_vector("bytes").bytes_, b"\x01\x02\x03\x04")
+        self.assertEqual(decode_vector("ptr_-1337").int_ptr, -1337)
+
+        msg = decode_vector("time_duration")
+        self.assertEqual(msg.time, datetime.datetime(1970, 1, 11, 10, tzinfo=datetime.timezone.utc))
+        # -1337s + 1ns, truncated to microseconds.
+        self.assertEqual(msg.duration, datetime.timedelta(seconds=-1336, microseconds=-999999))
+        # the encoding of the truncated value: -1336s - 999999000ns.
+        self.assertEqual(encode_test_type(msg).hex(), "0a0408a0f736121108c8f5ffffffffffffff0110e8f394a30c")
+
+    def test_floats(self):
+        msg = decode_vector("float_values")
+        self.assertEqual(msg.float_, 3.14159)
+        self.assertEqual(msg.float32, -2.5)
+        self.assertEqual(msg.float_ptr, 1e300)
+        self.assertEqual(msg.floats, [1.0, 0.0, -0.5])
+        self.assertEqual(msg.float_arr, [math.inf, -math.inf])
+
+        msg = decode_vector("float_neg_zero")
+        self.assertEqual(math.copysign(1.0, msg.float_), -1.0)
+        self.assertEqual(math.copysign(1.0, msg.float32), -1.0)
+        self.assertTrue(math.isnan(decode_vector("float_nan").float_))
+
+        # floats are written even when zero, and nil elements as zero.
+        v = next(v for v in VECTORS if v["name"] == "float_empty")
+        self.assertEqual(encode_float_type(FloatType()).hex(), v["binary"])
+        self.assertEqual(encode_float_type(FloatType(float_ptrs=[None])).hex(), v["binary"] + "32080000000000000000")
+
+    def test_encode_values(self):
+        msg = TestType(
+            time=datetime.datetime(1970, 1, 11, 10, tzinfo=datetime.timezone.utc),
+            byte_arr=b"\x01\x02\x03\x04",
+            int_ptr=0,
+        )
+        self.assertEqual(encode_test_type(msg).hex(), "0a0408a0f736320401020304")
+        with self.assertRaises(ValueError):
+            # ByteArr must have a length of 4.
+            encode_test_type(TestType(byte_arr=b"\x01"))
+        with self.assertRaises(ValueError):
+            encode_test_type(TestType(byte=256))
+
+    def test_invalid_utf8(self):
+        # amino accepts any bytes in strings, but str can't hold them.
+        with self.assertRaisesRegex(DecodeError, "invalid UTF-8 string"):
+            decode_json_type(bytes.fromhex("1201ff"))
+        with self.assertRaisesRegex(ValueError, "unpaired surrogates"):
+            encode_json_type(JSONType(name="\ud800"))
+
+    def test_decode_errors(self):
+        cases = {
+            # truncated varint.
+            "20": "unexpected end of input",
+            # ByteArr with a length other than 4.
+            "320100": "expected array of length 4",
+            # Byte with the fixed64 wire type.
+            "210000000000000000": "invalid wire type",
+            # Byte overflowing 8 bits.
+            "208002": "overflows",
+        }
+        for hex_, msg in cases.items():
+            with self.subTest(hex_):
+                with self.assertRaisesRegex(DecodeError, msg):
+                    decode_test_type(bytes.fromhex(hex_))
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+)
+
+func TestRegistryCompatibility(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			aminoRes, err := amino.Marshal(v)
+			require.NoError(t, err)
+			assert.Equal(t, aminoRes, tomtypes.MustMarshal(v))
+			assert.Equal(t, aminoRes, tomtypes.MustMarshal(&v))
+
+			var dec tomtypes.TestTypeMessage
+			require.NoError(t, tomtypes.Unmarshal(aminoRes, &dec))
+			assert.Equal(t, aminoRes, tomtypes.MustMarshal(dec))
+
+			aminoSized, err := amino.MarshalSized(v)
+			require.NoError(t, err)
+			assert.Equal(t, aminoSized, tomtypes.MustMarshalSized(v))
+			dec = tomtypes.TestTypeMessage{}
+			tomtypes.MustUnmarshalSized(aminoSized, &dec)
+			assert.Equal(t, aminoSized, tomtypes.MustMarshalSized(&dec))
+
+			aminoJSON, err := amino.MarshalJSON(v)
+			require.NoError(t, err)
+			assert.Equal(t, string(aminoJSON), string(tomtypes.MustMarshalJSON(v)))
+			dec = tomtypes.TestTypeMessage{}
+			tomtypes.MustUnmarshalJSON(aminoJSON, &dec)
+			assert.Equal(t, string(aminoJSON), string(tomtypes.MustMarshalJSON(dec)))
+
+			// the Any functions need the type registered with amino, so they
+			// are given the original type.
+			orig := toOriginal[tomtypes.TestType](v)
+			aminoAny, err := amino.MarshalAny(orig)
+			require.NoError(t, err)
+			assert.Equal(t, aminoAny, tomtypes.MustMarshalAny(v))
+			dec = tomtypes.TestTypeMessage{}
+			tomtypes.MustUnmarshalAny(aminoAny, &dec)
+			assert.Equal(t, aminoAny, tomtypes.MustMarshalAny(&dec))
+
+			aminoAnySized, err := amino.MarshalAnySized(orig)
+			require.NoError(t, err)
+			assert.Equal(t, aminoAnySized, tomtypes.MustMarshalAnySized(v))
+
+			aminoJSONAny, err := amino.MarshalJSONAny(orig)
+			require.NoError(t, err)
+			assert.Equal(t, string(aminoJSONAny), string(tomtypes.MustMarshalJSONAny(v)))
+		})
+	}
+}
+
+func TestRegistryAny(t *testing.T) {
+	cat := tomtypes.CatMessage{Name: "tom", Lives: 9}
+	b := tomtypes.MustMarshalAny(&cat)
+
+	// the Any is decoded as the message with its type URL.
+	var v any
+	require.NoError(t, tomtypes.UnmarshalAny(b, &v))
+	assert.Equal(t, cat, v)
+	var dec tomtypes.CatMessage
+	require.NoError(t, tomtypes.UnmarshalAny(b, &dec))
+	assert.Equal(t, cat, dec)
+
+	var dog tomtypes.DogMessage
+	assert.EqualError(t, tomtypes.UnmarshalAny(b, &dog),
+		`tomino: unknown type URL "/tomtypes.Cat" for github.com/thehowl/tomino/tests/golden.Dog`)
+	assert.EqualError(t, tomtypes.UnmarshalAny([]byte{0x0a, 0x02, 0x2f, 0x78}, &v),
+		`tomino: unregistered type URL "/x"`)
+	assert.ErrorContains(t, tomtypes.UnmarshalAny(b, (*any)(nil)), "nil pointer")
+
+	assert.Equal(t, `{"@type":"/tomtypes.Cat","Name":"tom","Lives":"9"}`,
+		string(tomtypes.MustMarshalJSONAny(cat)))
+}
+
+func TestRegistryErrors(t *testing.T) {
+	type unregistered struct{}
+
+	_, err := tomtypes.Marshal(unregistered{})
+	assert.ErrorContains(t, err, "unregistered type tests.unregistered")
+	_, err = tomtypes.MarshalJSON(1)
+	assert.ErrorContains(t, err, "unregistered type int")
+	_, err = tomtypes.Marshal((*tomtypes.TestTypeMessage)(nil))
+	assert.ErrorContains(t, err, "nil pointer")
+	// the original types are not registered, only their messages.
+	_, err = tomtypes.MarshalAny(tomtypes.Cat{})
+	assert.ErrorContains(t, err, "unregistered type tomtypes.Cat")
+	_, err = tomtypes.MarshalJSONAny(&tomtypes.Dog{})
+	assert.ErrorContains(t, err, "unregistered type *tomtypes.Dog")
+
+	// decoding needs a pointer.
+	var msg tomtypes.TestTypeMessage
+	assert.ErrorContains(t, tomtypes.Unmarshal(nil, msg), "unregistered type")
+	assert.ErrorContains(t, tomtypes.UnmarshalJSON([]byte("{}"), msg), "unregistered type")
+	assert.ErrorContains(t, tomtypes.UnmarshalSized([]byte{0}, (*tomtypes.TestTypeMessage)(nil)), "nil pointer")
+	assert.Panics(t, func() { tomtypes.MustUnmarshal([]byte{0xff}, &msg) })
+}
+
+func TestMarshalJSONIndent(t *testing.T) {
+	b, err := tomtypes.MarshalJSONIndent(tomtypes.FloatTypeMessage{Floats: []float32{1}}, "", "  ")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "Float": 0,
+  "Float32": 0,
+  "FloatPtr": null,
+  "Floats": [
+    1
+  ],
+  "FloatArr": [
+    0,
+    0
+  ],
+  "FloatPtrs": null
+}`, string(b))
+}
@@ -0,0 +1,8 @@
+//! Compiles the Rust output in golden/result.rs as a no_std module.
+#![no_std]
+#![deny(warnings)]
+
+extern crate alloc;
+
+#[path = "../../golden/result.rs"]
+pub mod result;
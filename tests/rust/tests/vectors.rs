@@ -0,0 +1,134 @@
+//! Checks the Rust output in golden/result.rs against the test vectors in
+//! golden/vectors.json and golden/invalid_vectors.json, which are verified
+//! against amino by TestVectors and TestInvalidVectors.
+//!
+//! Run with: cargo test
+
+use tomino_vectors::result::{DecodeError, FloatType, JSONType, TestType, Timestamp};
+
+struct Vector {
+    name: String,
+    typ: String,
+    binary: Vec<u8>,
+}
+
+/// Returns the value of the string property key in a line of vectors.json,
+/// which has one property per line.
+fn property<'a>(line: &'a str, key: &str) -> Option<&'a str> {
+    let rest = line.trim().strip_prefix(&format!("\"{key}\": \""))?;
+    Some(rest.trim_end_matches(',').strip_suffix('"')?)
+}
+
+fn from_hex(s: &str) -> Vec<u8> {
+    (0..s.len())
+        .step_by(2)
+        .map(|i| u8::from_str_radix(&s[i..i + 2], 16).unwrap())
+        .collect()
+}
+
+fn vectors() -> Vec<Vector> {
+    parse_vectors(include_str!("../../golden/vectors.json"))
+}
+
+fn invalid_vectors() -> Vec<Vector> {
+    parse_vectors(include_str!("../../golden/invalid_vectors.json"))
+}
+
+fn parse_vectors(data: &str) -> Vec<Vector> {
+    let mut vectors = Vec::new();
+    let (mut name, mut typ) = (None, None);
+    for line in data.lines() {
+        if let Some(v) = property(line, "name") {
+            name = Some(v.to_string());
+        } else if let Some(v) = property(line, "type") {
+            typ = Some(v.to_string());
+        } else if let Some(v) = property(line, "binary") {
+            vectors.push(Vector {
+                name: name.take().expect("name before binary"),
+                typ: typ.take().expect("type before binary"),
+                binary: from_hex(v),
+            });
+        }
+    }
+    assert!(!vectors.is_empty(), "no vectors found");
+    vectors
+}
+
+fn find_vector(name: &str) -> Vector {
+    vectors().into_iter().find(|v| v.name == name).unwrap()
+}
+
+fn decode_vector(name: &str) -> TestType {
+    TestType::decode(&find_vector(name).binary).unwrap()
+}
+
+fn decode_float_vector(name: &str) -> FloatType {
+    FloatType::decode(&find_vector(name).binary).unwrap()
+}
+
+#[test]
+fn vectors_round_trip() {
+    for v in vectors() {
+        // decoding and re-encoding the vector should produce the same bytes.
+        let encoded = match v.typ.as_str() {
+            "TestType" => TestType::decode(&v.binary).map(|msg| msg.encode_to_vec()),
+            "FloatType" => FloatType::decode(&v.binary).map(|msg| msg.encode_to_vec()),
+            typ => panic!("{}: unknown type {typ}", v.name),
+        };
+        assert_eq!(encoded.unwrap_or_else(|e| panic!("{}: {e}", v.name)), v.binary, "{}", v.name);
+    }
+}
+
+#[test]
+fn invalid_vectors_fail() {
+    for v in invalid_vectors() {
+        let ok = match v.typ.as_str() {
+            "TestType" => TestType::decode(&v.binary).is_ok(),
+            "JSONType" => JSONType::decode(&v.binary).is_ok(),
+            typ => panic!("{}: unknown type {typ}", v.name),
+        };
+        assert!(!ok, "{}: decoded an invalid vector", v.name);
+    }
+}
+
+#[test]
+fn decoded_values() {
+    assert_eq!(decode_vector("bytes").bytes, [1, 2, 3, 4]);
+    assert_eq!(decode_vector("ptr_-1337").int_ptr, Some(-1337));
+
+    let msg = decode_vector("time_duration");
+    assert_eq!(msg.time, Timestamp { seconds: 900000, nanos: 0 });
+    assert_eq!(msg.duration, Timestamp { seconds: -1337, nanos: 1 });
+}
+
+#[test]
+fn floats() {
+    let msg = decode_float_vector("float_values");
+    assert_eq!(msg.float, 3.14159);
+    assert_eq!(msg.float32, -2.5);
+    assert_eq!(msg.float_ptr, Some(1e300));
+    assert_eq!(msg.floats, [1.0, 0.0, -0.5]);
+    assert_eq!(msg.float_arr, [f64::INFINITY, f64::NEG_INFINITY]);
+
+    let msg = decode_float_vector("float_neg_zero");
+    assert_eq!(msg.float.to_bits(), (-0.0f64).to_bits());
+    assert_eq!(msg.float32.to_bits(), (-0.0f32).to_bits());
+    assert!(decode_float_vector("float_nan").float.is_nan());
+
+    // floats are written even when zero.
+    assert_eq!(FloatType::default().encode_to_vec(), find_vector("float_empty").binary);
+}
+
+#[test]
+fn decode_errors() {
+    // truncated varint.
+    assert_eq!(TestType::decode(&[0x20]), Err(DecodeError::UnexpectedEof));
+    // ByteArr with a length other than 4.
+    assert_eq!(TestType::decode(&[0x32, 0x01, 0x00]), Err(DecodeError::InvalidLength));
+    // Byte with the fixed64 wire type.
+    assert_eq!(TestType::decode(&[0x21, 0, 0, 0, 0, 0, 0, 0, 0]), Err(DecodeError::InvalidWireType));
+    // Bool as 2.
+    assert_eq!(JSONType::decode(&[0x30, 0x02]), Err(DecodeError::InvalidBool));
+    // amino accepts any bytes in strings, but String can't hold them.
+    assert_eq!(JSONType::decode(&[0x12, 0x01, 0xff]), Err(DecodeError::InvalidUtf8));
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+	"github.com/thehowl/tomino/tests/golden/unknown"
+)
+
+func TestStream(t *testing.T) {
+	tm := compatMessages()
+	names := sortedMapKeys(tm)
+
+	var stream, want bytes.Buffer
+	for _, name := range names {
+		n, err := tm[name].WriteTo(&stream)
+		require.NoError(t, err)
+		aminoRes, err := amino.MarshalSized(tm[name])
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(aminoRes)), n)
+		want.Write(aminoRes)
+	}
+	require.Equal(t, hex.EncodeToString(want.Bytes()), hex.EncodeToString(stream.Bytes()))
+
+	readers := map[string]func() io.Reader{
+		// the reader must not be read past the end of each message.
+		"reader":   func() io.Reader { return bytes.NewReader(stream.Bytes()) },
+		"one_byte": func() io.Reader { return iotest.OneByteReader(bytes.NewReader(stream.Bytes())) },
+	}
+	for _, rname := range sortedMapKeys(readers) {
+		t.Run(rname, func(t *testing.T) {
+			r := readers[rname]()
+			var total int64
+			for _, name := range names {
+				var dec tomtypes.TestTypeMessage
+				n, err := dec.ReadMessageFrom(r)
+				require.NoError(t, err, name)
+				total += n
+				reenc, err := dec.MarshalBinarySized()
+				require.NoError(t, err)
+				assert.Equal(t, int64(len(reenc)), n, name)
+			}
+			assert.Equal(t, int64(stream.Len()), total)
+
+			var dec tomtypes.TestTypeMessage
+			_, err := dec.ReadMessageFrom(r)
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	tt := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"truncated prefix", "80", "unexpected EOF"},
+		{"truncated message", "0320", "unexpected EOF"},
+		{"prefix overflow", "ffffffffffffffffff02", "overflows"},
+		{"too large", "8080808001", "exceeds the maximum size"},
+		{"invalid message", "0120", "unexpected end of input"},
+		{"embedded message too long", "034a0508", "unexpected end of input"},
+		{"invalid embedded message", "034a0108", "unexpected end of input"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := hex.DecodeString(tc.input)
+			require.NoError(t, err)
+			var msg tomtypes.TestTypeMessage
+			_, err = msg.ReadMessageFrom(bytes.NewReader(b))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+// TestStreamUnknownFields checks that the embedded messages decoded as they
+// are read by ReadMessageFrom keep their unknown fields, like with
+// UnmarshalBinary.
+func TestStreamUnknownFields(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			b, err := v.MarshalBinarySized()
+			require.NoError(t, err)
+
+			var want, got unknown.OldSliceTestTypeMessage
+			require.NoError(t, want.UnmarshalBinarySized(b, 0))
+			n, err := got.ReadMessageFrom(iotest.OneByteReader(bytes.NewReader(b)))
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(b)), n)
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Checks the TypeScript output in golden/result.ts against the test vectors
+// in golden/vectors.json and golden/invalid_vectors.json, which are verified
+// against amino by TestVectors and TestInvalidVectors.
+//
+// Run with: node --experimental-strip-types --test ts/vectors.test.ts
+import { test } from "node:test";
+import assert from "node:assert/strict";
+import { readFileSync } from "node:fs";
+
+import {
+	decodeFloatType,
+	decodeJSONType,
+	decodeTestType,
+	defaultJSONType,
+	encodeFloatType,
+	encodeJSONType,
+	encodeTestType,
+} from "../golden/result.ts";
+
+interface Vector {
+	name: string;
+	type: string;
+	binary: string;
+}
+
+const vectors: Vector[] = JSON.parse(
+	readFileSync(new URL("../golden/vectors.json", import.meta.url), "utf8"),
+);
+
+const invalidVectors: Vector[] = JSON.parse(
+	readFileSync(new URL("../golden/invalid_vectors.json", import.meta.url), "utf8"),
+);
+
+function toHex(b: Uint8Array): string {
+	return Array.from(b, (x) => x.toString(16).padStart(2, "0")).join("");
+}
+
+function fromHex(s: string): Uint8Array {
+	const b = new Uint8Array(s.length / 2);
+	for (let i = 0; i < b.length; i++) {
+		b[i] = parseInt(s.slice(i * 2, i * 2 + 2), 16);
+	}
+	return b;
+}
+
+// reEncode decodes the vector and encodes it again.
+function reEncode(v: Vector): Uint8Array {
+	switch (v.type) {
+		case "TestType":
+			return encodeTestType(decodeTestType(fromHex(v.binary)));
+		case "FloatType":
+			return encodeFloatType(decodeFloatType(fromHex(v.binary)));
+	}
+	throw new Error(`unknown type ${v.type}`);
+}
+
+for (const v of vectors) {
+	test(v.name, () => {
+		// decoding and re-encoding the vector should produce the same bytes.
+		assert.equal(toHex(reEncode(v)), v.binary);
+	});
+}
+
+for (const v of invalidVectors) {
+	test(`invalid ${v.name}`, () => {
+		const decode = v.type === "TestType" ? decodeTestType : decodeJSONType;
+		assert.throws(() => decode(fromHex(v.binary)), /tomino: /);
+	});
+}
+
+test("decoded values", () => {
+	const bytes = vectors.find((v) => v.name === "bytes");
+	assert.deepEqual(decodeTestType(fromHex(bytes!.binary)).Bytes, new Uint8Array([1, 2, 3, 4]));
+
+	const ptr = vectors.find((v) => v.name === "ptr_-1337");
+	assert.equal(decodeTestType(fromHex(ptr!.binary)).IntPtr, -1337n);
+
+	const td = vectors.find((v) => v.name === "time_duration");
+	const msg = decodeTestType(fromHex(td!.binary));
+	assert.equal(msg.Time.seconds, 900000n);
+	assert.equal(msg.Duration.seconds, BigInt.asUintN(64, -1337n));
+	assert.equal(msg.Duration.nanoseconds, 1);
+});
+
+test("floats", () => {
+	const values = vectors.find((v) => v.name === "float_values");
+	const msg = decodeFloatType(fromHex(values!.binary));
+	assert.equal(msg.Float, 3.14159);
+	assert.equal(msg.Float32, -2.5);
+	assert.equal(msg.FloatPtr, 1e300);
+	assert.deepEqual(msg.Floats, [1, 0, -0.5]);
+	assert.deepEqual(msg.FloatArr, [Infinity, -Infinity]);
+
+	const nz = decodeFloatType(fromHex(vectors.find((v) => v.name === "float_neg_zero")!.binary));
+	assert.ok(Object.is(nz.Float, -0));
+	assert.ok(Object.is(nz.Float32, -0));
+	const nan = decodeFloatType(fromHex(vectors.find((v) => v.name === "float_nan")!.binary));
+	assert.ok(Number.isNaN(nan.Float));
+
+	// zero values are written.
+	const empty = vectors.find((v) => v.name === "float_empty");
+	assert.equal(toHex(encodeFloatType(decodeFloatType(new Uint8Array()))), empty!.binary);
+});
+
+test("invalid UTF-8", () => {
+	// amino accepts any bytes in strings, but a JavaScript string can't hold
+	// them.
+	assert.throws(() => decodeJSONType(fromHex("1201ff")), /tomino: invalid UTF-8 string/);
+	const msg = defaultJSONType();
+	msg.name = "\uD800";
+	assert.throws(() => encodeJSONType(msg), /tomino: string with unpaired surrogates/);
+});
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thehowl/tomino/tests/golden/unknown"
+)
+
+// TestUnknownFields checks that the messages written by TestType are kept
+// intact when decoded and re-encoded by OldTestType, which only knows about
+// its first fields.
+func TestUnknownFields(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var old unknown.OldTestTypeMessage
+			require.NoError(t, old.UnmarshalBinary(b))
+			assert.Equal(t, v.Time, old.Time)
+			assert.Equal(t, v.Duration, old.Duration)
+			assert.Equal(t, v.FixedUint, old.FixedUint)
+			assert.Equal(t, v.Byte, old.Byte)
+
+			reenc, err := old.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(b), hex.EncodeToString(reenc))
+		})
+	}
+}
+
+// TestUnknownFieldsNested checks that the unknown fields of the structs
+// contained in a message are kept too: OldSliceTestType doesn't know about
+// the field B of the elements of Slice.
+func TestUnknownFieldsNested(t *testing.T) {
+	tm := compatMessages()
+
+	for _, name := range sortedMapKeys(tm) {
+		v := tm[name]
+		t.Run(name, func(t *testing.T) {
+			b, err := v.MarshalBinary()
+			require.NoError(t, err)
+
+			var old unknown.OldSliceTestTypeMessage
+			require.NoError(t, old.UnmarshalBinary(b))
+			assert.Empty(t, old.XXX_unknown)
+			require.Len(t, old.Slice, len(v.Slice))
+			for i, el := range v.Slice {
+				assert.Equal(t, el.A, old.Slice[i].A)
+			}
+
+			reenc, err := old.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, hex.EncodeToString(b), hex.EncodeToString(reenc))
+		})
+	}
+}
+
+func TestUnknownFieldsOrder(t *testing.T) {
+	tt := []struct {
+		name    string
+		unknown string
+		want    string
+	}{
+		// the known fields are numbered from 1, so the unknown ones, like 5
+		// and 10, are always written after them.
+		{"after", "5001", "2001" + "5001"},
+		{"several", "2a0102" + "5001", "2001" + "2a0102" + "5001"},
+		// malformed records are written at the end.
+		{"malformed", "ff", "2001" + "ff"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := hex.DecodeString(tc.unknown)
+			require.NoError(t, err)
+			msg := unknown.OldTestTypeMessage{Byte: 1, XXX_unknown: u}
+			b, err := msg.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, hex.EncodeToString(b))
+		})
+	}
+
+	// unknown fields are not part of the JSON encoding.
+	msg := unknown.OldTestTypeMessage{XXX_unknown: []byte{0x50, 0x01}}
+	b, err := msg.MarshalJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "XXX")
+}
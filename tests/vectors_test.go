@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tomtypes "github.com/thehowl/tomino/tests/golden"
+)
+
+var update = flag.Bool("update", false, "update the test vectors in "+vectorsFile+" and "+invalidVectorsFile)
+
+const (
+	vectorsFile        = "golden/vectors.json"
+	invalidVectorsFile = "golden/invalid_vectors.json"
+	// messages encoded in more bytes are not added to the vectors, to keep
+	// the file small.
+	maxVectorSize = 4096
+)
+
+// vector is a message, together with its binary encoding, used to test the
+// targets for languages other than Go.
+type vector struct {
+	Name string `json:"name"`
+	// Type of the message, as named in the generated code.
+	Type string `json:"type"`
+	// Binary encoding of the message, hex-encoded.
+	Binary string `json:"binary"`
+}
+
+// TestVectors checks that the test vectors match the encoding produced by
+// amino and tomino for compatMessages and floatCompatMessages.
+// Run with -update to regenerate them.
+func TestVectors(t *testing.T) {
+	var vectors []vector
+	tm := compatMessages()
+	for _, name := range sortedMapKeys(tm) {
+		vectors = appendVector(t, vectors, name, "TestType", tm[name])
+	}
+	ftm := floatCompatMessages()
+	for _, name := range sortedMapKeys(ftm) {
+		vectors = appendVector(t, vectors, name, "FloatType", ftm[name])
+	}
+
+	if *update {
+		data, err := json.MarshalIndent(vectors, "", "\t")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(vectorsFile, append(data, '\n'), 0o644))
+		return
+	}
+
+	data, err := os.ReadFile(vectorsFile)
+	require.NoError(t, err)
+	var existing []vector
+	require.NoError(t, json.Unmarshal(data, &existing))
+	assert.Equal(t, vectors, existing, "test vectors are out of date; re-run with -update")
+}
+
+// appendVector appends the vector for v to vectors, after checking that amino
+// and tomino encode it in the same way.
+func appendVector(t *testing.T, vectors []vector, name, typ string, v encoding.BinaryMarshaler) []vector {
+	t.Helper()
+
+	aminoRes, err := amino.Marshal(v)
+	require.NoError(t, err)
+	tominoRes, err := v.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(aminoRes), hex.EncodeToString(tominoRes), "%s: tomino and amino encodings differ", name)
+
+	if len(aminoRes) > maxVectorSize {
+		return vectors
+	}
+	return append(vectors, vector{
+		Name:   name,
+		Type:   typ,
+		Binary: hex.EncodeToString(aminoRes),
+	})
+}
+
+// invalidVectors returns encodings which amino refuses to decode, as their
+// values are out of the range of their types. The targets must refuse to
+// decode them as well.
+func invalidVectors() []vector {
+	return []vector{
+		{Name: "uint8_overflow", Type: "TestType", Binary: "208002"},
+		{Name: "int8_overflow", Type: "JSONType", Binary: "088002"},
+		{Name: "int8_underflow", Type: "JSONType", Binary: "088102"},
+		{Name: "int16_overflow", Type: "JSONType", Binary: "5203808004"},
+		{Name: "uint16_overflow", Type: "JSONType", Binary: "620400808004"},
+		{Name: "int32_overflow", Type: "JSONType", Binary: "188080808010"},
+		// bools are a single byte, 0 or 1.
+		{Name: "bool_2", Type: "JSONType", Binary: "3002"},
+		{Name: "bool_non_minimal", Type: "JSONType", Binary: "308100"},
+		{Name: "bool_nested", Type: "JSONType", Binary: "8201020802"},
+	}
+}
+
+// TestInvalidVectors checks that amino and tomino refuse to decode the
+// invalid test vectors, and that they match the ones in invalidVectorsFile.
+// Run with -update to regenerate them.
+func TestInvalidVectors(t *testing.T) {
+	vectors := invalidVectors()
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			b, err := hex.DecodeString(v.Binary)
+			require.NoError(t, err)
+			var aminoDec, tominoDec encoding.BinaryUnmarshaler
+			switch v.Type {
+			case "TestType":
+				aminoDec, tominoDec = new(tomtypes.TestTypeMessage), new(tomtypes.TestTypeMessage)
+			case "JSONType":
+				aminoDec, tominoDec = new(tomtypes.JSONTypeMessage), new(tomtypes.JSONTypeMessage)
+			default:
+				t.Fatalf("unknown type %s", v.Type)
+			}
+			assert.Error(t, amino.Unmarshal(b, aminoDec), "amino")
+			assert.Error(t, tominoDec.UnmarshalBinary(b), "tomino")
+		})
+	}
+
+	if *update {
+		data, err := json.MarshalIndent(vectors, "", "\t")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(invalidVectorsFile, append(data, '\n'), 0o644))
+		return
+	}
+
+	data, err := os.ReadFile(invalidVectorsFile)
+	require.NoError(t, err)
+	var existing []vector
+	require.NoError(t, json.Unmarshal(data, &existing))
+	assert.Equal(t, vectors, existing, "test vectors are out of date; re-run with -update")
+}